@@ -29,6 +29,7 @@ import (
 	"gopkg.in/natefinch/lumberjack.v2"
 
 	"github.com/apache/apisix-ingress-controller/pkg/config"
+	"github.com/apache/apisix-ingress-controller/pkg/id"
 	"github.com/apache/apisix-ingress-controller/pkg/log"
 	controller "github.com/apache/apisix-ingress-controller/pkg/providers"
 	"github.com/apache/apisix-ingress-controller/pkg/version"
@@ -185,9 +186,18 @@ For example, no available LB exists in the bare metal environment.`)
 	cmd.PersistentFlags().StringVar(&cfg.APISIX.DefaultClusterBaseURL, "default-apisix-cluster-base-url", "", "the base URL of admin api / manager api for the default APISIX cluster")
 	cmd.PersistentFlags().StringVar(&cfg.APISIX.DefaultClusterAdminKey, "default-apisix-cluster-admin-key", "", "admin key used for the authorization of admin api / manager api for the default APISIX cluster")
 	cmd.PersistentFlags().StringVar(&cfg.APISIX.DefaultClusterName, "default-apisix-cluster-name", "default", "name of the default apisix cluster")
+	cmd.PersistentFlags().StringVar(&cfg.APISIX.DefaultClusterControlAPIBaseURL, "default-apisix-cluster-control-api-base-url", "", "the base URL of control api for the default APISIX cluster, which provides the health status of upstream nodes, empty means not to fetch the health status")
 	cmd.PersistentFlags().BoolVar(&cfg.Kubernetes.EnableAdmission, "enable-admission", false, "can verify crd resources")
+	cmd.PersistentFlags().StringVar(&cfg.Kubernetes.DefaultIngressClassName, "default-ingress-class-name", "", "the ingressClassName injected by the mutating admission webhook into APISIX custom resources which don't specify one, empty means not to inject")
+	cmd.PersistentFlags().BoolVar(&cfg.Kubernetes.TopologyAwareRouting, "topology-aware-routing", false, "prefer the endpoints in the same zone as APISIX and fall back to other zones only when the local endpoints are unavailable, can be overridden by ApisixUpstream")
+	cmd.PersistentFlags().StringVar(&cfg.Kubernetes.TopologyZone, "topology-zone", "", "the zone where APISIX runs, required by the topology aware routing")
+	cmd.PersistentFlags().StringVar(&cfg.Kubernetes.RouteConflictPolicy, "route-conflict-policy", config.RouteConflictPolicyWarn, "how the admission webhook handles routes which duplicate or shadow the routes of other objects, can be \"ignore\", \"warn\" or \"deny\"")
 	cmd.PersistentFlags().DurationVar(&cfg.ApisixResourceSyncInterval.Duration, "apisix-resource-sync-interval", 1*time.Hour, "interval of periodic sync in seconds. Default value is 1h. Set to 0 to disable. Min is 60s.")
 	cmd.PersistentFlags().BoolVar(&cfg.ApisixResourceSyncComparison, "apisix-resource-sync-comparison", true, "enable comparison in periodic sync")
+	cmd.PersistentFlags().DurationVar(&cfg.HealthStatusSyncInterval.Duration, "health-status-sync-interval", 30*time.Second, "interval to fetch the health status of upstream nodes from the control api of APISIX")
+	cmd.PersistentFlags().BoolVar(&cfg.HealthStatusEvents, "health-status-events", false, "emit events on ApisixUpstream naming the pods which APISIX considers unhealthy")
+	cmd.PersistentFlags().StringVar(&cfg.ResourceIDScheme, "resource-id-scheme", id.SchemeCRC32, "the scheme to generate IDs of APISIX resources, can be \"crc32\" or \"sha256\", switching from \"crc32\" to \"sha256\" should be done with --resource-id-migration")
+	cmd.PersistentFlags().BoolVar(&cfg.ResourceIDMigration, "resource-id-migration", false, "remove the APISIX resources created with the crc32 IDs after their replacements are created with the new resource id scheme")
 	cmd.PersistentFlags().StringVar(&cfg.PluginMetadataConfigMap, "plugin-metadata-cm", "plugin-metadata-config-map", "ConfigMap name of plugin metadata.")
 	cmd.PersistentFlags().BoolVar(&cfg.EtcdServer.Enabled, "etcd-server-enabled", false, "enable etcd server")
 	cmd.PersistentFlags().StringVar(&cfg.EtcdServer.ListenAddress, "etcd-server-listen-address", ":12379", "etcd server listen address")
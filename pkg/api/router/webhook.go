@@ -28,5 +28,6 @@ func MountWebhooks(r *gin.Engine, co *apisix.ClusterOptions) {
 	_, _ = validation.GetSchemaClient(co)
 
 	r.POST("/validate", validation.NewHandlerFunc("apisix", validation.Validator))
+	r.POST("/mutate", validation.NewMutatingHandlerFunc("apisix", validation.Mutator))
 
 }
@@ -27,6 +27,7 @@ import (
 	"go.uber.org/zap"
 
 	apirouter "github.com/apache/apisix-ingress-controller/pkg/api/router"
+	"github.com/apache/apisix-ingress-controller/pkg/api/validation"
 	"github.com/apache/apisix-ingress-controller/pkg/apisix"
 	"github.com/apache/apisix-ingress-controller/pkg/config"
 	"github.com/apache/apisix-ingress-controller/pkg/log"
@@ -80,6 +81,8 @@ func NewServer(cfg *config.Config) (*Server, error) {
 				zap.String("KeyFilePath", cfg.KeyFilePath),
 			)
 		} else {
+			validation.GetRouteIndex().SetPolicy(cfg.Kubernetes.RouteConflictPolicy)
+			validation.SetDefaultIngressClassName(cfg.Kubernetes.DefaultIngressClassName)
 			admission := gin.New()
 			admission.Use(gin.Recovery(), gin.Logger())
 			apirouter.MountWebhooks(admission, &apisix.ClusterOptions{
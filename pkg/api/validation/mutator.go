@@ -0,0 +1,155 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	kwhmodel "github.com/slok/kubewebhook/v2/pkg/model"
+	kwhmutating "github.com/slok/kubewebhook/v2/pkg/webhook/mutating"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v2 "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/apis/config/v2"
+	"github.com/apache/apisix-ingress-controller/pkg/log"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/translation"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+var (
+	defaultIngressClassMu   sync.RWMutex
+	defaultIngressClassName string
+)
+
+// SetDefaultIngressClassName sets the ingressClassName injected into the
+// APISIX custom resources which don't specify one, an empty name disables
+// the injection.
+func SetDefaultIngressClassName(name string) {
+	defaultIngressClassMu.Lock()
+	defer defaultIngressClassMu.Unlock()
+	defaultIngressClassName = name
+}
+
+func getDefaultIngressClassName() string {
+	defaultIngressClassMu.RLock()
+	defer defaultIngressClassMu.RUnlock()
+	return defaultIngressClassName
+}
+
+// Mutator fills in the defaults which are otherwise applied implicitly by
+// the translators, and normalizes the APISIX custom resources, so that the
+// stored objects reflect what runs in APISIX.
+var Mutator = kwhmutating.MutatorFunc(
+	func(ctx context.Context, review *kwhmodel.AdmissionReview, object metav1.Object) (*kwhmutating.MutatorResult, error) {
+		log.Debugw("arrive mutator webhook", zap.Any("object", object))
+
+		switch obj := object.(type) {
+		case *v2.ApisixRoute:
+			mutateApisixRoute(obj)
+		case *v2.ApisixUpstream:
+			mutateApisixUpstream(obj)
+		case *v2.ApisixTls:
+			if obj.Spec != nil {
+				defaultIngressClass(&obj.Spec.IngressClassName)
+			}
+		case *v2.ApisixPluginConfig:
+			defaultIngressClass(&obj.Spec.IngressClassName)
+		}
+		return &kwhmutating.MutatorResult{MutatedObject: object}, nil
+	},
+)
+
+func mutateApisixRoute(ar *v2.ApisixRoute) {
+	defaultIngressClass(&ar.Spec.IngressClassName)
+	for i := range ar.Spec.HTTP {
+		part := &ar.Spec.HTTP[i]
+		for j, method := range part.Match.Methods {
+			// APISIX only accepts upper case methods.
+			part.Match.Methods[j] = strings.ToUpper(method)
+		}
+		normalizeTimeout(part.Timeout)
+		for j := range part.Backends {
+			defaultWeight(&part.Backends[j].Weight)
+		}
+		for j := range part.Upstreams {
+			defaultWeight(&part.Upstreams[j].Weight)
+		}
+	}
+}
+
+func mutateApisixUpstream(au *v2.ApisixUpstream) {
+	if au.Spec == nil {
+		return
+	}
+	defaultIngressClass(&au.Spec.IngressClassName)
+	for i := range au.Spec.ExternalNodes {
+		defaultWeight(&au.Spec.ExternalNodes[i].Weight)
+	}
+	mutateApisixUpstreamConfig(&au.Spec.ApisixUpstreamConfig)
+	for i := range au.Spec.PortLevelSettings {
+		mutateApisixUpstreamConfig(&au.Spec.PortLevelSettings[i].ApisixUpstreamConfig)
+	}
+}
+
+func mutateApisixUpstreamConfig(config *v2.ApisixUpstreamConfig) {
+	if config.LoadBalancer != nil && config.LoadBalancer.Type == "" {
+		config.LoadBalancer.Type = apisixv1.LbRoundRobin
+	}
+	normalizeTimeout(config.Timeout)
+	if config.HealthCheck != nil && config.HealthCheck.Active != nil {
+		truncateDuration(&config.HealthCheck.Active.Healthy.Interval)
+		truncateDuration(&config.HealthCheck.Active.Unhealthy.Interval)
+	}
+}
+
+func defaultIngressClass(ingressClassName *string) {
+	if *ingressClassName == "" {
+		*ingressClassName = getDefaultIngressClassName()
+	}
+}
+
+func defaultWeight(weight **int) {
+	if *weight == nil {
+		w := translation.DefaultWeight
+		*weight = &w
+	}
+}
+
+// normalizeTimeout fills in the omitted items with the default timeout,
+// since APISIX requires all of them once the timeout is configured.
+func normalizeTimeout(timeout *v2.UpstreamTimeout) {
+	if timeout == nil {
+		return
+	}
+	for _, d := range []*metav1.Duration{&timeout.Connect, &timeout.Send, &timeout.Read} {
+		if d.Duration == 0 {
+			d.Duration = apisixv1.DefaultUpstreamTimeout * time.Second
+		}
+		truncateDuration(d)
+	}
+}
+
+// truncateDuration rounds the duration down to seconds, which is the unit
+// used by APISIX. Durations less than a second are left as is since they
+// would become zero, which means the default value.
+func truncateDuration(d *metav1.Duration) {
+	if d.Duration >= time.Second {
+		d.Duration = d.Duration.Truncate(time.Second)
+	}
+}
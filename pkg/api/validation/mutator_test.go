@@ -0,0 +1,142 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	kwhmodel "github.com/slok/kubewebhook/v2/pkg/model"
+	kwhmutating "github.com/slok/kubewebhook/v2/pkg/webhook/mutating"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v2 "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/apis/config/v2"
+	apisixtranslation "github.com/apache/apisix-ingress-controller/pkg/providers/apisix/translation"
+)
+
+func TestMutateApisixRoute(t *testing.T) {
+	SetDefaultIngressClassName("apisix")
+	defer SetDefaultIngressClassName("")
+
+	raw := []byte(`{
+  "apiVersion": "apisix.apache.org/v2",
+  "kind": "ApisixRoute",
+  "metadata": {"name": "foo", "namespace": "default"},
+  "spec": {
+    "http": [
+      {
+        "name": "rule1",
+        "match": {"paths": ["/*"], "methods": ["get", "Post"]},
+        "timeout": {"read": "1500ms"},
+        "backends": [{"serviceName": "svc", "servicePort": 80}],
+        "plugins": [
+          {"name": "cors"},
+          {"name": "csrf", "enable": false}
+        ]
+      }
+    ]
+  }
+}`)
+	wh, err := kwhmutating.NewWebhook(kwhmutating.WebhookConfig{
+		ID:      "test",
+		Obj:     &v2.ApisixRoute{},
+		Mutator: Mutator,
+	})
+	assert.Nil(t, err)
+	resp, err := wh.Review(context.Background(), kwhmodel.AdmissionReview{
+		Operation:    kwhmodel.OperationCreate,
+		NewObjectRaw: raw,
+	})
+	assert.Nil(t, err)
+
+	var patch []map[string]interface{}
+	assert.Nil(t, json.Unmarshal(resp.(*kwhmodel.MutatingAdmissionResponse).JSONPatchPatch, &patch))
+	ops := make(map[string]interface{})
+	for _, op := range patch {
+		ops[op["path"].(string)] = op["value"]
+	}
+	assert.Equal(t, "apisix", ops["/spec/ingressClassName"])
+	assert.Equal(t, "GET", ops["/spec/http/0/match/methods/0"])
+	assert.Equal(t, "POST", ops["/spec/http/0/match/methods/1"])
+	assert.Equal(t, "1s", ops["/spec/http/0/timeout/read"])
+	assert.Equal(t, "1m0s", ops["/spec/http/0/timeout/connect"])
+	assert.Equal(t, "1m0s", ops["/spec/http/0/timeout/send"])
+	assert.Equal(t, float64(100), ops["/spec/http/0/backends/0/weight"])
+	// The plugin without enable field is disabled as the translators treat it.
+	assert.Equal(t, false, ops["/spec/http/0/plugins/0/enable"])
+	_, ok := ops["/spec/http/0/plugins/1/enable"]
+	assert.False(t, ok, "explicitly disabled plugin should be kept")
+}
+
+func TestMutateApisixPluginConfigTranslation(t *testing.T) {
+	raw := []byte(`{
+  "apiVersion": "apisix.apache.org/v2",
+  "kind": "ApisixPluginConfig",
+  "metadata": {"name": "foo", "namespace": "default"},
+  "spec": {
+    "plugins": [
+      {"name": "cors"},
+      {"name": "csrf", "enable": false},
+      {"name": "key-auth", "enable": true}
+    ]
+  }
+}`)
+	var apc v2.ApisixPluginConfig
+	assert.Nil(t, json.Unmarshal(raw, &apc))
+	res, err := Mutator.Mutate(context.Background(), &kwhmodel.AdmissionReview{NewObjectRaw: raw}, apc.DeepCopy())
+	assert.Nil(t, err)
+
+	tr := apisixtranslation.NewApisixTranslator(&apisixtranslation.TranslatorOptions{}, nil)
+	before, err := tr.TranslatePluginConfigV2(&apc)
+	assert.Nil(t, err)
+	after, err := tr.TranslatePluginConfigV2(res.MutatedObject.(*v2.ApisixPluginConfig))
+	assert.Nil(t, err)
+	assert.Equal(t, before.PluginConfigs, after.PluginConfigs)
+	assert.Len(t, after.PluginConfigs[0].Plugins, 1)
+}
+
+func TestMutateApisixUpstream(t *testing.T) {
+	au := &v2.ApisixUpstream{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "svc",
+			Namespace: "default",
+		},
+		Spec: &v2.ApisixUpstreamSpec{
+			IngressClassName: "custom",
+			ExternalNodes: []v2.ApisixUpstreamExternalNode{
+				{Name: "httpbin.org", Type: v2.ExternalTypeDomain},
+			},
+			ApisixUpstreamConfig: v2.ApisixUpstreamConfig{
+				LoadBalancer: &v2.LoadBalancer{},
+				Timeout: &v2.UpstreamTimeout{
+					Connect: metav1.Duration{Duration: 5*time.Second + 300*time.Millisecond},
+				},
+			},
+		},
+	}
+	res, err := Mutator.Mutate(context.Background(), &kwhmodel.AdmissionReview{}, au)
+	assert.Nil(t, err)
+	au = res.MutatedObject.(*v2.ApisixUpstream)
+	assert.Equal(t, "custom", au.Spec.IngressClassName)
+	assert.Equal(t, 100, *au.Spec.ExternalNodes[0].Weight)
+	assert.Equal(t, "roundrobin", au.Spec.LoadBalancer.Type)
+	assert.Equal(t, 5*time.Second, au.Spec.Timeout.Connect.Duration)
+	assert.Equal(t, 60*time.Second, au.Spec.Timeout.Read.Duration)
+	assert.Equal(t, 60*time.Second, au.Spec.Timeout.Send.Duration)
+}
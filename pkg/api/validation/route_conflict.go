@@ -0,0 +1,575 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	listersnetworkingv1 "k8s.io/client-go/listers/networking/v1"
+	"k8s.io/client-go/tools/cache"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/apache/apisix-ingress-controller/pkg/config"
+	"github.com/apache/apisix-ingress-controller/pkg/kube"
+	v2 "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/apis/config/v2"
+	ingresstranslation "github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/utils"
+)
+
+const (
+	_ingressClassAnnotation = "kubernetes.io/ingress.class"
+	_ingressRegexAnnotation = "k8s.apisix.apache.org/use-regex"
+	_ingressRegexPriority   = 100
+)
+
+// indexedRoute is the effective match conditions of a route in APISIX.
+type indexedRoute struct {
+	// Name is the rule of the owner which generates the route.
+	Name  string
+	Hosts []string
+	URI   string
+	// Methods is empty if the route matches all methods.
+	Methods []string
+	// Vars is the canonical form of the other match conditions, routes with
+	// different Vars are considered as distinguishable.
+	Vars     string
+	Priority int
+}
+
+// RouteIndex indexes the routes generated by ApisixRoutes, Ingresses and
+// HTTPRoutes in the informer caches, so that the admission webhook can find
+// the routes which are duplicated or shadowed by a new object.
+type RouteIndex struct {
+	ingressClass string
+	policy       string
+	// ingressClassLister is used to find out if the IngressClass of the
+	// controller is the default one, nil if IngressClass isn't watched.
+	ingressClassLister listersnetworkingv1.IngressClassLister
+
+	mu sync.RWMutex
+	// owner (Kind/namespace/name) -> routes
+	routes map[string][]*indexedRoute
+}
+
+var routeIndex = &RouteIndex{
+	ingressClass: config.IngressClassApisixAndAll,
+	policy:       config.RouteConflictPolicyWarn,
+	routes:       make(map[string][]*indexedRoute),
+}
+
+// GetRouteIndex returns the RouteIndex used by the admission webhook.
+func GetRouteIndex() *RouteIndex {
+	return routeIndex
+}
+
+// SetIngressClass sets the ingress class of the controller, objects of the
+// other classes are not indexed.
+func (idx *RouteIndex) SetIngressClass(ingressClass string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.ingressClass = ingressClass
+}
+
+// SetIngressClassLister sets the lister of IngressClass, so that Ingresses
+// without class are indexed if the IngressClass of the controller is the
+// default one.
+func (idx *RouteIndex) SetIngressClassLister(lister listersnetworkingv1.IngressClassLister) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.ingressClassLister = lister
+}
+
+// SetPolicy sets how to handle the conflicts, see config.RouteConflictPolicyWarn.
+func (idx *RouteIndex) SetPolicy(policy string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.policy = policy
+}
+
+// Policy returns how to handle the conflicts.
+func (idx *RouteIndex) Policy() string {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.policy
+}
+
+func (idx *RouteIndex) update(owner string, routes []*indexedRoute) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if len(routes) == 0 {
+		delete(idx.routes, owner)
+		return
+	}
+	idx.routes[owner] = routes
+}
+
+func (idx *RouteIndex) delete(owner string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	delete(idx.routes, owner)
+}
+
+// routeConflict describes an indexed route which matches some of the same
+// requests as a new route.
+type routeConflict struct {
+	Description string
+	// Partial means the URIs of the routes differ and one of them only covers
+	// a part of the other, APISIX always prefers the more specific URI.
+	Partial bool
+}
+
+// Conflicts returns the indexed routes owned by the other objects which are
+// duplicated or shadowed by the routes.
+func (idx *RouteIndex) Conflicts(owner string, routes []*indexedRoute) []routeConflict {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	owners := make([]string, 0, len(idx.routes))
+	for o := range idx.routes {
+		if o != owner {
+			owners = append(owners, o)
+		}
+	}
+	sort.Strings(owners)
+
+	var conflicts []routeConflict
+	for _, route := range routes {
+		for _, o := range owners {
+			for _, existing := range idx.routes[o] {
+				relation, partial, ok := conflictRelation(route, existing)
+				if !ok {
+					continue
+				}
+				conflicts = append(conflicts, routeConflict{
+					Description: fmt.Sprintf("rule %q %s rule %q of %s (uri: %s, hosts: %v, methods: %v, priority: %d)",
+						route.Name, relation, existing.Name, o, existing.URI, existing.Hosts, existing.Methods, existing.Priority),
+					Partial: partial,
+				})
+			}
+		}
+	}
+	return conflicts
+}
+
+// conflictRelation checks whether the route can match some of the same
+// requests as the existing one, which means only one of them takes effect
+// for these requests in APISIX. For the same URI, the route with the higher
+// priority takes effect, otherwise the more specific URI takes effect
+// regardless of the priorities.
+func conflictRelation(route, existing *indexedRoute) (relation string, partial bool, ok bool) {
+	if route.Vars != existing.Vars {
+		return "", false, false
+	}
+	if !hostsIntersect(route.Hosts, existing.Hosts) || !methodsIntersect(route.Methods, existing.Methods) {
+		return "", false, false
+	}
+	if route.URI != existing.URI {
+		switch {
+		case uriCovers(existing.URI, route.URI):
+			return "shadows part of", true, true
+		case uriCovers(route.URI, existing.URI):
+			return "is partially shadowed by", true, true
+		}
+		return "", false, false
+	}
+	switch {
+	case route.Priority > existing.Priority:
+		return "shadows", false, true
+	case route.Priority < existing.Priority:
+		return "is shadowed by", false, true
+	}
+	if sameSet(route.Hosts, existing.Hosts) && sameSet(route.Methods, existing.Methods) {
+		return "duplicates", false, true
+	}
+	return "overlaps with", false, true
+}
+
+// uriCovers reports whether the URI with a trailing wildcard matches all the
+// requests matched by the other URI.
+func uriCovers(wildcard, uri string) bool {
+	if !strings.HasSuffix(wildcard, "*") {
+		return false
+	}
+	return strings.HasPrefix(strings.TrimSuffix(uri, "*"), strings.TrimSuffix(wildcard, "*"))
+}
+
+func hostsIntersect(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	for _, x := range a {
+		for _, y := range b {
+			if _, ok := utils.IntersectHostname(x, y); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func methodsIntersect(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	for _, x := range a {
+		for _, y := range b {
+			if strings.EqualFold(x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sameSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[string]struct{}, len(a))
+	for _, x := range a {
+		set[strings.ToLower(x)] = struct{}{}
+	}
+	for _, y := range b {
+		if _, ok := set[strings.ToLower(y)]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func canonicalVars(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func ownerKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// apisixRouteOwner returns the key of the ApisixRoute in the index.
+func apisixRouteOwner(ar *v2.ApisixRoute) string {
+	return ownerKey("ApisixRoute", ar.Namespace, ar.Name)
+}
+
+// ingressOwnerKey returns the key of the Ingress in the index.
+func ingressOwnerKey(namespace, name string) string {
+	return ownerKey("Ingress", namespace, name)
+}
+
+// httpRouteOwner returns the key of the HTTPRoute in the index.
+func httpRouteOwner(hr *gatewayv1beta1.HTTPRoute) string {
+	return ownerKey("HTTPRoute", hr.Namespace, hr.Name)
+}
+
+func apisixRouteRoutes(ar *v2.ApisixRoute) []*indexedRoute {
+	var routes []*indexedRoute
+	for _, rule := range ar.Spec.HTTP {
+		var vars string
+		if len(rule.Match.NginxVars) > 0 || len(rule.Match.RemoteAddrs) > 0 || rule.Match.FilterFunc != "" {
+			vars = canonicalVars(struct {
+				Exprs       []v2.ApisixRouteHTTPMatchExpr
+				RemoteAddrs []string
+				FilterFunc  string
+			}{rule.Match.NginxVars, rule.Match.RemoteAddrs, rule.Match.FilterFunc})
+		}
+		for _, path := range rule.Match.Paths {
+			routes = append(routes, &indexedRoute{
+				Name:     rule.Name,
+				Hosts:    rule.Match.Hosts,
+				URI:      path,
+				Methods:  rule.Match.Methods,
+				Vars:     vars,
+				Priority: rule.Priority,
+			})
+		}
+	}
+	return routes
+}
+
+// ingressPathRoutes follows the translation of the Ingress paths.
+func ingressPathRoutes(host, path string, prefix, regex bool) []*indexedRoute {
+	name := host + path
+	if regex {
+		return []*indexedRoute{{
+			Name:     name,
+			URI:      "/*",
+			Hosts:    hostsOf(host),
+			Vars:     canonicalVars(path),
+			Priority: _ingressRegexPriority,
+		}}
+	}
+	routes := []*indexedRoute{{Name: name, URI: path, Hosts: hostsOf(host)}}
+	if prefix {
+		if strings.HasSuffix(path, "/") {
+			path += "*"
+		} else {
+			path += "/*"
+		}
+		routes = append(routes, &indexedRoute{Name: name, URI: path, Hosts: hostsOf(host)})
+	}
+	return routes
+}
+
+func hostsOf(host string) []string {
+	if host == "" {
+		return nil
+	}
+	return []string{host}
+}
+
+func ingressRoutes(ing kube.Ingress) []*indexedRoute {
+	var routes []*indexedRoute
+	switch ing.GroupVersion() {
+	case kube.IngressV1:
+		obj := ing.V1()
+		useRegex := obj.Annotations[_ingressRegexAnnotation] == "true"
+		for _, rule := range obj.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			for _, path := range rule.HTTP.Paths {
+				prefix := path.PathType != nil && *path.PathType == networkingv1.PathTypePrefix
+				regex := path.PathType != nil && *path.PathType == networkingv1.PathTypeImplementationSpecific && useRegex
+				routes = append(routes, ingressPathRoutes(rule.Host, path.Path, prefix, regex)...)
+			}
+		}
+	case kube.IngressV1beta1:
+		obj := ing.V1beta1()
+		useRegex := obj.Annotations[_ingressRegexAnnotation] == "true"
+		for _, rule := range obj.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			for _, path := range rule.HTTP.Paths {
+				prefix := path.PathType != nil && *path.PathType == networkingv1beta1.PathTypePrefix
+				regex := path.PathType != nil && *path.PathType == networkingv1beta1.PathTypeImplementationSpecific && useRegex
+				routes = append(routes, ingressPathRoutes(rule.Host, path.Path, prefix, regex)...)
+			}
+		}
+	}
+	return routes
+}
+
+func httpRouteRoutes(hr *gatewayv1beta1.HTTPRoute) []*indexedRoute {
+	hosts := make([]string, 0, len(hr.Spec.Hostnames))
+	for _, host := range hr.Spec.Hostnames {
+		hosts = append(hosts, string(host))
+	}
+	var routes []*indexedRoute
+	for i, rule := range hr.Spec.Rules {
+		matches := rule.Matches
+		if len(matches) == 0 {
+			matches = []gatewayv1beta1.HTTPRouteMatch{{}}
+		}
+		for _, match := range matches {
+			route := &indexedRoute{
+				Name:  fmt.Sprintf("rules[%d]", i),
+				Hosts: hosts,
+			}
+			if len(match.Headers) > 0 || len(match.QueryParams) > 0 {
+				route.Vars = canonicalVars([]interface{}{match.Headers, match.QueryParams})
+			}
+			if match.Path != nil && match.Path.Value != nil {
+				pathType := gatewayv1beta1.PathMatchPathPrefix
+				if match.Path.Type != nil {
+					pathType = *match.Path.Type
+				}
+				switch pathType {
+				case gatewayv1beta1.PathMatchExact:
+					route.URI = *match.Path.Value
+				case gatewayv1beta1.PathMatchPathPrefix:
+					route.URI = *match.Path.Value + "*"
+				default:
+					route.Vars = canonicalVars([]interface{}{match.Path.Value, match.Headers, match.QueryParams})
+				}
+			}
+			if match.Method != nil {
+				route.Methods = []string{string(*match.Method)}
+			}
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
+
+func (idx *RouteIndex) isApisixRouteEffective(ar *v2.ApisixRoute) bool {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return utils.MatchCRDsIngressClass(ar.Spec.IngressClassName, idx.ingressClass)
+}
+
+func (idx *RouteIndex) isIngressEffective(ing kube.Ingress) bool {
+	idx.mu.RLock()
+	configIngressClass := idx.ingressClass
+	ingressClassLister := idx.ingressClassLister
+	idx.mu.RUnlock()
+
+	var (
+		ic  *string
+		ica string
+	)
+	switch ing.GroupVersion() {
+	case kube.IngressV1:
+		ic = ing.V1().Spec.IngressClassName
+		ica = ing.V1().GetAnnotations()[_ingressClassAnnotation]
+	case kube.IngressV1beta1:
+		ic = ing.V1beta1().Spec.IngressClassName
+		ica = ing.V1beta1().GetAnnotations()[_ingressClassAnnotation]
+	}
+	if configIngressClass == config.IngressClassApisixAndAll {
+		configIngressClass = config.IngressClass
+	}
+	if ica != "" {
+		return ica == configIngressClass
+	}
+	if ic != nil {
+		return *ic == configIngressClass
+	}
+	// Ingresses without class belong to the default IngressClass.
+	if ingressClassLister == nil {
+		return false
+	}
+	ingressClass, err := ingressClassLister.Get(configIngressClass)
+	if err != nil {
+		return false
+	}
+	return ingressClass.Annotations[networkingv1.AnnotationIsDefaultIngressClass] == "true"
+}
+
+func unwrapTombstone(obj interface{}) interface{} {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		return tombstone.Obj
+	}
+	return obj
+}
+
+// ApisixRouteEventHandler returns the handler which indexes ApisixRoutes
+// from the informer.
+func (idx *RouteIndex) ApisixRouteEventHandler() cache.ResourceEventHandler {
+	onUpdate := func(obj interface{}) {
+		ar, ok := unwrapTombstone(obj).(*v2.ApisixRoute)
+		if !ok {
+			return
+		}
+		if !idx.isApisixRouteEffective(ar) {
+			idx.delete(apisixRouteOwner(ar))
+			return
+		}
+		idx.update(apisixRouteOwner(ar), apisixRouteRoutes(ar))
+	}
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: onUpdate,
+		UpdateFunc: func(_, obj interface{}) {
+			onUpdate(obj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if ar, ok := unwrapTombstone(obj).(*v2.ApisixRoute); ok {
+				idx.delete(apisixRouteOwner(ar))
+			}
+		},
+	}
+}
+
+// IngressEventHandler returns the handler which indexes Ingresses from the
+// informer.
+func (idx *RouteIndex) IngressEventHandler() cache.ResourceEventHandler {
+	onUpdate := func(obj interface{}) {
+		ing, err := kube.NewIngress(unwrapTombstone(obj))
+		if err != nil {
+			return
+		}
+		owner := ingressOwner(ing)
+		// Canary Ingresses share the routes of their primary Ingresses.
+		if !idx.isIngressEffective(ing) || ingresstranslation.IsCanaryIngress(ing) {
+			idx.delete(owner)
+			return
+		}
+		idx.update(owner, ingressRoutes(ing))
+	}
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: onUpdate,
+		UpdateFunc: func(_, obj interface{}) {
+			onUpdate(obj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if ing, err := kube.NewIngress(unwrapTombstone(obj)); err == nil {
+				idx.delete(ingressOwner(ing))
+			}
+		},
+	}
+}
+
+// HTTPRouteEventHandler returns the handler which indexes HTTPRoutes from
+// the informer.
+func (idx *RouteIndex) HTTPRouteEventHandler() cache.ResourceEventHandler {
+	onUpdate := func(obj interface{}) {
+		if hr, ok := unwrapTombstone(obj).(*gatewayv1beta1.HTTPRoute); ok {
+			idx.update(httpRouteOwner(hr), httpRouteRoutes(hr))
+		}
+	}
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: onUpdate,
+		UpdateFunc: func(_, obj interface{}) {
+			onUpdate(obj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if hr, ok := unwrapTombstone(obj).(*gatewayv1beta1.HTTPRoute); ok {
+				idx.delete(httpRouteOwner(hr))
+			}
+		},
+	}
+}
+
+func ingressOwner(ing kube.Ingress) string {
+	switch ing.GroupVersion() {
+	case kube.IngressV1:
+		return ingressOwnerKey(ing.V1().Namespace, ing.V1().Name)
+	case kube.IngressV1beta1:
+		return ingressOwnerKey(ing.V1beta1().Namespace, ing.V1beta1().Name)
+	}
+	return ""
+}
+
+// checkRouteConflicts checks the routes of the object against the index and
+// returns the result according to the policy: if the object should be
+// allowed, and the warnings. Partial conflicts are only warned, since a more
+// specific route under a broader one is usually intended.
+func checkRouteConflicts(owner string, routes []*indexedRoute) (bool, []string) {
+	policy := routeIndex.Policy()
+	if policy == config.RouteConflictPolicyIgnore {
+		return true, nil
+	}
+	var (
+		valid    = true
+		warnings []string
+	)
+	for _, conflict := range routeIndex.Conflicts(owner, routes) {
+		if !conflict.Partial && policy == config.RouteConflictPolicyDeny {
+			valid = false
+		}
+		warnings = append(warnings, conflict.Description)
+	}
+	return valid, warnings
+}
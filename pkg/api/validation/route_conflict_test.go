@@ -0,0 +1,309 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	kwhmodel "github.com/slok/kubewebhook/v2/pkg/model"
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listersnetworkingv1 "k8s.io/client-go/listers/networking/v1"
+	"k8s.io/client-go/tools/cache"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/apache/apisix-ingress-controller/pkg/config"
+	"github.com/apache/apisix-ingress-controller/pkg/kube"
+	v2 "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/apis/config/v2"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations"
+)
+
+func newTestApisixRoute(name string, host, path string, methods []string) *v2.ApisixRoute {
+	return &v2.ApisixRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: v2.ApisixRouteSpec{
+			HTTP: []v2.ApisixRouteHTTP{
+				{
+					Name: "rule1",
+					Match: v2.ApisixRouteHTTPMatch{
+						Hosts:   []string{host},
+						Paths:   []string{path},
+						Methods: methods,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRouteIndexConflicts(t *testing.T) {
+	idx := &RouteIndex{
+		ingressClass: config.IngressClassApisixAndAll,
+		policy:       config.RouteConflictPolicyWarn,
+		routes:       make(map[string][]*indexedRoute),
+	}
+	handler := idx.ApisixRouteEventHandler()
+	handler.OnAdd(newTestApisixRoute("foo", "api.example.com", "/foo*", []string{"GET"}), false)
+
+	pathType := networkingv1.PathTypePrefix
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bar",
+			Namespace: "default",
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: &[]string{config.IngressClass}[0],
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: "api.example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{Path: "/bar", PathType: &pathType},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	idx.IngressEventHandler().OnAdd(ing, false)
+
+	// The same route of the same object doesn't conflict.
+	ar := newTestApisixRoute("foo", "api.example.com", "/foo*", []string{"GET"})
+	assert.Len(t, idx.Conflicts(apisixRouteOwner(ar), apisixRouteRoutes(ar)), 0)
+
+	ar = newTestApisixRoute("foo2", "api.example.com", "/foo*", []string{"GET"})
+	conflicts := idx.Conflicts(apisixRouteOwner(ar), apisixRouteRoutes(ar))
+	assert.Len(t, conflicts, 1)
+	assert.True(t, strings.Contains(conflicts[0].Description, "duplicates"))
+	assert.True(t, strings.Contains(conflicts[0].Description, "ApisixRoute/default/foo"))
+
+	// A wildcard host and all methods overlap with the route.
+	ar = newTestApisixRoute("foo2", "*.example.com", "/foo*", nil)
+	conflicts = idx.Conflicts(apisixRouteOwner(ar), apisixRouteRoutes(ar))
+	assert.Len(t, conflicts, 1)
+	assert.True(t, strings.Contains(conflicts[0].Description, "overlaps with"))
+
+	// Routes matching different methods, hosts or paths don't conflict.
+	ar = newTestApisixRoute("foo2", "api.example.com", "/foo*", []string{"POST"})
+	assert.Len(t, idx.Conflicts(apisixRouteOwner(ar), apisixRouteRoutes(ar)), 0)
+	ar = newTestApisixRoute("foo2", "www.example.com", "/foo*", nil)
+	assert.Len(t, idx.Conflicts(apisixRouteOwner(ar), apisixRouteRoutes(ar)), 0)
+	ar = newTestApisixRoute("foo2", "api.example.com", "/baz", nil)
+	assert.Len(t, idx.Conflicts(apisixRouteOwner(ar), apisixRouteRoutes(ar)), 0)
+
+	// A more specific path shadows a part of the wildcard path.
+	ar = newTestApisixRoute("foo2", "api.example.com", "/foo/bar", nil)
+	conflicts = idx.Conflicts(apisixRouteOwner(ar), apisixRouteRoutes(ar))
+	assert.Len(t, conflicts, 1)
+	assert.True(t, conflicts[0].Partial)
+	assert.True(t, strings.Contains(conflicts[0].Description, "shadows part of"))
+	ar = newTestApisixRoute("foo2", "api.example.com", "/*", nil)
+	conflicts = idx.Conflicts(apisixRouteOwner(ar), apisixRouteRoutes(ar))
+	assert.Len(t, conflicts, 3)
+	for _, conflict := range conflicts {
+		assert.True(t, conflict.Partial)
+		assert.True(t, strings.Contains(conflict.Description, "is partially shadowed by"))
+	}
+
+	// The route with the higher priority shadows the other one.
+	ar = newTestApisixRoute("foo2", "api.example.com", "/foo*", []string{"GET"})
+	ar.Spec.HTTP[0].Priority = 1
+	conflicts = idx.Conflicts(apisixRouteOwner(ar), apisixRouteRoutes(ar))
+	assert.Len(t, conflicts, 1)
+	assert.False(t, conflicts[0].Partial)
+	assert.True(t, strings.Contains(conflicts[0].Description, "shadows rule"))
+	ar.Spec.HTTP[0].Priority = -1
+	conflicts = idx.Conflicts(apisixRouteOwner(ar), apisixRouteRoutes(ar))
+	assert.Len(t, conflicts, 1)
+	assert.True(t, strings.Contains(conflicts[0].Description, "is shadowed by"))
+
+	// The prefix path of the Ingress is translated to an exact and a prefix route.
+	ar = newTestApisixRoute("foo2", "api.example.com", "/bar/*", nil)
+	conflicts = idx.Conflicts(apisixRouteOwner(ar), apisixRouteRoutes(ar))
+	assert.Len(t, conflicts, 1)
+	assert.True(t, strings.Contains(conflicts[0].Description, "Ingress/default/bar"))
+
+	hr := &gatewayv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "baz",
+			Namespace: "default",
+		},
+		Spec: gatewayv1beta1.HTTPRouteSpec{
+			Hostnames: []gatewayv1beta1.Hostname{"api.example.com"},
+			Rules: []gatewayv1beta1.HTTPRouteRule{
+				{
+					Matches: []gatewayv1beta1.HTTPRouteMatch{
+						{
+							Path: &gatewayv1beta1.HTTPPathMatch{
+								Type:  &[]gatewayv1beta1.PathMatchType{gatewayv1beta1.PathMatchExact}[0],
+								Value: &[]string{"/bar"}[0],
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	conflicts = idx.Conflicts(httpRouteOwner(hr), httpRouteRoutes(hr))
+	assert.Len(t, conflicts, 1)
+	assert.True(t, strings.Contains(conflicts[0].Description, "Ingress/default/bar"))
+
+	idx.IngressEventHandler().OnDelete(ing)
+	assert.Len(t, idx.Conflicts(httpRouteOwner(hr), httpRouteRoutes(hr)), 0)
+}
+
+func TestRouteIndexIngressClass(t *testing.T) {
+	idx := &RouteIndex{
+		ingressClass: "apisix",
+		policy:       config.RouteConflictPolicyWarn,
+		routes:       make(map[string][]*indexedRoute),
+	}
+	ar := newTestApisixRoute("foo", "api.example.com", "/foo*", nil)
+	ar.Spec.IngressClassName = "nginx"
+	idx.ApisixRouteEventHandler().OnAdd(ar, false)
+	assert.Len(t, idx.routes, 0)
+
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "bar",
+			Namespace:   "default",
+			Annotations: map[string]string{"kubernetes.io/ingress.class": "apisix"},
+		},
+	}
+	assert.True(t, idx.isIngressEffective(kube.MustNewIngress(ing)))
+	ing.Annotations["kubernetes.io/ingress.class"] = "nginx"
+	assert.False(t, idx.isIngressEffective(kube.MustNewIngress(ing)))
+
+	// Ingresses without class belong to the default IngressClass.
+	delete(ing.Annotations, "kubernetes.io/ingress.class")
+	assert.False(t, idx.isIngressEffective(kube.MustNewIngress(ing)))
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	ingressClass := &networkingv1.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "apisix",
+		},
+	}
+	assert.Nil(t, indexer.Add(ingressClass))
+	idx.SetIngressClassLister(listersnetworkingv1.NewIngressClassLister(indexer))
+	assert.False(t, idx.isIngressEffective(kube.MustNewIngress(ing)))
+	ingressClass.Annotations = map[string]string{networkingv1.AnnotationIsDefaultIngressClass: "true"}
+	assert.Nil(t, indexer.Update(ingressClass))
+	assert.True(t, idx.isIngressEffective(kube.MustNewIngress(ing)))
+}
+
+func TestCheckRouteConflicts(t *testing.T) {
+	prev := routeIndex
+	defer func() {
+		routeIndex = prev
+	}()
+	routeIndex = &RouteIndex{
+		ingressClass: config.IngressClassApisixAndAll,
+		policy:       config.RouteConflictPolicyDeny,
+		routes:       make(map[string][]*indexedRoute),
+	}
+	routeIndex.ApisixRouteEventHandler().OnAdd(newTestApisixRoute("foo", "api.example.com", "/foo*", nil), false)
+
+	ar := newTestApisixRoute("foo2", "api.example.com", "/foo*", nil)
+	valid, warnings := checkRouteConflicts(apisixRouteOwner(ar), apisixRouteRoutes(ar))
+	assert.False(t, valid)
+	assert.Len(t, warnings, 1)
+
+	// Partial conflicts are only warned.
+	ar = newTestApisixRoute("foo2", "api.example.com", "/foo/bar", nil)
+	valid, warnings = checkRouteConflicts(apisixRouteOwner(ar), apisixRouteRoutes(ar))
+	assert.True(t, valid)
+	assert.Len(t, warnings, 1)
+
+	routeIndex.SetPolicy(config.RouteConflictPolicyWarn)
+	ar = newTestApisixRoute("foo2", "api.example.com", "/foo*", nil)
+	valid, warnings = checkRouteConflicts(apisixRouteOwner(ar), apisixRouteRoutes(ar))
+	assert.True(t, valid)
+	assert.Len(t, warnings, 1)
+}
+
+func TestValidateCanaryIngress(t *testing.T) {
+	prev := routeIndex
+	defer func() {
+		routeIndex = prev
+	}()
+	routeIndex = &RouteIndex{
+		ingressClass: config.IngressClassApisixAndAll,
+		policy:       config.RouteConflictPolicyDeny,
+		routes:       make(map[string][]*indexedRoute),
+	}
+
+	pathType := networkingv1.PathTypePrefix
+	newIngress := func(name string, anno map[string]string) *networkingv1.Ingress {
+		return &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "default",
+				Annotations: anno,
+			},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: &[]string{config.IngressClass}[0],
+				Rules: []networkingv1.IngressRule{
+					{
+						Host: "api.example.com",
+						IngressRuleValue: networkingv1.IngressRuleValue{
+							HTTP: &networkingv1.HTTPIngressRuleValue{
+								Paths: []networkingv1.HTTPIngressPath{
+									{Path: "/foo", PathType: &pathType},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	primary := newIngress("primary", nil)
+	canary := newIngress("canary", map[string]string{
+		annotations.AnnotationsCanary:       "true",
+		annotations.AnnotationsCanaryWeight: "10",
+	})
+	validate := func(ing *networkingv1.Ingress) (bool, []string) {
+		result, err := Validator.Validate(context.Background(), &kwhmodel.AdmissionReview{
+			Operation:  kwhmodel.OperationCreate,
+			RequestGVR: &IngressV1GVR,
+		}, ing)
+		assert.Nil(t, err)
+		return result.Valid, result.Warnings
+	}
+
+	routeIndex.IngressEventHandler().OnAdd(primary, false)
+	// The canary Ingress shares the routes of the primary one.
+	valid, warnings := validate(canary)
+	assert.True(t, valid)
+	assert.Len(t, warnings, 0)
+
+	routeIndex.IngressEventHandler().OnAdd(canary, false)
+	valid, warnings = validate(primary)
+	assert.True(t, valid)
+	assert.Len(t, warnings, 0)
+
+	// Another primary Ingress still conflicts.
+	valid, _ = validate(newIngress("other", nil))
+	assert.False(t, valid)
+}
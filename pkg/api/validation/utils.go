@@ -23,6 +23,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/hashicorp/go-multierror"
 	kwhhttp "github.com/slok/kubewebhook/v2/pkg/http"
+	kwhmutating "github.com/slok/kubewebhook/v2/pkg/webhook/mutating"
 	kwhvalidating "github.com/slok/kubewebhook/v2/pkg/webhook/validating"
 	"github.com/xeipuuv/gojsonschema"
 
@@ -75,6 +76,25 @@ func NewHandlerFunc(ID string, validator kwhvalidating.Validator) gin.HandlerFun
 	return gin.WrapH(h)
 }
 
+// NewMutatingHandlerFunc returns a HandlerFunc to handle admission reviews using the given mutator.
+func NewMutatingHandlerFunc(ID string, mutator kwhmutating.Mutator) gin.HandlerFunc {
+	// Create a mutating webhook.
+	wh, err := kwhmutating.NewWebhook(kwhmutating.WebhookConfig{
+		ID:      ID,
+		Mutator: mutator,
+	})
+	if err != nil {
+		log.Errorf("failed to create webhook: %s", err)
+	}
+
+	h, err := kwhhttp.HandlerFor(kwhhttp.HandlerConfig{Webhook: wh})
+	if err != nil {
+		log.Errorf("failed to create webhook handle: %s", err)
+	}
+
+	return gin.WrapH(h)
+}
+
 // validateSchema validates the schema of the given Go struct.
 func validateSchema(schemaLoader *gojsonschema.JSONLoader, obj interface{}) (bool, error) {
 	configLoader := gojsonschema.NewGoLoader(obj)
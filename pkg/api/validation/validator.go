@@ -18,18 +18,25 @@ package validation
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/go-multierror"
 	kwhmodel "github.com/slok/kubewebhook/v2/pkg/model"
 	kwhvalidating "github.com/slok/kubewebhook/v2/pkg/webhook/validating"
 	"go.uber.org/zap"
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/apache/apisix-ingress-controller/pkg/apisix"
+	"github.com/apache/apisix-ingress-controller/pkg/kube"
 	v2 "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/apis/config/v2"
 	"github.com/apache/apisix-ingress-controller/pkg/log"
+	"github.com/apache/apisix-ingress-controller/pkg/metrics"
+	ingresstranslation "github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation"
 )
 
 var (
@@ -79,8 +86,35 @@ var (
 		Version:  v2.GroupVersion.Version,
 		Resource: "apisixglobalrules",
 	}
+
+	IngressV1GVR = metav1.GroupVersionResource{
+		Group:    networkingv1.GroupName,
+		Version:  "v1",
+		Resource: "ingresses",
+	}
+
+	IngressV1beta1GVR = metav1.GroupVersionResource{
+		Group:    networkingv1beta1.GroupName,
+		Version:  "v1beta1",
+		Resource: "ingresses",
+	}
+
+	HTTPRouteV1beta1GVR = metav1.GroupVersionResource{
+		Group:    gatewayv1beta1.GroupName,
+		Version:  "v1beta1",
+		Resource: "httproutes",
+	}
 )
 
+// ingressTranslator is used to translate Ingress in dry-run mode, it's set
+// by the Ingress provider once it's created.
+var ingressTranslator ingresstranslation.IngressTranslator
+
+// SetIngressTranslator sets the translator used to validate Ingress.
+func SetIngressTranslator(t ingresstranslation.IngressTranslator) {
+	ingressTranslator = t
+}
+
 var Validator = kwhvalidating.ValidatorFunc(
 	func(ctx context.Context, review *kwhmodel.AdmissionReview, object metav1.Object) (result *kwhvalidating.ValidatorResult, err error) {
 
@@ -93,6 +127,7 @@ var Validator = kwhvalidating.ValidatorFunc(
 
 			resultErr error
 			msg       string
+			warnings  []string
 		)
 
 		switch *GVR {
@@ -110,6 +145,9 @@ var Validator = kwhvalidating.ValidatorFunc(
 			if valid {
 				valid, resultErr = ValidateApisixRouteV2(ar)
 			}
+			if valid && routeIndex.isApisixRouteEffective(ar) {
+				valid, warnings = checkRouteConflicts(apisixRouteOwner(ar), apisixRouteRoutes(ar))
+			}
 		case ApisixUpstreamV2GVR:
 			au := object.(*v2.ApisixUpstream)
 			if au.Spec == nil {
@@ -187,16 +225,54 @@ var Validator = kwhvalidating.ValidatorFunc(
 				}
 				valid, resultErr = validateIngressClassName(old.Spec.IngressClassName, agr.Spec.IngressClassName)
 			}
+		case IngressV1GVR, IngressV1beta1GVR:
+			ing, err := kube.NewIngress(object)
+			if err != nil {
+				valid, resultErr = false, err
+				break
+			}
+			if !routeIndex.isIngressEffective(ing) {
+				break
+			}
+			if ingressTranslator != nil {
+				if err := ingressTranslator.ValidateIngress(ing); err != nil {
+					valid, resultErr = false, err
+					break
+				}
+			}
+			// The routes of a canary Ingress are the ones of its primary Ingress.
+			if !ingresstranslation.IsCanaryIngress(ing) {
+				valid, warnings = checkRouteConflicts(ingressOwner(ing), ingressRoutes(ing))
+			}
+		case HTTPRouteV1beta1GVR:
+			var hr gatewayv1beta1.HTTPRoute
+			if _, _, err := deserializer.Decode(review.NewObjectRaw, nil, &hr); err != nil {
+				log.Errorw("Failed to deserialize HTTPRoute in admisson webhook",
+					zap.Error(err),
+				)
+				valid, resultErr = false, err
+				break
+			}
+			valid, warnings = checkRouteConflicts(httpRouteOwner(&hr), httpRouteRoutes(&hr))
 		default:
 			valid = false
 			resultErr = fmt.Errorf("{group: %s, version: %s, Resource: %s} not supported", GVR.Group, GVR.Version, GVR.Resource)
 		}
 		if resultErr != nil {
 			msg = resultErr.Error()
+		} else if !valid && len(warnings) > 0 {
+			msg = "routes conflict with other objects:\n" + strings.Join(warnings, "\n")
+			warnings = nil
+		}
+		decision := "allowed"
+		if !valid {
+			decision = "denied"
 		}
+		metrics.NewPrometheusCollector().IncrAdmissionReviews(fmt.Sprintf("%s/%s/%s", GVR.Group, GVR.Version, GVR.Resource), decision)
 		return &kwhvalidating.ValidatorResult{
-			Valid:   valid,
-			Message: msg,
+			Valid:    valid,
+			Message:  msg,
+			Warnings: warnings,
 		}, nil
 	},
 )
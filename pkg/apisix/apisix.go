@@ -32,6 +32,8 @@ type APISIX interface {
 	UpdateCluster(context.Context, *ClusterOptions) error
 	// ListClusters lists all APISIX clusters.
 	ListClusters() []Cluster
+	// HasCluster checks whether the named APISIX cluster was added.
+	HasCluster(name string) bool
 	// DeleteCluster deletes the target APISIX cluster by its name.
 	DeleteCluster(name string)
 }
@@ -61,6 +63,8 @@ type Cluster interface {
 	Plugin() Plugin
 	// PluginConfig returns a PluginConfig interface that can operate PluginConfig resources.
 	PluginConfig() PluginConfig
+	// Service returns a Service interface that can operate Service resources.
+	Service() Service
 	// Schema returns a Schema interface that can fetch schema of APISIX objects.
 	Schema() Schema
 
@@ -69,6 +73,9 @@ type Cluster interface {
 	UpstreamServiceRelation() UpstreamServiceRelation
 
 	Validator() APISIXSchemaValidator
+	// HealthStatus returns a HealthStatus interface that can fetch the health
+	// status of upstream nodes.
+	HealthStatus() HealthStatus
 }
 
 // Route is the specific client interface to take over the create, update,
@@ -165,6 +172,22 @@ type PluginMetadata interface {
 	Create(ctx context.Context, metadata *v1.PluginMetadata, shouldCompare bool) (*v1.PluginMetadata, error)
 }
 
+// Service is the specific client interface to take over the create, update,
+// list and delete for APISIX Service resource.
+type Service interface {
+	Get(ctx context.Context, name string) (*v1.Service, error)
+	List(ctx context.Context) ([]*v1.Service, error)
+	Create(ctx context.Context, svc *v1.Service, shouldCompare bool) (*v1.Service, error)
+	Delete(ctx context.Context, svc *v1.Service) error
+	Update(ctx context.Context, svc *v1.Service, shouldCompare bool) (*v1.Service, error)
+}
+
+// HealthStatus is the specific client interface to fetch the health status
+// of the upstream nodes from the APISIX control API.
+type HealthStatus interface {
+	List(ctx context.Context) ([]*v1.UpstreamHealthStatus, error)
+}
+
 type UpstreamServiceRelation interface {
 	// Get relation based on namespace+"_"+service.name
 	Get(ctx context.Context, svcName string) (*v1.UpstreamServiceRelation, error)
@@ -219,6 +242,14 @@ func (c *apisix) ListClusters() []Cluster {
 	return clusters
 }
 
+// HasCluster implements APISIX.HasCluster method.
+func (c *apisix) HasCluster(name string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.clusters[name]
+	return ok
+}
+
 // AddCluster implements APISIX.AddCluster method.
 func (c *apisix) AddCluster(ctx context.Context, co *ClusterOptions) error {
 	c.mu.Lock()
@@ -19,7 +19,7 @@ import v1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
 
 // Cache defines the necessary behaviors that the cache object should have.
 // Note this interface is for APISIX, not for generic purpose, it supports
-// standard APISIX resources, i.e. Route, Upstream, and SSL.
+// standard APISIX resources, i.e. Route, Upstream, Service and SSL.
 // Cache implementations should copy the target objects before/after read/write
 // operations for the sake of avoiding data corrupted by other writers.
 type Cache interface {
@@ -39,6 +39,8 @@ type Cache interface {
 	InsertSchema(*v1.Schema) error
 	// InsertPluginConfig adds or updates plugin_config to cache.
 	InsertPluginConfig(*v1.PluginConfig) error
+	// InsertService adds or updates service to cache.
+	InsertService(*v1.Service) error
 
 	InsertUpstreamServiceRelation(*v1.UpstreamServiceRelation) error
 
@@ -58,6 +60,8 @@ type Cache interface {
 	GetSchema(string) (*v1.Schema, error)
 	// GetPluginConfig finds the plugin_config from cache according to the primary index (id).
 	GetPluginConfig(string) (*v1.PluginConfig, error)
+	// GetService finds the service from cache according to the primary index (id).
+	GetService(string) (*v1.Service, error)
 	// GetUpstreamServiceRelation finds the upstream_service from cache according to the primary index (service name).
 	GetUpstreamServiceRelation(string) (*v1.UpstreamServiceRelation, error)
 
@@ -77,6 +81,8 @@ type Cache interface {
 	ListSchema() ([]*v1.Schema, error)
 	// ListPluginConfigs lists all plugin_config in cache.
 	ListPluginConfigs() ([]*v1.PluginConfig, error)
+	// ListServices lists all services in cache.
+	ListServices() ([]*v1.Service, error)
 
 	ListUpstreamServiceRelation() ([]*v1.UpstreamServiceRelation, error)
 
@@ -96,8 +102,11 @@ type Cache interface {
 	DeleteSchema(*v1.Schema) error
 	// DeletePluginConfig deletes the specified plugin_config in cache.
 	DeletePluginConfig(*v1.PluginConfig) error
+	// DeleteService deletes the specified service in cache.
+	DeleteService(*v1.Service) error
 
 	CheckUpstreamReference(*v1.Upstream) error
 	CheckPluginConfigReference(*v1.PluginConfig) error
+	CheckServiceReference(*v1.Service) error
 	DeleteUpstreamServiceRelation(*v1.UpstreamServiceRelation) error
 }
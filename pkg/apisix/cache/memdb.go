@@ -78,6 +78,10 @@ func (c *dbCache) InsertPluginConfig(pc *v1.PluginConfig) error {
 	return c.insert("plugin_config", pc.DeepCopy())
 }
 
+func (c *dbCache) InsertService(svc *v1.Service) error {
+	return c.insert("service", svc.DeepCopy())
+}
+
 func (c *dbCache) InsertUpstreamServiceRelation(us *v1.UpstreamServiceRelation) error {
 	return c.insert("upstream_service", us.DeepCopy())
 }
@@ -156,6 +160,14 @@ func (c *dbCache) GetPluginConfig(name string) (*v1.PluginConfig, error) {
 	return obj.(*v1.PluginConfig).DeepCopy(), nil
 }
 
+func (c *dbCache) GetService(id string) (*v1.Service, error) {
+	obj, err := c.get("service", id)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*v1.Service).DeepCopy(), nil
+}
+
 func (c *dbCache) GetUpstreamServiceRelation(serviceName string) (*v1.UpstreamServiceRelation, error) {
 	obj, err := c.get("upstream_service", serviceName)
 	if err != nil {
@@ -276,6 +288,18 @@ func (c *dbCache) ListPluginConfigs() ([]*v1.PluginConfig, error) {
 	return pluginConfigs, nil
 }
 
+func (c *dbCache) ListServices() ([]*v1.Service, error) {
+	raws, err := c.list("service")
+	if err != nil {
+		return nil, err
+	}
+	services := make([]*v1.Service, 0, len(raws))
+	for _, raw := range raws {
+		services = append(services, raw.(*v1.Service).DeepCopy())
+	}
+	return services, nil
+}
+
 func (c *dbCache) ListUpstreamServiceRelation() ([]*v1.UpstreamServiceRelation, error) {
 	raws, err := c.list("upstream_service")
 	if err != nil {
@@ -340,6 +364,13 @@ func (c *dbCache) DeletePluginConfig(pc *v1.PluginConfig) error {
 	return c.delete("plugin_config", pc)
 }
 
+func (c *dbCache) DeleteService(svc *v1.Service) error {
+	if err := c.CheckServiceReference(svc); err != nil {
+		return err
+	}
+	return c.delete("service", svc)
+}
+
 func (c *dbCache) DeleteUpstreamServiceRelation(us *v1.UpstreamServiceRelation) error {
 	return c.delete("upstream_service", us)
 }
@@ -376,6 +407,14 @@ func (c *dbCache) CheckUpstreamReference(u *v1.Upstream) error {
 	if obj != nil {
 		return ErrStillInUse
 	}
+
+	obj, err = txn.First("service", "upstream_id", u.ID)
+	if err != nil && err != memdb.ErrNotFound {
+		return err
+	}
+	if obj != nil {
+		return ErrStillInUse
+	}
 	return nil
 }
 
@@ -392,3 +431,17 @@ func (c *dbCache) CheckPluginConfigReference(u *v1.PluginConfig) error {
 	}
 	return nil
 }
+
+func (c *dbCache) CheckServiceReference(svc *v1.Service) error {
+	// Service is referenced by Route.
+	txn := c.db.Txn(false)
+	defer txn.Abort()
+	obj, err := txn.First("route", "service_id", svc.ID)
+	if err != nil && err != memdb.ErrNotFound {
+		return err
+	}
+	if obj != nil {
+		return ErrStillInUse
+	}
+	return nil
+}
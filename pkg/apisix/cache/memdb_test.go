@@ -463,6 +463,60 @@ func TestMemDBCachePluginConfig(t *testing.T) {
 	assert.Error(t, ErrNotFound, c.DeletePluginConfig(pc4))
 }
 
+func TestMemDBCacheService(t *testing.T) {
+	c, err := NewMemDBCache()
+	assert.Nil(t, err, "NewMemDBCache")
+
+	svc1 := &v1.Service{
+		Metadata: v1.Metadata{
+			ID:   "1",
+			Name: "name1",
+		},
+		UpstreamId: "1",
+	}
+	assert.Nil(t, c.InsertService(svc1), "inserting service svc1")
+
+	svc11, err := c.GetService("1")
+	assert.Nil(t, err)
+	assert.Equal(t, svc1, svc11)
+
+	svc2 := &v1.Service{
+		Metadata: v1.Metadata{
+			ID:   "2",
+			Name: "name2",
+		},
+	}
+	assert.Nil(t, c.InsertService(svc2), "inserting service svc2")
+	assert.Nil(t, c.DeleteService(svc2), "delete service svc2")
+
+	svcList, err := c.ListServices()
+	assert.Nil(t, err, "listing service")
+	assert.Len(t, svcList, 1)
+	assert.Equal(t, svc1, svcList[0])
+
+	r := &v1.Route{
+		Metadata: v1.Metadata{
+			ID:   "1",
+			Name: "route",
+		},
+		ServiceId: "1",
+	}
+	u := &v1.Upstream{
+		Metadata: v1.Metadata{
+			ID:   "1",
+			Name: "upstream",
+		},
+	}
+	assert.Nil(t, c.InsertRoute(r))
+	assert.Nil(t, c.InsertUpstream(u))
+	assert.Equal(t, ErrStillInUse, c.DeleteService(svc1))
+	assert.Equal(t, ErrStillInUse, c.DeleteUpstream(u))
+	assert.Nil(t, c.DeleteRoute(r))
+	assert.Nil(t, c.DeleteService(svc1))
+	assert.Nil(t, c.DeleteUpstream(u))
+	assert.Equal(t, ErrNotFound, c.DeleteService(svc1))
+}
+
 func TestMemDBCacheUpstreamServiceRelation(t *testing.T) {
 	c, err := NewMemDBCache()
 	assert.Nil(t, err, "NewMemDBCache")
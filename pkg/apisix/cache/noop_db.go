@@ -61,6 +61,10 @@ func (c *noopCache) InsertPluginConfig(pc *v1.PluginConfig) error {
 	return nil
 }
 
+func (c *noopCache) InsertService(svc *v1.Service) error {
+	return nil
+}
+
 func (c *noopCache) InsertUpstreamServiceRelation(us *v1.UpstreamServiceRelation) error {
 	return nil
 }
@@ -97,6 +101,10 @@ func (c *noopCache) GetPluginConfig(name string) (*v1.PluginConfig, error) {
 	return nil, nil
 }
 
+func (c *noopCache) GetService(id string) (*v1.Service, error) {
+	return nil, nil
+}
+
 func (c *noopCache) GetUpstreamServiceRelation(serviceName string) (*v1.UpstreamServiceRelation, error) {
 	return nil, nil
 }
@@ -133,6 +141,10 @@ func (c *noopCache) ListPluginConfigs() ([]*v1.PluginConfig, error) {
 	return nil, nil
 }
 
+func (c *noopCache) ListServices() ([]*v1.Service, error) {
+	return nil, nil
+}
+
 func (c *noopCache) ListUpstreamServiceRelation() ([]*v1.UpstreamServiceRelation, error) {
 	return nil, nil
 }
@@ -169,6 +181,10 @@ func (c *noopCache) DeletePluginConfig(pc *v1.PluginConfig) error {
 	return nil
 }
 
+func (c *noopCache) DeleteService(svc *v1.Service) error {
+	return nil
+}
+
 func (c *noopCache) DeleteUpstreamServiceRelation(us *v1.UpstreamServiceRelation) error {
 	return nil
 }
@@ -180,3 +196,7 @@ func (c *noopCache) CheckUpstreamReference(u *v1.Upstream) error {
 func (c *noopCache) CheckPluginConfigReference(pc *v1.PluginConfig) error {
 	return nil
 }
+
+func (c *noopCache) CheckServiceReference(svc *v1.Service) error {
+	return nil
+}
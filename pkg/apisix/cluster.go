@@ -84,7 +84,10 @@ type ClusterOptions struct {
 	Name            string
 	AdminKey        string
 	BaseURL         string
-	Timeout         time.Duration
+	// ControlAPIBaseURL is the base url of the control API, which provides
+	// the health status of upstream nodes. Empty means not available.
+	ControlAPIBaseURL string
+	Timeout           time.Duration
 	// SyncInterval is the interval to sync schema.
 	SyncInterval      types.TimeDuration
 	SyncComparison    bool
@@ -102,6 +105,7 @@ type cluster struct {
 	name                    string
 	baseURL                 string
 	baseURLHost             string
+	controlAPIBaseURL       string
 	adminKey                string
 	prefix                  string
 	cli                     *http.Client
@@ -120,9 +124,11 @@ type cluster struct {
 	plugin                  Plugin
 	schema                  Schema
 	pluginConfig            PluginConfig
+	service                 Service
 	metricsCollector        metrics.Collector
 	upstreamServiceRelation UpstreamServiceRelation
 	pluginMetadata          PluginMetadata
+	healthStatus            HealthStatus
 	adapter                 adapter.Adapter
 	waitforCacheSync        bool
 	validator               APISIXSchemaValidator
@@ -152,12 +158,13 @@ func newCluster(ctx context.Context, o *ClusterOptions) (Cluster, error) {
 		adminVersion = "v2"
 	}
 	c := &cluster{
-		adminVersion: adminVersion,
-		name:         o.Name,
-		baseURL:      o.BaseURL,
-		baseURLHost:  u.Host,
-		adminKey:     o.AdminKey,
-		prefix:       o.Prefix,
+		adminVersion:      adminVersion,
+		name:              o.Name,
+		baseURL:           o.BaseURL,
+		baseURLHost:       u.Host,
+		controlAPIBaseURL: strings.TrimSuffix(o.ControlAPIBaseURL, "/"),
+		adminKey:          o.AdminKey,
+		prefix:            o.Prefix,
 		cli: &http.Client{
 			Timeout:   o.Timeout,
 			Transport: _defaultTransport,
@@ -184,8 +191,10 @@ func newCluster(ctx context.Context, o *ClusterOptions) (Cluster, error) {
 		c.plugin = newPluginClient(c)
 		c.schema = newSchemaClient(c)
 		c.pluginConfig = newPluginConfigMem(c)
+		c.service = newServiceMem(c)
 		c.upstreamServiceRelation = newUpstreamServiceRelation(c)
 		c.pluginMetadata = newPluginMetadataMem(c)
+		c.healthStatus = newHealthStatusClient(c)
 
 		c.validator, err = NewReferenceFile("conf/apisix-schema.json")
 		if err != nil {
@@ -214,8 +223,10 @@ func newCluster(ctx context.Context, o *ClusterOptions) (Cluster, error) {
 		c.plugin = newPluginClient(c)
 		c.schema = newSchemaClient(c)
 		c.pluginConfig = newPluginConfigClient(c)
+		c.service = newServiceClient(c)
 		c.upstreamServiceRelation = newUpstreamServiceRelation(c)
 		c.pluginMetadata = newPluginMetadataClient(c)
+		c.healthStatus = newHealthStatusClient(c)
 		c.validator = newDummyValidator()
 
 		c.cache, err = cache.NewMemDBCache()
@@ -328,6 +339,11 @@ func (c *cluster) syncCacheOnce(ctx context.Context) (bool, error) {
 		log.Errorf("failed to list plugin_configs in APISIX: %s", err)
 		return false, err
 	}
+	services, err := c.service.List(ctx)
+	if err != nil {
+		log.Errorf("failed to list services in APISIX: %s", err)
+		return false, err
+	}
 
 	for _, r := range routes {
 		if err := c.cache.InsertRoute(r); err != nil {
@@ -398,6 +414,16 @@ func (c *cluster) syncCacheOnce(ctx context.Context) (bool, error) {
 			return false, err
 		}
 	}
+	for _, s := range services {
+		if err := c.cache.InsertService(s); err != nil {
+			log.Errorw("failed to insert service to cache",
+				zap.String("service", s.ID),
+				zap.String("cluster", c.name),
+				zap.String("error", err.Error()),
+			)
+			return false, err
+		}
+	}
 	return true, nil
 }
 
@@ -551,6 +577,16 @@ func (c *cluster) Plugin() Plugin {
 	return c.plugin
 }
 
+// Service implements Cluster.Service method.
+func (c *cluster) Service() Service {
+	return c.service
+}
+
+// HealthStatus implements Cluster.HealthStatus method.
+func (c *cluster) HealthStatus() HealthStatus {
+	return c.healthStatus
+}
+
 // PluginConfig implements Cluster.PluginConfig method.
 func (c *cluster) PluginConfig() PluginConfig {
 	return c.pluginConfig
@@ -1089,6 +1125,40 @@ func (c *cluster) GetPluginConfig(ctx context.Context, baseUrl, id string) (*v1.
 	return pluginConfig, nil
 }
 
+func (c *cluster) GetService(ctx context.Context, baseUrl, id string) (*v1.Service, error) {
+	url := baseUrl + "/" + id
+	resp, err := c.getResource(ctx, url, "service")
+	if err != nil {
+		if err == cache.ErrNotFound {
+			log.Warnw("service not found",
+				zap.String("id", id),
+				zap.String("url", url),
+				zap.String("cluster", c.name),
+			)
+		} else {
+			log.Errorw("failed to get service from APISIX",
+				zap.String("id", id),
+				zap.String("url", url),
+				zap.String("cluster", c.name),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	svc, err := resp.service()
+	if err != nil {
+		log.Errorw("failed to convert service item",
+			zap.String("url", url),
+			zap.String("service_key", resp.Key),
+			zap.String("service_value", string(resp.Value)),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return svc, nil
+}
+
 func (c *cluster) GetRoute(ctx context.Context, baseUrl, id string) (*v1.Route, error) {
 	url := baseUrl + "/" + id
 	resp, err := c.getResource(ctx, url, "route")
@@ -54,6 +54,13 @@ func TestAddCluster(t *testing.T) {
 
 	clusters = apisix.ListClusters()
 	assert.Len(t, clusters, 2)
+
+	assert.True(t, apisix.HasCluster("service2"))
+	assert.False(t, apisix.HasCluster("service3"))
+
+	apisix.DeleteCluster("service2")
+	assert.False(t, apisix.HasCluster("service2"))
+	assert.Len(t, apisix.ListClusters(), 1)
 }
 
 func TestNonExistentCluster(t *testing.T) {
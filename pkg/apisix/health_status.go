@@ -0,0 +1,102 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apisix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/apache/apisix-ingress-controller/pkg/log"
+	v1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+const (
+	_upstreamHealthCheckerPrefix = "/upstreams/"
+)
+
+type healthStatusClient struct {
+	url     string
+	cluster *cluster
+}
+
+func newHealthStatusClient(c *cluster) HealthStatus {
+	cli := &healthStatusClient{
+		cluster: c,
+	}
+	if c.controlAPIBaseURL != "" {
+		cli.url = c.controlAPIBaseURL + "/v1/healthcheck"
+	}
+	return cli
+}
+
+// healthChecker is the health checker status reported by the control API.
+type healthChecker struct {
+	// Name is the resource path of the object owning the health checker,
+	// e.g. /apisix/upstreams/1.
+	Name  string                        `json:"name"`
+	Nodes []v1.UpstreamNodeHealthStatus `json:"nodes"`
+}
+
+// List returns the health status of the upstreams with health checkers,
+// the upstreams embedded in other objects are not included.
+func (h *healthStatusClient) List(ctx context.Context) ([]*v1.UpstreamHealthStatus, error) {
+	if h.url == "" {
+		return nil, ErrFunctionDisabled
+	}
+	log.Debugw("try to list upstream health status in APISIX",
+		zap.String("cluster", h.cluster.name),
+		zap.String("url", h.url),
+	)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	resp, err := h.cluster.cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	h.cluster.metricsCollector.RecordAPISIXLatency(time.Since(start), "healthStatus")
+	h.cluster.metricsCollector.RecordAPISIXCode(resp.StatusCode, "healthStatus")
+
+	defer drainBody(resp.Body, h.url)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d, error message: %s", resp.StatusCode, readBody(resp.Body, h.url))
+	}
+
+	var checkers []healthChecker
+	if err := json.NewDecoder(resp.Body).Decode(&checkers); err != nil {
+		return nil, err
+	}
+	statuses := make([]*v1.UpstreamHealthStatus, 0, len(checkers))
+	for _, checker := range checkers {
+		idx := strings.LastIndex(checker.Name, _upstreamHealthCheckerPrefix)
+		if idx < 0 {
+			continue
+		}
+		statuses = append(statuses, &v1.UpstreamHealthStatus{
+			ID:    checker.Name[idx+len(_upstreamHealthCheckerPrefix):],
+			Nodes: checker.Nodes,
+		})
+	}
+	return statuses, nil
+}
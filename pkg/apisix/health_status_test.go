@@ -0,0 +1,111 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package apisix
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/nettest"
+
+	"github.com/apache/apisix-ingress-controller/pkg/metrics"
+	v1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+const fakeHealthCheckers = `[
+  {
+    "name": "/apisix/upstreams/1",
+    "type": "http",
+    "nodes": [
+      {"ip": "10.0.0.1", "port": 80, "status": "healthy"},
+      {"ip": "10.0.0.2", "port": 80, "status": "unhealthy"},
+      {"ip": "10.0.0.3", "port": 80, "status": "mostly_unhealthy"}
+    ]
+  },
+  {
+    "name": "/apisix/routes/2",
+    "type": "http",
+    "nodes": [
+      {"ip": "10.0.0.4", "port": 80, "status": "unhealthy"}
+    ]
+  }
+]`
+
+type fakeAPISIXHealthStatusSrv struct{}
+
+func (srv *fakeAPISIXHealthStatusSrv) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	if r.URL.Path != "/v1/healthcheck" || r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_, _ = w.Write([]byte(fakeHealthCheckers))
+}
+
+func runFakeHealthStatusSrv(t *testing.T) *http.Server {
+	ln, _ := nettest.NewLocalListener("tcp")
+
+	httpSrv := &http.Server{
+		Addr:    ln.Addr().String(),
+		Handler: &fakeAPISIXHealthStatusSrv{},
+	}
+
+	go func() {
+		if err := httpSrv.Serve(ln); err != nil && err != http.ErrServerClosed {
+			t.Errorf("failed to run http server: %s", err)
+		}
+	}()
+
+	return httpSrv
+}
+
+func TestHealthStatusClient(t *testing.T) {
+	srv := runFakeHealthStatusSrv(t)
+	defer func() {
+		assert.Nil(t, srv.Shutdown(context.Background()))
+	}()
+
+	u := url.URL{
+		Scheme: "http",
+		Host:   srv.Addr,
+	}
+	cli := newHealthStatusClient(&cluster{
+		controlAPIBaseURL: u.String(),
+		cli:               http.DefaultClient,
+		metricsCollector:  metrics.NewPrometheusCollector(),
+	})
+
+	statuses, err := cli.List(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, statuses, 1)
+	assert.Equal(t, "1", statuses[0].ID)
+	assert.Len(t, statuses[0].Nodes, 3)
+	assert.Equal(t, []v1.UpstreamNodeHealthStatus{
+		{Host: "10.0.0.2", Port: 80, Status: v1.NodeStatusUnhealthy},
+		{Host: "10.0.0.3", Port: 80, Status: v1.NodeStatusMostlyUnhealthy},
+	}, statuses[0].UnhealthyNodes())
+
+	// Disabled
+	cli = newHealthStatusClient(&cluster{
+		cli:              http.DefaultClient,
+		metricsCollector: metrics.NewPrometheusCollector(),
+	})
+	_, err = cli.List(context.Background())
+	assert.Equal(t, ErrFunctionDisabled, err)
+}
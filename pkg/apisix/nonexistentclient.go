@@ -38,8 +38,10 @@ func newNonExistentCluster() *nonExistentCluster {
 			plugin:                  &dummyPlugin{},
 			schema:                  &dummySchema{},
 			pluginConfig:            &dummyPluginConfig{},
+			service:                 &dummyService{},
 			upstreamServiceRelation: &dummyUpstreamServiceRelation{},
 			pluginMetadata:          &dummyPluginMetadata{},
+			healthStatus:            &dummyHealthStatus{},
 		},
 	}
 }
@@ -54,8 +56,10 @@ type embedDummyResourceImplementer struct {
 	plugin                  Plugin
 	schema                  Schema
 	pluginConfig            PluginConfig
+	service                 Service
 	upstreamServiceRelation UpstreamServiceRelation
 	pluginMetadata          PluginMetadata
+	healthStatus            HealthStatus
 	validator               APISIXSchemaValidator
 }
 
@@ -197,6 +201,34 @@ func (f *dummyPlugin) List(_ context.Context) ([]string, error) {
 	return nil, ErrClusterNotExist
 }
 
+type dummyHealthStatus struct{}
+
+func (f *dummyHealthStatus) List(_ context.Context) ([]*v1.UpstreamHealthStatus, error) {
+	return nil, ErrClusterNotExist
+}
+
+type dummyService struct{}
+
+func (f *dummyService) Get(_ context.Context, _ string) (*v1.Service, error) {
+	return nil, ErrClusterNotExist
+}
+
+func (f *dummyService) List(_ context.Context) ([]*v1.Service, error) {
+	return nil, ErrClusterNotExist
+}
+
+func (f *dummyService) Create(_ context.Context, _ *v1.Service, _ bool) (*v1.Service, error) {
+	return nil, ErrClusterNotExist
+}
+
+func (f *dummyService) Delete(_ context.Context, _ *v1.Service) error {
+	return ErrClusterNotExist
+}
+
+func (f *dummyService) Update(_ context.Context, _ *v1.Service, _ bool) (*v1.Service, error) {
+	return nil, ErrClusterNotExist
+}
+
 type dummySchema struct{}
 
 func (f *dummySchema) GetPluginSchema(_ context.Context, _ string) (*v1.Schema, error) {
@@ -342,6 +374,14 @@ func (nc *nonExistentCluster) UpstreamServiceRelation() UpstreamServiceRelation
 	return nc.upstreamServiceRelation
 }
 
+func (nc *nonExistentCluster) Service() Service {
+	return nc.service
+}
+
+func (nc *nonExistentCluster) HealthStatus() HealthStatus {
+	return nc.healthStatus
+}
+
 func (nc *nonExistentCluster) HasSynced(_ context.Context) error {
 	return nil
 }
@@ -366,6 +406,7 @@ func (c *dummyCache) InsertGlobalRule(_ *v1.GlobalRule) error
 func (c *dummyCache) InsertConsumer(_ *v1.Consumer) error                               { return nil }
 func (c *dummyCache) InsertSchema(_ *v1.Schema) error                                   { return nil }
 func (c *dummyCache) InsertPluginConfig(_ *v1.PluginConfig) error                       { return nil }
+func (c *dummyCache) InsertService(_ *v1.Service) error                                 { return nil }
 func (c *dummyCache) InsertUpstreamServiceRelation(_ *v1.UpstreamServiceRelation) error { return nil }
 func (c *dummyCache) GetRoute(_ string) (*v1.Route, error)                              { return nil, cache.ErrNotFound }
 func (c *dummyCache) GetSSL(_ string) (*v1.Ssl, error)                                  { return nil, cache.ErrNotFound }
@@ -377,6 +418,7 @@ func (c *dummyCache) GetSchema(_ string) (*v1.Schema, error)
 func (c *dummyCache) GetPluginConfig(_ string) (*v1.PluginConfig, error) {
 	return nil, cache.ErrNotFound
 }
+func (c *dummyCache) GetService(_ string) (*v1.Service, error) { return nil, cache.ErrNotFound }
 func (c *dummyCache) GetUpstreamServiceRelation(_ string) (*v1.UpstreamServiceRelation, error) {
 	return nil, cache.ErrNotFound
 }
@@ -388,6 +430,7 @@ func (c *dummyCache) ListGlobalRules() ([]*v1.GlobalRule, error)     { return ni
 func (c *dummyCache) ListConsumers() ([]*v1.Consumer, error)         { return nil, nil }
 func (c *dummyCache) ListSchema() ([]*v1.Schema, error)              { return nil, nil }
 func (c *dummyCache) ListPluginConfigs() ([]*v1.PluginConfig, error) { return nil, nil }
+func (c *dummyCache) ListServices() ([]*v1.Service, error)           { return nil, nil }
 func (c *dummyCache) ListUpstreamServiceRelation() ([]*v1.UpstreamServiceRelation, error) {
 	return nil, nil
 }
@@ -399,6 +442,8 @@ func (c *dummyCache) DeleteGlobalRule(_ *v1.GlobalRule) error
 func (c *dummyCache) DeleteConsumer(_ *v1.Consumer) error                               { return nil }
 func (c *dummyCache) DeleteSchema(_ *v1.Schema) error                                   { return nil }
 func (c *dummyCache) DeletePluginConfig(_ *v1.PluginConfig) error                       { return nil }
+func (c *dummyCache) DeleteService(_ *v1.Service) error                                 { return nil }
 func (c *dummyCache) DeleteUpstreamServiceRelation(_ *v1.UpstreamServiceRelation) error { return nil }
 func (c *dummyCache) CheckUpstreamReference(_ *v1.Upstream) error                       { return nil }
 func (c *dummyCache) CheckPluginConfigReference(_ *v1.PluginConfig) error               { return nil }
+func (c *dummyCache) CheckServiceReference(_ *v1.Service) error                         { return nil }
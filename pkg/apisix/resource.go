@@ -197,6 +197,16 @@ func (i *item) pluginMetadata() (*v1.PluginMetadata, error) {
 	return &pluginMetadata, nil
 }
 
+// service decodes item.Value and converts it to v1.Service.
+func (i *item) service() (*v1.Service, error) {
+	log.Debugf("got service: %s", string(i.Value))
+	var svc v1.Service
+	if err := json.Unmarshal(i.Value, &svc); err != nil {
+		return nil, err
+	}
+	return &svc, nil
+}
+
 // pluginConfig decodes item.Value and converts it to v1.PluginConfig.
 func (i *item) pluginConfig() (*v1.PluginConfig, error) {
 	log.Debugf("got pluginConfig: %s", string(i.Value))
@@ -0,0 +1,358 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apisix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/apache/apisix-ingress-controller/pkg/apisix/cache"
+	"github.com/apache/apisix-ingress-controller/pkg/id"
+	"github.com/apache/apisix-ingress-controller/pkg/log"
+	v1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+type serviceClient struct {
+	url     string
+	cluster *cluster
+}
+
+func newServiceClient(c *cluster) Service {
+	return &serviceClient{
+		url:     c.baseURL + "/services",
+		cluster: c,
+	}
+}
+
+// Get returns the v1.Service.
+// FIXME, currently if caller pass a non-existent resource, the Get always passes
+// through cache.
+func (s *serviceClient) Get(ctx context.Context, name string) (*v1.Service, error) {
+	log.Debugw("try to look up service",
+		zap.String("name", name),
+		zap.String("url", s.url),
+		zap.String("cluster", s.cluster.name),
+	)
+	rid := id.GenID(name)
+	svc, err := s.cluster.cache.GetService(rid)
+	if err == nil {
+		return svc, nil
+	}
+	if err != cache.ErrNotFound {
+		log.Errorw("failed to find service in cache, will try to lookup from APISIX",
+			zap.String("name", name),
+			zap.Error(err),
+		)
+	} else {
+		log.Debugw("service not found in cache, will try to lookup from APISIX",
+			zap.String("name", name),
+			zap.Error(err),
+		)
+	}
+
+	// TODO Add mutex here to avoid dog-pile effect.
+	svc, err = s.cluster.GetService(ctx, s.url, rid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cluster.cache.InsertService(svc); err != nil {
+		log.Errorf("failed to reflect service create to cache: %s", err)
+		return nil, err
+	}
+	return svc, nil
+}
+
+// List is only used in cache warming up. So here just pass through
+// to APISIX.
+func (s *serviceClient) List(ctx context.Context) ([]*v1.Service, error) {
+	log.Debugw("try to list services in APISIX",
+		zap.String("cluster", s.cluster.name),
+		zap.String("url", s.url),
+	)
+	serviceItems, err := s.cluster.listResource(ctx, s.url, "service")
+	if err != nil {
+		log.Errorf("failed to list services: %s", err)
+		return nil, err
+	}
+
+	var items []*v1.Service
+	for i, item := range serviceItems {
+		svc, err := item.service()
+		if err != nil {
+			log.Errorw("failed to convert service item",
+				zap.String("url", s.url),
+				zap.String("service_key", item.Key),
+				zap.String("service_value", string(item.Value)),
+				zap.Error(err),
+			)
+			return nil, err
+		}
+
+		items = append(items, svc)
+		log.Debugf("list service #%d, body: %s", i, string(item.Value))
+	}
+
+	return items, nil
+}
+
+func (s *serviceClient) Create(ctx context.Context, obj *v1.Service, shouldCompare bool) (*v1.Service, error) {
+	if v, skip := skipRequest(s.cluster, shouldCompare, s.url, obj.ID, obj); skip {
+		return v, nil
+	}
+
+	log.Debugw("try to create service",
+		zap.String("name", obj.Name),
+		zap.String("upstream_id", obj.UpstreamId),
+		zap.Any("plugins", obj.Plugins),
+		zap.String("cluster", s.cluster.name),
+		zap.String("url", s.url),
+	)
+
+	if err := s.cluster.HasSynced(ctx); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	url := s.url + "/" + obj.ID
+	log.Debugw("creating service", zap.ByteString("body", data), zap.String("url", url))
+	resp, err := s.cluster.createResource(ctx, url, "service", data)
+	if err != nil {
+		log.Errorf("failed to create service: %s", err)
+		return nil, err
+	}
+
+	svc, err := resp.service()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cluster.cache.InsertService(svc); err != nil {
+		log.Errorf("failed to reflect service create to cache: %s", err)
+		return nil, err
+	}
+	if err := s.cluster.generatedObjCache.InsertService(obj); err != nil {
+		log.Errorf("failed to cache generated service object: %s", err)
+		return nil, err
+	}
+	return svc, nil
+}
+
+func (s *serviceClient) Delete(ctx context.Context, obj *v1.Service) error {
+	log.Debugw("try to delete service",
+		zap.String("id", obj.ID),
+		zap.String("name", obj.Name),
+		zap.String("cluster", s.cluster.name),
+		zap.String("url", s.url),
+	)
+	// Deletion marks are generated for the services which might not
+	// exist, skip them to avoid needless requests.
+	if _, err := s.cluster.cache.GetService(obj.ID); err == cache.ErrNotFound {
+		return nil
+	}
+	err := s.cluster.cache.CheckServiceReference(obj)
+	if err != nil {
+		log.Warnw("deletion for service: " + obj.Name + " aborted as it is still in use.")
+		return err
+	}
+	if err := s.cluster.HasSynced(ctx); err != nil {
+		return err
+	}
+	url := s.url + "/" + obj.ID
+	if err := s.cluster.deleteResource(ctx, url, "service"); err != nil {
+		return err
+	}
+	if err := s.cluster.cache.DeleteService(obj); err != nil {
+		log.Errorf("failed to reflect service delete to cache: %s", err)
+		if err != cache.ErrNotFound {
+			return err
+		}
+	}
+	if err := s.cluster.generatedObjCache.DeleteService(obj); err != nil {
+		log.Errorf("failed to reflect service delete to generated cache: %s", err)
+		if err != cache.ErrNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *serviceClient) Update(ctx context.Context, obj *v1.Service, shouldCompare bool) (*v1.Service, error) {
+	if v, skip := skipRequest(s.cluster, shouldCompare, s.url, obj.ID, obj); skip {
+		return v, nil
+	}
+
+	log.Debugw("try to update service",
+		zap.String("id", obj.ID),
+		zap.String("name", obj.Name),
+		zap.String("upstream_id", obj.UpstreamId),
+		zap.Any("plugins", obj.Plugins),
+		zap.String("cluster", s.cluster.name),
+		zap.String("url", s.url),
+	)
+	if err := s.cluster.HasSynced(ctx); err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	url := s.url + "/" + obj.ID
+	resp, err := s.cluster.updateResource(ctx, url, "service", body)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := resp.service()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cluster.cache.InsertService(svc); err != nil {
+		log.Errorf("failed to reflect service update to cache: %s", err)
+		return nil, err
+	}
+	if err := s.cluster.generatedObjCache.InsertService(obj); err != nil {
+		log.Errorf("failed to cache generated service object: %s", err)
+		return nil, err
+	}
+	return svc, nil
+}
+
+type serviceMem struct {
+	url string
+
+	resource string
+	cluster  *cluster
+}
+
+func newServiceMem(c *cluster) Service {
+	return &serviceMem{
+		url:      c.baseURL + "/services",
+		resource: "services",
+		cluster:  c,
+	}
+}
+
+func (r *serviceMem) Get(ctx context.Context, name string) (*v1.Service, error) {
+	log.Debugw("try to look up service",
+		zap.String("name", name),
+		zap.String("url", r.url),
+		zap.String("cluster", r.cluster.name),
+	)
+	rid := id.GenID(name)
+	svc, err := r.cluster.cache.GetService(rid)
+	if err != nil {
+		log.Errorw("failed to find service in cache",
+			zap.String("name", name),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return svc, nil
+}
+
+// List is only used in cache warming up. So here just pass through
+// to APISIX.
+func (r *serviceMem) List(ctx context.Context) ([]*v1.Service, error) {
+	log.Debugw("try to list resource in APISIX",
+		zap.String("cluster", r.cluster.name),
+		zap.String("resource", r.resource),
+	)
+	services, err := r.cluster.cache.ListServices()
+	if err != nil {
+		log.Errorf("failed to list %s: %s", r.resource, err)
+		return nil, err
+	}
+	return services, nil
+}
+
+func (r *serviceMem) Create(ctx context.Context, obj *v1.Service, shouldCompare bool) (*v1.Service, error) {
+	if shouldCompare && CompareResourceEqualFromCluster(r.cluster, obj.ID, obj) {
+		return obj, nil
+	}
+	if ok, err := r.cluster.validator.ValidateHTTPPluginSchema(obj.Plugins); !ok {
+		return nil, err
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	r.cluster.CreateResource(r.resource, obj.ID, data)
+	if err := r.cluster.cache.InsertService(obj); err != nil {
+		log.Errorf("failed to reflect service create to cache: %s", err)
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (r *serviceMem) Delete(ctx context.Context, obj *v1.Service) error {
+	if _, err := r.cluster.cache.GetService(obj.ID); err == cache.ErrNotFound {
+		return nil
+	}
+	if ok, err := r.deleteCheck(ctx, obj); !ok {
+		log.Debug("failed to delete service", zap.Error(err))
+		return cache.ErrStillInUse
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	r.cluster.DeleteResource(r.resource, obj.ID, data)
+	if err := r.cluster.cache.DeleteService(obj); err != nil {
+		log.Errorf("failed to reflect service delete to cache: %s", err)
+		if err != cache.ErrNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *serviceMem) Update(ctx context.Context, obj *v1.Service, shouldCompare bool) (*v1.Service, error) {
+	if shouldCompare && CompareResourceEqualFromCluster(r.cluster, obj.ID, obj) {
+		return obj, nil
+	}
+	if ok, err := r.cluster.validator.ValidateHTTPPluginSchema(obj.Plugins); !ok {
+		return nil, err
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	r.cluster.UpdateResource(r.resource, obj.ID, data)
+	if err := r.cluster.cache.InsertService(obj); err != nil {
+		log.Errorf("failed to reflect service update to cache: %s", err)
+		return nil, err
+	}
+	return obj, nil
+}
+
+// TODO: Maintain a reference count for each object without having to poll each time
+func (r *serviceMem) deleteCheck(ctx context.Context, obj *v1.Service) (bool, error) {
+	routes, _ := r.cluster.route.List(ctx)
+	if routes == nil {
+		return true, nil
+	}
+	for _, route := range routes {
+		if route.ServiceId == obj.ID {
+			return false, fmt.Errorf("can not delete this service, route.id=%s is still using it now", route.ID)
+		}
+	}
+	return true, nil
+}
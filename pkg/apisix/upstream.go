@@ -337,7 +337,8 @@ func (u *upstreamMem) Update(ctx context.Context, obj *v1.Upstream, shouldCompar
 func (u *upstreamMem) deleteCheck(ctx context.Context, obj *v1.Upstream) (bool, error) {
 	routes, _ := u.cluster.route.List(ctx)
 	sroutes, _ := u.cluster.cache.ListStreamRoutes()
-	if routes == nil && sroutes == nil {
+	services, _ := u.cluster.cache.ListServices()
+	if routes == nil && sroutes == nil && services == nil {
 		return true, nil
 	}
 	for _, route := range routes {
@@ -350,5 +351,10 @@ func (u *upstreamMem) deleteCheck(ctx context.Context, obj *v1.Upstream) (bool,
 			return false, fmt.Errorf("can not delete this upstream, stream_route.id=%s is still using it now", sroute.ID)
 		}
 	}
+	for _, svc := range services {
+		if svc.UpstreamId == obj.ID {
+			return false, fmt.Errorf("can not delete this upstream, service.id=%s is still using it now", svc.ID)
+		}
+	}
 	return true, nil
 }
@@ -39,7 +39,7 @@ var (
 )
 
 type ResourceTypes interface {
-	*v1.Route | *v1.Ssl | *v1.Upstream | *v1.StreamRoute | *v1.GlobalRule | *v1.Consumer | *v1.PluginConfig
+	*v1.Route | *v1.Ssl | *v1.Upstream | *v1.StreamRoute | *v1.GlobalRule | *v1.Consumer | *v1.PluginConfig | *v1.Service
 }
 
 func skipRequest[T ResourceTypes](cluster *cluster, shouldCompare bool, url, id string, obj T) (T, bool) {
@@ -111,6 +111,9 @@ func skipRequest[T ResourceTypes](cluster *cluster, shouldCompare bool, url, id
 		case *v1.PluginConfig:
 			cachedGeneratedObj, err = cluster.generatedObjCache.GetPluginConfig(id)
 			resourceType = "plugin_config"
+		case *v1.Service:
+			cachedGeneratedObj, err = cluster.generatedObjCache.GetService(id)
+			resourceType = "service"
 		//case *v1.PluginMetadata:
 		default:
 			log.Errorw("resource comparison aborted",
@@ -144,6 +147,8 @@ func skipRequest[T ResourceTypes](cluster *cluster, shouldCompare bool, url, id
 					expectedServerObj, err = cluster.cache.GetConsumer(id)
 				case *v1.PluginConfig:
 					expectedServerObj, err = cluster.cache.GetPluginConfig(id)
+				case *v1.Service:
+					expectedServerObj, err = cluster.cache.GetService(id)
 				}
 
 				if err == nil && expectedServerObj != nil {
@@ -167,6 +172,8 @@ func skipRequest[T ResourceTypes](cluster *cluster, shouldCompare bool, url, id
 						serverObj, err = cluster.GetConsumer(context.Background(), url, id)
 					case *v1.PluginConfig:
 						serverObj, err = cluster.GetPluginConfig(context.Background(), url, id)
+					case *v1.Service:
+						serverObj, err = cluster.GetService(context.Background(), url, id)
 					}
 					if err == nil && serverObj != nil {
 						if reflect.DeepEqual(expectedServerObj, serverObj) {
@@ -263,6 +270,8 @@ func CompareResourceEqualFromCluster[T ResourceTypes](cluster *cluster, id strin
 		old, _ = cluster.cache.GetConsumer(id)
 	case *v1.PluginConfig:
 		old, _ = cluster.cache.GetPluginConfig(id)
+	case *v1.Service:
+		old, _ = cluster.cache.GetService(id)
 	}
 	if old == nil {
 		return false
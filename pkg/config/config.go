@@ -27,6 +27,7 @@ import (
 	"gopkg.in/yaml.v2"
 	"k8s.io/apimachinery/pkg/util/validation"
 
+	"github.com/apache/apisix-ingress-controller/pkg/id"
 	"github.com/apache/apisix-ingress-controller/pkg/types"
 )
 
@@ -64,6 +65,12 @@ const (
 	// Deployment mode
 	DeploymentMode_AdminAPI = "admin-api"
 	DeploymentMode_gRPC     = "grpc"
+
+	// How the admission webhook handles routes which conflict with the
+	// routes of other objects.
+	RouteConflictPolicyIgnore = "ignore"
+	RouteConflictPolicyWarn   = "warn"
+	RouteConflictPolicyDeny   = "deny"
 )
 
 var (
@@ -91,8 +98,12 @@ type Config struct {
 	APISIX                       APISIXConfig       `json:"apisix" yaml:"apisix"`
 	ApisixResourceSyncInterval   types.TimeDuration `json:"apisix_resource_sync_interval" yaml:"apisix_resource_sync_interval"`
 	ApisixResourceSyncComparison bool               `json:"apisix_resource_sync_comparison" yaml:"apisix_resource_sync_comparison"`
+	HealthStatusSyncInterval     types.TimeDuration `json:"health_status_sync_interval" yaml:"health_status_sync_interval"`
+	HealthStatusEvents           bool               `json:"health_status_events" yaml:"health_status_events"`
 	PluginMetadataConfigMap      string             `json:"plugin_metadata_cm" yaml:"plugin_metadata_cm"`
 	EtcdServer                   EtcdServerConfig   `json:"etcdserver" yaml:"etcdserver"`
+	ResourceIDScheme             string             `json:"resource_id_scheme" yaml:"resource_id_scheme"`
+	ResourceIDMigration          bool               `json:"resource_id_migration" yaml:"resource_id_migration"`
 }
 
 type EtcdServerConfig struct {
@@ -104,17 +115,21 @@ type EtcdServerConfig struct {
 
 // KubernetesConfig contains all Kubernetes related config items.
 type KubernetesConfig struct {
-	Kubeconfig           string             `json:"kubeconfig" yaml:"kubeconfig"`
-	ResyncInterval       types.TimeDuration `json:"resync_interval" yaml:"resync_interval"`
-	NamespaceSelector    []string           `json:"namespace_selector" yaml:"namespace_selector"`
-	ElectionID           string             `json:"election_id" yaml:"election_id"`
-	IngressClass         string             `json:"ingress_class" yaml:"ingress_class"`
-	IngressVersion       string             `json:"ingress_version" yaml:"ingress_version"`
-	WatchEndpointSlices  bool               `json:"watch_endpoint_slices" yaml:"watch_endpoint_slices"`
-	APIVersion           string             `json:"api_version" yaml:"api_version"`
-	EnableGatewayAPI     bool               `json:"enable_gateway_api" yaml:"enable_gateway_api"`
-	DisableStatusUpdates bool               `json:"disable_status_updates" yaml:"disable_status_updates"`
-	EnableAdmission      bool               `json:"enable_admission" yaml:"enable_admission"`
+	Kubeconfig              string             `json:"kubeconfig" yaml:"kubeconfig"`
+	ResyncInterval          types.TimeDuration `json:"resync_interval" yaml:"resync_interval"`
+	NamespaceSelector       []string           `json:"namespace_selector" yaml:"namespace_selector"`
+	ElectionID              string             `json:"election_id" yaml:"election_id"`
+	IngressClass            string             `json:"ingress_class" yaml:"ingress_class"`
+	IngressVersion          string             `json:"ingress_version" yaml:"ingress_version"`
+	WatchEndpointSlices     bool               `json:"watch_endpoint_slices" yaml:"watch_endpoint_slices"`
+	APIVersion              string             `json:"api_version" yaml:"api_version"`
+	EnableGatewayAPI        bool               `json:"enable_gateway_api" yaml:"enable_gateway_api"`
+	DisableStatusUpdates    bool               `json:"disable_status_updates" yaml:"disable_status_updates"`
+	EnableAdmission         bool               `json:"enable_admission" yaml:"enable_admission"`
+	RouteConflictPolicy     string             `json:"route_conflict_policy" yaml:"route_conflict_policy"`
+	DefaultIngressClassName string             `json:"default_ingress_class_name" yaml:"default_ingress_class_name"`
+	TopologyAwareRouting    bool               `json:"topology_aware_routing" yaml:"topology_aware_routing"`
+	TopologyZone            string             `json:"topology_zone" yaml:"topology_zone"`
 }
 
 // APISIXConfig contains all APISIX related config items.
@@ -128,6 +143,10 @@ type APISIXConfig struct {
 	// DefaultClusterAdminKey is the admin key for the default cluster.
 	// TODO: Obsolete the plain way to specify admin_key, which is insecure.
 	DefaultClusterAdminKey string `json:"default_cluster_admin_key" yaml:"default_cluster_admin_key"`
+	// DefaultClusterControlAPIBaseURL is the base url of the control API
+	// for the default cluster, which provides the health status of upstream
+	// nodes.
+	DefaultClusterControlAPIBaseURL string `json:"default_cluster_control_api_base_url" yaml:"default_cluster_control_api_base_url"`
 }
 
 // NewDefaultConfig creates a Config object which fills all config items with
@@ -149,6 +168,7 @@ func NewDefaultConfig() *Config {
 		EnableProfiling:              true,
 		ApisixResourceSyncInterval:   types.TimeDuration{Duration: 1 * time.Hour},
 		ApisixResourceSyncComparison: true,
+		HealthStatusSyncInterval:     types.TimeDuration{Duration: 30 * time.Second},
 		Kubernetes: KubernetesConfig{
 			Kubeconfig:           "", // Use in-cluster configurations.
 			ResyncInterval:       types.TimeDuration{Duration: 6 * time.Hour},
@@ -160,6 +180,7 @@ func NewDefaultConfig() *Config {
 			EnableGatewayAPI:     false,
 			DisableStatusUpdates: false,
 			EnableAdmission:      false,
+			RouteConflictPolicy:  RouteConflictPolicyWarn,
 		},
 		APISIX: APISIXConfig{
 			AdminAPIVersion:    "v2",
@@ -171,6 +192,8 @@ func NewDefaultConfig() *Config {
 			ListenAddress:     ":12379",
 			SSLKeyEncryptSalt: "edd1c9f0985e76a2",
 		},
+		ResourceIDScheme:    id.SchemeCRC32,
+		ResourceIDMigration: false,
 	}
 }
 
@@ -230,6 +253,21 @@ func (cfg *Config) Validate() error {
 	default:
 		return errors.New("unsupported ingress version")
 	}
+	switch cfg.Kubernetes.RouteConflictPolicy {
+	case RouteConflictPolicyIgnore, RouteConflictPolicyWarn, RouteConflictPolicyDeny:
+		break
+	default:
+		return errors.New("unsupported route conflict policy")
+	}
+	if cfg.Kubernetes.TopologyAwareRouting && cfg.Kubernetes.TopologyZone == "" {
+		return errors.New("topology zone is required by topology aware routing")
+	}
+	switch cfg.ResourceIDScheme {
+	case id.SchemeCRC32, id.SchemeSHA256:
+		break
+	default:
+		return errors.New("unsupported resource id scheme")
+	}
 	ok, err := cfg.verifyNamespaceSelector()
 	if !ok {
 		return err
@@ -42,6 +42,7 @@ func TestNewConfigFromFile(t *testing.T) {
 		EnableProfiling:              true,
 		ApisixResourceSyncInterval:   types.TimeDuration{Duration: 200 * time.Second},
 		ApisixResourceSyncComparison: true,
+		HealthStatusSyncInterval:     types.TimeDuration{Duration: 30 * time.Second},
 		Kubernetes: KubernetesConfig{
 			ResyncInterval:       types.TimeDuration{Duration: time.Hour},
 			Kubeconfig:           "/path/to/foo/baz",
@@ -50,6 +51,7 @@ func TestNewConfigFromFile(t *testing.T) {
 			IngressVersion:       IngressNetworkingV1,
 			APIVersion:           DefaultAPIVersion,
 			DisableStatusUpdates: true,
+			RouteConflictPolicy:  RouteConflictPolicyWarn,
 		},
 		APISIX: APISIXConfig{
 			AdminAPIVersion:        "v2",
@@ -63,6 +65,7 @@ func TestNewConfigFromFile(t *testing.T) {
 			ListenAddress:     ":12379",
 			SSLKeyEncryptSalt: "edd1c9f0985e76a2",
 		},
+		ResourceIDScheme: "crc32",
 	}
 
 	jsonData, err := json.Marshal(cfg)
@@ -146,6 +149,7 @@ func TestConfigWithEnvVar(t *testing.T) {
 		EnableProfiling:              true,
 		ApisixResourceSyncInterval:   types.TimeDuration{Duration: 200 * time.Second},
 		ApisixResourceSyncComparison: true,
+		HealthStatusSyncInterval:     types.TimeDuration{Duration: 30 * time.Second},
 		Kubernetes: KubernetesConfig{
 			ResyncInterval:       types.TimeDuration{Duration: time.Hour},
 			Kubeconfig:           "",
@@ -154,6 +158,7 @@ func TestConfigWithEnvVar(t *testing.T) {
 			IngressVersion:       IngressNetworkingV1,
 			APIVersion:           DefaultAPIVersion,
 			DisableStatusUpdates: true,
+			RouteConflictPolicy:  RouteConflictPolicyWarn,
 		},
 		APISIX: APISIXConfig{
 			AdminAPIVersion:        "v2",
@@ -167,6 +172,7 @@ func TestConfigWithEnvVar(t *testing.T) {
 			ListenAddress:     ":12379",
 			SSLKeyEncryptSalt: "edd1c9f0985e76a2",
 		},
+		ResourceIDScheme: "crc32",
 	}
 
 	defaultClusterBaseURLEnvName := "DEFAULT_CLUSTER_BASE_URL"
@@ -331,4 +337,13 @@ apisix:
 	err = newCfg.Validate()
 	assert.NotNil(t, err)
 	assert.Equal(t, err.Error(), "controller resync interval too small", "bad error: ", err)
+
+	newCfg = NewDefaultConfig()
+	newCfg.APISIX.DefaultClusterBaseURL = "http://127.0.0.1:1234/apisix"
+	newCfg.Kubernetes.TopologyAwareRouting = true
+	err = newCfg.Validate()
+	assert.NotNil(t, err)
+	assert.Equal(t, err.Error(), "topology zone is required by topology aware routing", "bad error: ", err)
+	newCfg.Kubernetes.TopologyZone = "zone-a"
+	assert.Nil(t, newCfg.Validate())
 }
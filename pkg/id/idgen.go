@@ -16,14 +16,88 @@
 package id
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"hash/crc32"
+	"sync"
 
 	"github.com/apache/apisix-ingress-controller/pkg/utils"
 )
 
+const (
+	// SchemeCRC32 generates IDs from the CRC32 checksum of the raw material,
+	// IDs are short but collisions are likely once there are tens of thousands
+	// of objects.
+	SchemeCRC32 = "crc32"
+	// SchemeSHA256 generates IDs from the SHA-256 digest of the raw material,
+	// truncated to 32 hex characters (128 bits) so that they fit in the APISIX
+	// ID length limit.
+	SchemeSHA256 = "sha256"
+
+	_sha256IDLength = 32
+)
+
+var (
+	scheme = SchemeCRC32
+
+	// The legacy (CRC32) IDs of the generated IDs, only recorded in the
+	// migration mode. They are kept in two generations and the older one is
+	// dropped once the newer one is full, so that the IDs which are generated
+	// but never synced don't pile up. IDs of synced objects are generated
+	// again by every translation, right before the sync looks them up.
+	migration         bool
+	legacyIDsLock     sync.Mutex
+	legacyIDs         map[string]string
+	previousLegacyIDs map[string]string
+	// cluster name -> IDs whose legacy objects were removed from the cluster
+	migratedIDs map[string]map[string]struct{}
+
+	legacyIDsGenerationSize = 1 << 16
+)
+
+// SetScheme sets the scheme used by GenID. If migrate is true and the scheme
+// isn't SchemeCRC32, the CRC32 IDs of all generated IDs are recorded so that
+// the objects created by the previous scheme can be cleaned up, see LegacyID.
+// It should be called before any ID is generated.
+func SetScheme(s string, migrate bool) error {
+	switch s {
+	case SchemeCRC32, SchemeSHA256:
+	default:
+		return fmt.Errorf("unknown resource id scheme %s", s)
+	}
+
+	legacyIDsLock.Lock()
+	defer legacyIDsLock.Unlock()
+
+	scheme = s
+	migration = migrate && s != SchemeCRC32
+	legacyIDs = make(map[string]string)
+	previousLegacyIDs = nil
+	migratedIDs = make(map[string]map[string]struct{})
+	return nil
+}
+
 // GenID generates an ID according to the raw material.
 func GenID(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	if scheme != SchemeSHA256 {
+		return GenLegacyID(raw)
+	}
+
+	sum := sha256.Sum256(utils.String2Byte(raw))
+	id := hex.EncodeToString(sum[:])[:_sha256IDLength]
+	if migration {
+		recordLegacyID(id, GenLegacyID(raw))
+	}
+	return id
+}
+
+// GenLegacyID generates an ID according to the raw material with the CRC32
+// scheme.
+func GenLegacyID(raw string) string {
 	if raw == "" {
 		return ""
 	}
@@ -32,3 +106,43 @@ func GenID(raw string) string {
 	res := crc32.ChecksumIEEE(p)
 	return fmt.Sprintf("%x", res)
 }
+
+func recordLegacyID(id, legacy string) {
+	legacyIDsLock.Lock()
+	defer legacyIDsLock.Unlock()
+	if _, ok := legacyIDs[id]; ok {
+		return
+	}
+	if len(legacyIDs) >= legacyIDsGenerationSize {
+		previousLegacyIDs = legacyIDs
+		legacyIDs = make(map[string]string)
+	}
+	legacyIDs[id] = legacy
+}
+
+// LegacyID returns the CRC32 ID of the object whose ID is id, it's only
+// available in the migration mode and before the legacy object is marked
+// as migrated in the cluster.
+func LegacyID(cluster, id string) (string, bool) {
+	legacyIDsLock.Lock()
+	defer legacyIDsLock.Unlock()
+	if _, ok := migratedIDs[cluster][id]; ok {
+		return "", false
+	}
+	legacy, ok := legacyIDs[id]
+	if !ok {
+		legacy, ok = previousLegacyIDs[id]
+	}
+	return legacy, ok
+}
+
+// MarkMigrated marks that the legacy object of the object whose ID is id was
+// removed from the cluster.
+func MarkMigrated(cluster, id string) {
+	legacyIDsLock.Lock()
+	defer legacyIDsLock.Unlock()
+	if migratedIDs[cluster] == nil {
+		migratedIDs[cluster] = make(map[string]struct{})
+	}
+	migratedIDs[cluster][id] = struct{}{}
+}
@@ -28,3 +28,69 @@ func TestGenID(t *testing.T) {
 	assert.Equal(t, GenID("111"), GenID("111"))
 	assert.NotEqual(t, GenID("112"), GenID("111"))
 }
+
+func TestGenIDSHA256(t *testing.T) {
+	assert.Nil(t, SetScheme(SchemeSHA256, false))
+	defer func() {
+		assert.Nil(t, SetScheme(SchemeCRC32, false))
+	}()
+
+	assert.Len(t, GenID(""), 0)
+	assert.Len(t, GenID("111"), 32)
+	assert.Equal(t, GenID("111"), GenID("111"))
+	assert.NotEqual(t, GenID("112"), GenID("111"))
+	assert.NotEqual(t, GenLegacyID("111"), GenID("111"))
+
+	_, ok := LegacyID("default", GenID("111"))
+	assert.False(t, ok)
+
+	assert.NotNil(t, SetScheme("md5", false))
+}
+
+func TestLegacyID(t *testing.T) {
+	assert.Nil(t, SetScheme(SchemeSHA256, true))
+	defer func() {
+		assert.Nil(t, SetScheme(SchemeCRC32, false))
+	}()
+
+	newID := GenID("111")
+	legacy, ok := LegacyID("default", newID)
+	assert.True(t, ok)
+	assert.Equal(t, GenLegacyID("111"), legacy)
+
+	MarkMigrated("default", newID)
+	_, ok = LegacyID("default", GenID("111"))
+	assert.False(t, ok)
+	// The migration is tracked per cluster.
+	legacy, ok = LegacyID("other", newID)
+	assert.True(t, ok)
+	assert.Equal(t, GenLegacyID("111"), legacy)
+}
+
+func TestLegacyIDGenerations(t *testing.T) {
+	prev := legacyIDsGenerationSize
+	legacyIDsGenerationSize = 2
+	assert.Nil(t, SetScheme(SchemeSHA256, true))
+	defer func() {
+		legacyIDsGenerationSize = prev
+		assert.Nil(t, SetScheme(SchemeCRC32, false))
+	}()
+
+	first := GenID("1")
+	GenID("2")
+	// The first generation is full, the IDs are still found in the previous one.
+	third := GenID("3")
+	_, ok := LegacyID("default", first)
+	assert.True(t, ok)
+	_, ok = LegacyID("default", third)
+	assert.True(t, ok)
+
+	// The IDs which are not generated again are dropped eventually.
+	GenID("4")
+	GenID("5")
+	_, ok = LegacyID("default", first)
+	assert.False(t, ok)
+	legacy, ok := LegacyID("default", GenID("1"))
+	assert.True(t, ok)
+	assert.Equal(t, GenLegacyID("1"), legacy)
+}
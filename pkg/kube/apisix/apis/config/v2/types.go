@@ -306,6 +306,28 @@ type ApisixClusterConfigSpec struct {
 	// Admin contains the Admin API information about APISIX cluster.
 	// +optional
 	Admin *ApisixClusterAdminConfig `json:"admin" yaml:"admin"`
+	// IngressDefaults are inherited by the Ingresses whose IngressClass
+	// refers to this ApisixClusterConfig in spec.parameters, these Ingresses
+	// are synced to this APISIX cluster.
+	// +optional
+	IngressDefaults *ApisixClusterIngressDefaults `json:"ingressDefaults,omitempty" yaml:"ingressDefaults,omitempty"`
+}
+
+// ApisixClusterIngressDefaults is the default settings of the Ingresses whose
+// IngressClass refers to the ApisixClusterConfig.
+type ApisixClusterIngressDefaults struct {
+	// Annotations are merged into the annotations of the Ingresses, the
+	// ones on the Ingresses take precedence.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	// Plugins are enabled on the routes of the Ingresses unless the same
+	// plugins are configured by the annotations. SecretRef is not supported.
+	// +optional
+	Plugins []ApisixRoutePlugin `json:"plugins,omitempty" yaml:"plugins,omitempty"`
+	// Upstream is the config of the upstreams translated from the Services
+	// without ApisixUpstream.
+	// +optional
+	Upstream *ApisixUpstreamConfig `json:"upstream,omitempty" yaml:"upstream,omitempty"`
 }
 
 // ApisixClusterMonitoringConfig categories all monitoring related features.
@@ -343,6 +365,11 @@ type ApisixClusterAdminConfig struct {
 	AdminKey string `json:"adminKey" yaml:"adminKey"`
 	// ClientTimeout is request timeout for the APISIX Admin API client
 	ClientTimeout types.TimeDuration `json:"clientTimeout" yaml:"clientTimeout"`
+	// ControlAPIBaseURL is the base URL for the APISIX Control API, which
+	// provides the health status of upstream nodes.
+	// It looks like "http://apisix-control.default.svc.cluster.local:9090"
+	// +optional
+	ControlAPIBaseURL string `json:"controlAPIBaseURL,omitempty" yaml:"controlAPIBaseURL,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -556,11 +583,50 @@ type ApisixUpstreamConfig struct {
 	// +optional
 	UpstreamHost string `json:"upstreamHost,omitempty" yaml:"upstreamHost,omitempty"`
 
+	// TopologyAware prefers the endpoints in the same zone as Apache APISIX,
+	// the endpoints in other zones are only used when the local ones are
+	// unavailable. It overrides the topology_aware_routing option of the
+	// controller.
+	// +optional
+	TopologyAware *bool `json:"topologyAware,omitempty" yaml:"topologyAware,omitempty"`
+
+	// TerminatingEndpoints decides how the terminating but still serving
+	// endpoints are handled, can be fallback or drop. The default fallback
+	// drains them, but keeps them with a low weight when no ready endpoints
+	// remain, while drop removes them at once.
+	// +optional
+	TerminatingEndpoints TerminatingEndpointsPolicy `json:"terminatingEndpoints,omitempty" yaml:"terminatingEndpoints,omitempty"`
+
+	// NodeWeight sets the weights of the upstream nodes according to
+	// their pods, the nodes weigh 100 if it's not set.
+	// +optional
+	NodeWeight *ApisixUpstreamNodeWeight `json:"nodeWeight,omitempty" yaml:"nodeWeight,omitempty"`
+
+	// SlowStart ramps up the weights of the upstream nodes since their
+	// pods become ready, so that they can warm up before taking the full
+	// traffic.
+	// +optional
+	SlowStart *ApisixUpstreamSlowStart `json:"slowStart,omitempty" yaml:"slowStart,omitempty"`
+
 	// Discovery is used to configure service discovery for upstream.
 	// +optional
 	Discovery *Discovery `json:"discovery,omitempty" yaml:"discovery,omitempty"`
 }
 
+// TerminatingEndpointsPolicy is the way to handle the terminating endpoints.
+type TerminatingEndpointsPolicy string
+
+const (
+	// TerminatingEndpointsFallback uses the terminating endpoints only when
+	// no ready endpoints remain.
+	// +k8s:deepcopy-gen=false
+	TerminatingEndpointsFallback TerminatingEndpointsPolicy = "fallback"
+
+	// TerminatingEndpointsDrop never uses the terminating endpoints.
+	// +k8s:deepcopy-gen=false
+	TerminatingEndpointsDrop TerminatingEndpointsPolicy = "drop"
+)
+
 // ApisixUpstreamExternalType is the external service type
 type ApisixUpstreamExternalType string
 
@@ -593,6 +659,39 @@ type ApisixUpstreamSubset struct {
 	Labels map[string]string `json:"labels" yaml:"labels"`
 }
 
+// ApisixUpstreamNodeWeight decides the weights of the upstream nodes by
+// the annotations and labels of their pods.
+type ApisixUpstreamNodeWeight struct {
+	// Annotation is the pod annotation whose value is the weight of the
+	// node, it takes precedence over the rules.
+	// +optional
+	Annotation string `json:"annotation,omitempty" yaml:"annotation,omitempty"`
+	// Rules are matched against the pod labels in order, the first
+	// matched one decides the weight of the node.
+	// +optional
+	Rules []ApisixUpstreamNodeWeightRule `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+// ApisixUpstreamNodeWeightRule sets the weight of the nodes whose pods
+// have all the labels.
+type ApisixUpstreamNodeWeightRule struct {
+	// Labels is the label set of the pods.
+	Labels map[string]string `json:"labels" yaml:"labels"`
+	// Weight is the weight of the nodes.
+	Weight int `json:"weight" yaml:"weight"`
+}
+
+// ApisixUpstreamSlowStart configures the slow start of the upstream nodes.
+type ApisixUpstreamSlowStart struct {
+	// Window is the duration for the weight of a node to grow to the full
+	// value since its pod becomes ready.
+	Window metav1.Duration `json:"window" yaml:"window"`
+	// InitialPercentage is the percentage of the full weight a node
+	// starts with.
+	// +optional
+	InitialPercentage int `json:"initialPercentage,omitempty" yaml:"initialPercentage,omitempty"`
+}
+
 // PortLevelSettings configures the ApisixUpstreamConfig for each individual port. It inherits
 // configurations from the outer level (the whole Kubernetes Service) and overrides some of
 // them if they are set on the port level.
@@ -189,6 +189,11 @@ func (in *ApisixClusterConfigSpec) DeepCopyInto(out *ApisixClusterConfigSpec) {
 		*out = new(ApisixClusterAdminConfig)
 		**out = **in
 	}
+	if in.IngressDefaults != nil {
+		in, out := &in.IngressDefaults, &out.IngressDefaults
+		*out = new(ApisixClusterIngressDefaults)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -202,6 +207,41 @@ func (in *ApisixClusterConfigSpec) DeepCopy() *ApisixClusterConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApisixClusterIngressDefaults) DeepCopyInto(out *ApisixClusterIngressDefaults) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]ApisixRoutePlugin, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Upstream != nil {
+		in, out := &in.Upstream, &out.Upstream
+		*out = new(ApisixUpstreamConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApisixClusterIngressDefaults.
+func (in *ApisixClusterIngressDefaults) DeepCopy() *ApisixClusterIngressDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(ApisixClusterIngressDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApisixClusterMonitoringConfig) DeepCopyInto(out *ApisixClusterMonitoringConfig) {
 	*out = *in
@@ -1427,6 +1467,21 @@ func (in *ApisixUpstreamConfig) DeepCopyInto(out *ApisixUpstreamConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TopologyAware != nil {
+		in, out := &in.TopologyAware, &out.TopologyAware
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NodeWeight != nil {
+		in, out := &in.NodeWeight, &out.NodeWeight
+		*out = new(ApisixUpstreamNodeWeight)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SlowStart != nil {
+		in, out := &in.SlowStart, &out.SlowStart
+		*out = new(ApisixUpstreamSlowStart)
+		**out = **in
+	}
 	if in.Discovery != nil {
 		in, out := &in.Discovery, &out.Discovery
 		*out = new(Discovery)
@@ -1504,6 +1559,69 @@ func (in *ApisixUpstreamList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApisixUpstreamNodeWeight) DeepCopyInto(out *ApisixUpstreamNodeWeight) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ApisixUpstreamNodeWeightRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApisixUpstreamNodeWeight.
+func (in *ApisixUpstreamNodeWeight) DeepCopy() *ApisixUpstreamNodeWeight {
+	if in == nil {
+		return nil
+	}
+	out := new(ApisixUpstreamNodeWeight)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApisixUpstreamNodeWeightRule) DeepCopyInto(out *ApisixUpstreamNodeWeightRule) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApisixUpstreamNodeWeightRule.
+func (in *ApisixUpstreamNodeWeightRule) DeepCopy() *ApisixUpstreamNodeWeightRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ApisixUpstreamNodeWeightRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApisixUpstreamSlowStart) DeepCopyInto(out *ApisixUpstreamSlowStart) {
+	*out = *in
+	out.Window = in.Window
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApisixUpstreamSlowStart.
+func (in *ApisixUpstreamSlowStart) DeepCopy() *ApisixUpstreamSlowStart {
+	if in == nil {
+		return nil
+	}
+	out := new(ApisixUpstreamSlowStart)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApisixUpstreamSpec) DeepCopyInto(out *ApisixUpstreamSpec) {
 	*out = *in
@@ -32,6 +32,15 @@ import (
 type HostPort struct {
 	Host string
 	Port int
+	// Zone is the zone where the endpoint resides, only available
+	// for EndpointSlice.
+	Zone string
+	// ZoneHints are the zones which should consume the endpoint
+	// according to the topology aware hints of EndpointSlice.
+	ZoneHints []string
+	// Terminating indicates that the endpoint is terminating but still
+	// serving, only available for EndpointSlice.
+	Terminating bool
 }
 
 // EndpointLister is an encapsulation for the lister of Kubernetes
@@ -180,14 +189,34 @@ func (e *endpoint) Endpoints(svcPort *corev1.ServicePort) []HostPort {
 			}
 			if epPort != -1 {
 				for _, ep := range slice.Endpoints {
+					terminating := false
 					if ep.Conditions.Ready != nil && !*ep.Conditions.Ready {
-						// Ignore not ready endpoints.
-						continue
+						// Ignore not ready endpoints, unless they are
+						// terminating but still serving.
+						if !isTrue(ep.Conditions.Serving) || !isTrue(ep.Conditions.Terminating) {
+							continue
+						}
+						terminating = true
+					}
+					var (
+						zone  string
+						hints []string
+					)
+					if ep.Zone != nil {
+						zone = *ep.Zone
+					}
+					if ep.Hints != nil {
+						for _, hint := range ep.Hints.ForZones {
+							hints = append(hints, hint.Name)
+						}
 					}
 					for _, addr := range ep.Addresses {
 						addrs = append(addrs, HostPort{
-							Host: addr,
-							Port: epPort,
+							Host:        addr,
+							Port:        epPort,
+							Zone:        zone,
+							ZoneHints:   hints,
+							Terminating: terminating,
 						})
 					}
 				}
@@ -197,6 +226,10 @@ func (e *endpoint) Endpoints(svcPort *corev1.ServicePort) []HostPort {
 	return addrs
 }
 
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
+
 // NewEndpointListerAndInformer creates an EndpointLister and the sharedIndexInformer.
 func NewEndpointListerAndInformer(factory informers.SharedInformerFactory, useEndpointSlice bool) (EndpointLister, cache.SharedIndexInformer) {
 	var informer cache.SharedIndexInformer
@@ -20,6 +20,7 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	listersnetworkingv1 "k8s.io/client-go/listers/networking/v1"
 	listersnetworkingv1beta1 "k8s.io/client-go/listers/networking/v1beta1"
 )
@@ -38,6 +39,10 @@ type IngressLister interface {
 	V1(string, string) (Ingress, error)
 	// V1beta1 gets the ingress in networking/v1beta1.
 	V1beta1(string, string) (Ingress, error)
+	// ListV1 lists the ingresses in networking/v1 of the namespace.
+	ListV1(string) ([]Ingress, error)
+	// ListV1beta1 lists the ingresses in networking/v1beta1 of the namespace.
+	ListV1beta1(string) ([]Ingress, error)
 }
 
 // IngressInformer is an encapsulation for the informer of Kubernetes
@@ -134,6 +139,38 @@ func (l *ingressLister) V1beta1(namespace, name string) (Ingress, error) {
 	}, nil
 }
 
+func (l *ingressLister) ListV1(namespace string) ([]Ingress, error) {
+	list, err := l.v1Lister.Ingresses(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	items := make([]Ingress, 0, len(list))
+	for _, ing := range list {
+		items = append(items, &ingress{
+			groupVersion: IngressV1,
+			v1:           ing,
+			Object:       ing,
+		})
+	}
+	return items, nil
+}
+
+func (l *ingressLister) ListV1beta1(namespace string) ([]Ingress, error) {
+	list, err := l.v1beta1Lister.Ingresses(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	items := make([]Ingress, 0, len(list))
+	for _, ing := range list {
+		items = append(items, &ingress{
+			groupVersion: IngressV1beta1,
+			v1beta1:      ing,
+			Object:       ing,
+		})
+	}
+	return items, nil
+}
+
 // MustNewIngress creates a kube.Ingress object according to the
 // type of obj.
 func MustNewIngress(obj interface{}) Ingress {
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/util/workqueue"
 )
 
 const (
@@ -52,6 +53,24 @@ type Collector interface {
 	// IncrEvents increases the number of events handled by controllers with the
 	// operation label.
 	IncrEvents(string, string)
+	// RecordTranslation records the latency of a translation with the resource
+	// type label, and increases the number of translation errors if it failed.
+	RecordTranslation(time.Duration, string, error)
+	// IncrAdmissionReviews increases the number of admission reviews with the
+	// resource (GVR) and the result (allowed, denied) labels.
+	IncrAdmissionReviews(string, string)
+	// SetCertificateExpiry sets the expiry time of the certificate with the
+	// Secret (namespace/name) and the SNI labels.
+	SetCertificateExpiry(string, string, time.Time)
+	// DeleteCertificateExpiry deletes the expiry time of the certificate with
+	// the Secret (namespace/name) and the SNI labels.
+	DeleteCertificateExpiry(string, string)
+	// SetUpstreamUnhealthyNodes sets the number of the nodes which APISIX
+	// considers unhealthy with the upstream label.
+	SetUpstreamUnhealthyNodes(string, int)
+	// DeleteUpstreamUnhealthyNodes deletes the number of unhealthy nodes
+	// with the upstream label.
+	DeleteUpstreamUnhealthyNodes(string)
 }
 
 // collector contains necessary messages to collect Prometheus metrics.
@@ -64,6 +83,12 @@ type collector struct {
 	syncOperation      *prometheus.CounterVec
 	cacheSyncOperation *prometheus.CounterVec
 	controllerEvents   *prometheus.CounterVec
+	translationLatency *prometheus.HistogramVec
+	translationErrors  *prometheus.CounterVec
+	admissionReviews   *prometheus.CounterVec
+	certificateExpiry  *prometheus.GaugeVec
+	unhealthyNodes     *prometheus.GaugeVec
+	workqueue          *workqueueMetricsProvider
 }
 
 var (
@@ -159,6 +184,53 @@ func NewPrometheusCollector() Collector {
 			},
 			[]string{"operation", "resource"},
 		),
+		translationLatency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace:   _namespace,
+				Name:        "translation_duration_seconds",
+				Help:        "Latencies of translating Kubernetes resources to APISIX resources",
+				Buckets:     prometheus.ExponentialBuckets(0.0001, 4, 10),
+				ConstLabels: constLabels,
+			},
+			[]string{"resource", "result"},
+		),
+		translationErrors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   _namespace,
+				Name:        "translation_errors_total",
+				Help:        "Number of failed translations of Kubernetes resources",
+				ConstLabels: constLabels,
+			},
+			[]string{"resource"},
+		),
+		admissionReviews: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   _namespace,
+				Name:        "admission_reviews_total",
+				Help:        "Number of admission reviews handled by the webhook",
+				ConstLabels: constLabels,
+			},
+			[]string{"resource", "result"},
+		),
+		certificateExpiry: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace:   _namespace,
+				Name:        "certificate_expiry_timestamp_seconds",
+				Help:        "Expiry time of the certificates in Secrets, in seconds since the Unix epoch",
+				ConstLabels: constLabels,
+			},
+			[]string{"secret", "sni"},
+		),
+		unhealthyNodes: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace:   _namespace,
+				Name:        "upstream_unhealthy_nodes",
+				Help:        "Number of upstream nodes which the health checks of APISIX consider unhealthy",
+				ConstLabels: constLabels,
+			},
+			[]string{"upstream"},
+		),
+		workqueue: newWorkqueueMetricsProvider(constLabels),
 	}
 
 	// Since we use the DefaultRegisterer, in test cases, the metrics
@@ -171,6 +243,14 @@ func NewPrometheusCollector() Collector {
 	prometheus.Unregister(collector.syncOperation)
 	prometheus.Unregister(collector.cacheSyncOperation)
 	prometheus.Unregister(collector.controllerEvents)
+	prometheus.Unregister(collector.translationLatency)
+	prometheus.Unregister(collector.translationErrors)
+	prometheus.Unregister(collector.admissionReviews)
+	prometheus.Unregister(collector.certificateExpiry)
+	prometheus.Unregister(collector.unhealthyNodes)
+	for _, c := range collector.workqueue.collectors() {
+		prometheus.Unregister(c)
+	}
 
 	prometheus.MustRegister(
 		collector.isLeader,
@@ -181,7 +261,15 @@ func NewPrometheusCollector() Collector {
 		collector.syncOperation,
 		collector.cacheSyncOperation,
 		collector.controllerEvents,
+		collector.translationLatency,
+		collector.translationErrors,
+		collector.admissionReviews,
+		collector.certificateExpiry,
+		collector.unhealthyNodes,
 	)
+	prometheus.MustRegister(collector.workqueue.collectors()...)
+	// Workqueues created afterwards report their metrics via the provider.
+	workqueue.SetProvider(collector.workqueue)
 
 	globalCollector = collector
 	return collector
@@ -253,6 +341,59 @@ func (c *collector) IncrEvents(resource, operation string) {
 	}).Inc()
 }
 
+// RecordTranslation records the latency of translating the specific
+// resource, the translation errors are also counted.
+func (c *collector) RecordTranslation(latency time.Duration, resource string, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+		c.translationErrors.WithLabelValues(resource).Inc()
+	}
+	c.translationLatency.With(prometheus.Labels{
+		"resource": resource,
+		"result":   result,
+	}).Observe(latency.Seconds())
+}
+
+// IncrAdmissionReviews increases the number of admission reviews for
+// specific resource.
+func (c *collector) IncrAdmissionReviews(resource, result string) {
+	c.admissionReviews.With(prometheus.Labels{
+		"resource": resource,
+		"result":   result,
+	}).Inc()
+}
+
+// SetCertificateExpiry sets the expiry time of the certificate in the
+// Secret for the specific SNI.
+func (c *collector) SetCertificateExpiry(secret, sni string, expiry time.Time) {
+	c.certificateExpiry.With(prometheus.Labels{
+		"secret": secret,
+		"sni":    sni,
+	}).Set(float64(expiry.Unix()))
+}
+
+// DeleteCertificateExpiry deletes the expiry time of the certificate in
+// the Secret for the specific SNI.
+func (c *collector) DeleteCertificateExpiry(secret, sni string) {
+	c.certificateExpiry.Delete(prometheus.Labels{
+		"secret": secret,
+		"sni":    sni,
+	})
+}
+
+// SetUpstreamUnhealthyNodes sets the number of unhealthy nodes of the
+// upstream.
+func (c *collector) SetUpstreamUnhealthyNodes(upstream string, count int) {
+	c.unhealthyNodes.WithLabelValues(upstream).Set(float64(count))
+}
+
+// DeleteUpstreamUnhealthyNodes deletes the number of unhealthy nodes of
+// the upstream.
+func (c *collector) DeleteUpstreamUnhealthyNodes(upstream string) {
+	c.unhealthyNodes.DeleteLabelValues(upstream)
+}
+
 // Collect collects the prometheus.Collect.
 func (c *collector) Collect(ch chan<- prometheus.Metric) {
 	c.isLeader.Collect(ch)
@@ -263,6 +404,14 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 	c.syncOperation.Collect(ch)
 	c.cacheSyncOperation.Collect(ch)
 	c.controllerEvents.Collect(ch)
+	c.translationLatency.Collect(ch)
+	c.translationErrors.Collect(ch)
+	c.admissionReviews.Collect(ch)
+	c.certificateExpiry.Collect(ch)
+	c.unhealthyNodes.Collect(ch)
+	for _, wc := range c.workqueue.collectors() {
+		wc.Collect(ch)
+	}
 }
 
 // Describe describes the prometheus.Describe.
@@ -275,4 +424,12 @@ func (c *collector) Describe(ch chan<- *prometheus.Desc) {
 	c.syncOperation.Describe(ch)
 	c.cacheSyncOperation.Describe(ch)
 	c.controllerEvents.Describe(ch)
+	c.translationLatency.Describe(ch)
+	c.translationErrors.Describe(ch)
+	c.admissionReviews.Describe(ch)
+	c.certificateExpiry.Describe(ch)
+	c.unhealthyNodes.Describe(ch)
+	for _, wc := range c.workqueue.collectors() {
+		wc.Describe(ch)
+	}
 }
@@ -15,12 +15,14 @@
 package metrics
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	io_prometheus_client "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/util/workqueue"
 )
 
 func apisixStatusCodesTestHandler(t *testing.T, metrics []*io_prometheus_client.MetricFamily) func(*testing.T) {
@@ -206,6 +208,104 @@ func controllerEventsTestHandler(t *testing.T, metrics []*io_prometheus_client.M
 	}
 }
 
+func translationTestHandler(t *testing.T, metrics []*io_prometheus_client.MetricFamily) func(t *testing.T) {
+	return func(t *testing.T) {
+		metric := findMetric("apisix_ingress_controller_translation_duration_seconds", metrics)
+		assert.NotNil(t, metric)
+		assert.Equal(t, "HISTOGRAM", metric.Type.String())
+		m := metric.GetMetric()
+		assert.Len(t, m, 2)
+
+		assert.Equal(t, uint64(1), *m[0].Histogram.SampleCount)
+		assert.Equal(t, "resource", *m[0].Label[2].Name)
+		assert.Equal(t, "route", *m[0].Label[2].Value)
+		assert.Equal(t, "result", *m[0].Label[3].Name)
+		assert.Equal(t, "failure", *m[0].Label[3].Value)
+		assert.Equal(t, "success", *m[1].Label[3].Value)
+
+		metric = findMetric("apisix_ingress_controller_translation_errors_total", metrics)
+		assert.NotNil(t, metric)
+		m = metric.GetMetric()
+		assert.Len(t, m, 1)
+		assert.Equal(t, float64(1), *m[0].Counter.Value)
+		assert.Equal(t, "resource", *m[0].Label[2].Name)
+		assert.Equal(t, "route", *m[0].Label[2].Value)
+	}
+}
+
+func admissionReviewsTestHandler(t *testing.T, metrics []*io_prometheus_client.MetricFamily) func(t *testing.T) {
+	return func(t *testing.T) {
+		metric := findMetric("apisix_ingress_controller_admission_reviews_total", metrics)
+		assert.NotNil(t, metric)
+		assert.Equal(t, "COUNTER", metric.Type.String())
+		m := metric.GetMetric()
+		assert.Len(t, m, 2)
+
+		assert.Equal(t, float64(2), *m[0].Counter.Value)
+		assert.Equal(t, "resource", *m[0].Label[2].Name)
+		assert.Equal(t, "apisix.apache.org/v2/apisixroutes", *m[0].Label[2].Value)
+		assert.Equal(t, "result", *m[0].Label[3].Name)
+		assert.Equal(t, "allowed", *m[0].Label[3].Value)
+		assert.Equal(t, float64(1), *m[1].Counter.Value)
+		assert.Equal(t, "denied", *m[1].Label[3].Value)
+	}
+}
+
+func certificateExpiryTestHandler(t *testing.T, metrics []*io_prometheus_client.MetricFamily) func(t *testing.T) {
+	return func(t *testing.T) {
+		metric := findMetric("apisix_ingress_controller_certificate_expiry_timestamp_seconds", metrics)
+		assert.NotNil(t, metric)
+		assert.Equal(t, "GAUGE", metric.Type.String())
+		m := metric.GetMetric()
+		assert.Len(t, m, 1)
+
+		assert.Equal(t, float64(1700000000), *m[0].Gauge.Value)
+		assert.Equal(t, "secret", *m[0].Label[2].Name)
+		assert.Equal(t, "default/cert", *m[0].Label[2].Value)
+		assert.Equal(t, "sni", *m[0].Label[3].Name)
+		assert.Equal(t, "foo.com", *m[0].Label[3].Value)
+	}
+}
+
+func upstreamUnhealthyNodesTestHandler(t *testing.T, metrics []*io_prometheus_client.MetricFamily) func(t *testing.T) {
+	return func(t *testing.T) {
+		metric := findMetric("apisix_ingress_controller_upstream_unhealthy_nodes", metrics)
+		assert.NotNil(t, metric)
+		assert.Equal(t, "GAUGE", metric.Type.String())
+		m := metric.GetMetric()
+		assert.Len(t, m, 1)
+
+		assert.Equal(t, float64(2), *m[0].Gauge.Value)
+		assert.Equal(t, "upstream", *m[0].Label[2].Name)
+		assert.Equal(t, "default_httpbin_80", *m[0].Label[2].Value)
+	}
+}
+
+func workqueueTestHandler(t *testing.T, metrics []*io_prometheus_client.MetricFamily) func(t *testing.T) {
+	return func(t *testing.T) {
+		metric := findMetric("apisix_ingress_controller_workqueue_depth", metrics)
+		assert.NotNil(t, metric)
+		m := metric.GetMetric()
+		assert.Len(t, m, 1)
+		assert.Equal(t, float64(1), *m[0].Gauge.Value)
+		assert.Equal(t, "name", *m[0].Label[2].Name)
+		assert.Equal(t, "ApisixRoute", *m[0].Label[2].Value)
+
+		metric = findMetric("apisix_ingress_controller_workqueue_adds_total", metrics)
+		assert.NotNil(t, metric)
+		m = metric.GetMetric()
+		assert.Len(t, m, 1)
+		// The rate limited item isn't added until its backoff elapses.
+		assert.Equal(t, float64(1), *m[0].Counter.Value)
+
+		metric = findMetric("apisix_ingress_controller_workqueue_retries_total", metrics)
+		assert.NotNil(t, metric)
+		m = metric.GetMetric()
+		assert.Len(t, m, 1)
+		assert.Equal(t, float64(1), *m[0].Counter.Value)
+	}
+}
+
 func TestPrometheusCollector(t *testing.T) {
 	c := NewPrometheusCollector()
 	c.ResetLeader(true)
@@ -220,6 +320,22 @@ func TestPrometheusCollector(t *testing.T) {
 	c.IncrSyncOperation("endpoint", "success")
 	c.IncrCacheSyncOperation("failure")
 	c.IncrEvents("pod", "add")
+	c.RecordTranslation(time.Millisecond, "route", nil)
+	c.RecordTranslation(time.Millisecond, "route", errors.New("bad route"))
+	c.IncrAdmissionReviews("apisix.apache.org/v2/apisixroutes", "allowed")
+	c.IncrAdmissionReviews("apisix.apache.org/v2/apisixroutes", "allowed")
+	c.IncrAdmissionReviews("apisix.apache.org/v2/apisixroutes", "denied")
+	c.SetCertificateExpiry("default/cert", "foo.com", time.Unix(1700000000, 0))
+	c.SetCertificateExpiry("default/cert", "bar.com", time.Unix(1700000000, 0))
+	c.DeleteCertificateExpiry("default/cert", "bar.com")
+	c.SetUpstreamUnhealthyNodes("default_httpbin_80", 2)
+	c.SetUpstreamUnhealthyNodes("default_httpbin_443", 1)
+	c.DeleteUpstreamUnhealthyNodes("default_httpbin_443")
+
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "ApisixRoute")
+	defer queue.ShutDown()
+	queue.Add("default/route1")
+	queue.AddRateLimited("default/route2")
 
 	metrics, err := prometheus.DefaultGatherer.Gather()
 	assert.Nil(t, err)
@@ -232,6 +348,11 @@ func TestPrometheusCollector(t *testing.T) {
 	t.Run("sync_operation_total", syncOperationTestHandler(t, metrics))
 	t.Run("cache_sync_total", cacheSncOperationTestHandler(t, metrics))
 	t.Run("events_total", controllerEventsTestHandler(t, metrics))
+	t.Run("translation", translationTestHandler(t, metrics))
+	t.Run("admission_reviews_total", admissionReviewsTestHandler(t, metrics))
+	t.Run("certificate_expiry_timestamp_seconds", certificateExpiryTestHandler(t, metrics))
+	t.Run("upstream_unhealthy_nodes", upstreamUnhealthyNodesTestHandler(t, metrics))
+	t.Run("workqueue", workqueueTestHandler(t, metrics))
 }
 
 func findMetric(name string, metrics []*io_prometheus_client.MetricFamily) *io_prometheus_client.MetricFamily {
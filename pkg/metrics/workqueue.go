@@ -0,0 +1,155 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	_workqueueSubsystem = "workqueue"
+)
+
+// workqueueMetricsProvider implements the workqueue.MetricsProvider interface,
+// all metrics are labelled by the name of the workqueue, which is the resource
+// handled by the controller.
+type workqueueMetricsProvider struct {
+	depth                   *prometheus.GaugeVec
+	adds                    *prometheus.CounterVec
+	latency                 *prometheus.HistogramVec
+	workDuration            *prometheus.HistogramVec
+	unfinishedWork          *prometheus.GaugeVec
+	longestRunningProcessor *prometheus.GaugeVec
+	retries                 *prometheus.CounterVec
+}
+
+func newWorkqueueMetricsProvider(constLabels prometheus.Labels) *workqueueMetricsProvider {
+	labels := []string{"name"}
+	return &workqueueMetricsProvider{
+		depth: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace:   _namespace,
+				Subsystem:   _workqueueSubsystem,
+				Name:        "depth",
+				Help:        "Current depth of workqueue",
+				ConstLabels: constLabels,
+			},
+			labels,
+		),
+		adds: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   _namespace,
+				Subsystem:   _workqueueSubsystem,
+				Name:        "adds_total",
+				Help:        "Number of adds handled by workqueue",
+				ConstLabels: constLabels,
+			},
+			labels,
+		),
+		latency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace:   _namespace,
+				Subsystem:   _workqueueSubsystem,
+				Name:        "queue_duration_seconds",
+				Help:        "How long in seconds an item stays in workqueue before being requested",
+				Buckets:     prometheus.ExponentialBuckets(10e-9, 10, 10),
+				ConstLabels: constLabels,
+			},
+			labels,
+		),
+		workDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace:   _namespace,
+				Subsystem:   _workqueueSubsystem,
+				Name:        "work_duration_seconds",
+				Help:        "How long in seconds processing an item from workqueue takes",
+				Buckets:     prometheus.ExponentialBuckets(10e-9, 10, 10),
+				ConstLabels: constLabels,
+			},
+			labels,
+		),
+		unfinishedWork: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace:   _namespace,
+				Subsystem:   _workqueueSubsystem,
+				Name:        "unfinished_work_seconds",
+				Help:        "How many seconds of work has been done that is in progress",
+				ConstLabels: constLabels,
+			},
+			labels,
+		),
+		longestRunningProcessor: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace:   _namespace,
+				Subsystem:   _workqueueSubsystem,
+				Name:        "longest_running_processor_seconds",
+				Help:        "How many seconds has the longest running processor for workqueue been running",
+				ConstLabels: constLabels,
+			},
+			labels,
+		),
+		retries: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   _namespace,
+				Subsystem:   _workqueueSubsystem,
+				Name:        "retries_total",
+				Help:        "Number of retries handled by workqueue",
+				ConstLabels: constLabels,
+			},
+			labels,
+		),
+	}
+}
+
+func (p *workqueueMetricsProvider) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		p.depth,
+		p.adds,
+		p.latency,
+		p.workDuration,
+		p.unfinishedWork,
+		p.longestRunningProcessor,
+		p.retries,
+	}
+}
+
+func (p *workqueueMetricsProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+	return p.depth.WithLabelValues(name)
+}
+
+func (p *workqueueMetricsProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+	return p.adds.WithLabelValues(name)
+}
+
+func (p *workqueueMetricsProvider) NewLatencyMetric(name string) workqueue.HistogramMetric {
+	return p.latency.WithLabelValues(name)
+}
+
+func (p *workqueueMetricsProvider) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
+	return p.workDuration.WithLabelValues(name)
+}
+
+func (p *workqueueMetricsProvider) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return p.unfinishedWork.WithLabelValues(name)
+}
+
+func (p *workqueueMetricsProvider) NewLongestRunningProcessorSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return p.longestRunningProcessor.WithLabelValues(name)
+}
+
+func (p *workqueueMetricsProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return p.retries.WithLabelValues(name)
+}
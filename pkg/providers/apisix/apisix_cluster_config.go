@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
@@ -38,18 +39,42 @@ import (
 	"github.com/apache/apisix-ingress-controller/pkg/types"
 )
 
+const _clusterHealthCheckInterval = 5 * time.Second
+
 type apisixClusterConfigController struct {
 	*apisixCommon
 
 	workqueue workqueue.RateLimitingInterface
 	workers   int
+
+	// clusters records the admin configuration applied for each
+	// APISIX cluster, keyed by the cluster name.
+	clusterLock sync.Mutex
+	clusters    map[string]*clusterState
+
+	// onClusterSynced is called when a non-default cluster
+	// was registered and synced.
+	onClusterSynced func(name string)
 }
 
-func newApisixClusterConfigController(common *apisixCommon) *apisixClusterConfigController {
+// clusterState is the state of an APISIX cluster registered
+// from an ApisixClusterConfig.
+type clusterState struct {
+	admin  configv2.ApisixClusterAdminConfig
+	synced bool
+	// stopHealthCheck stops the health check loop, it's nil
+	// for the default cluster.
+	stopHealthCheck context.CancelFunc
+}
+
+func newApisixClusterConfigController(common *apisixCommon, onClusterSynced func(string)) *apisixClusterConfigController {
 	c := &apisixClusterConfigController{
 		apisixCommon: common,
 		workqueue:    workqueue.NewNamedRateLimitingQueue(workqueue.NewItemFastSlowRateLimiter(time.Second, 60*time.Second, 5), "ApisixClusterConfig"),
 		workers:      1,
+		clusters:     make(map[string]*clusterState),
+
+		onClusterSynced: onClusterSynced,
 	}
 	c.ApisixClusterConfigInformer.AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
@@ -65,6 +90,7 @@ func (c *apisixClusterConfigController) run(ctx context.Context) {
 	log.Info("ApisixClusterConfig controller started")
 	defer log.Info("ApisixClusterConfig controller exited")
 	defer c.workqueue.ShutDown()
+	defer c.stopHealthChecks()
 
 	for i := 0; i < c.workers; i++ {
 		go c.runWorker(ctx)
@@ -136,46 +162,39 @@ func (c *apisixClusterConfigController) sync(ctx context.Context, ev *types.Even
 	switch event.GroupVersion {
 	case config.ApisixV2:
 		acc := multiVersioned.V2()
-		// Currently we don't handle multiple cluster, so only process
-		// the default apisix cluster.
-		if acc.Name != c.Config.APISIX.DefaultClusterName {
-			log.Infow("ignore non-default apisix cluster config",
-				zap.String("default_cluster_name", c.Config.APISIX.DefaultClusterName),
-				zap.Any("ApisixClusterConfig", acc),
-			)
-			return nil
-		}
-		// Cluster delete is dangerous.
-		// TODO handle delete?
+		isDefault := acc.Name == c.Config.APISIX.DefaultClusterName
 		if ev.Type == types.EventDelete {
-			log.Error("ApisixClusterConfig delete event for default apisix cluster will be ignored")
+			// Cluster delete is dangerous.
+			if isDefault {
+				log.Error("ApisixClusterConfig delete event for default apisix cluster will be ignored")
+				return nil
+			}
+			c.removeCluster(acc.Name)
 			return nil
 		}
 
-		if acc.Spec.Admin != nil {
-			clusterOpts := &apisix.ClusterOptions{
-				Name:     acc.Name,
-				BaseURL:  acc.Spec.Admin.BaseURL,
-				AdminKey: acc.Spec.Admin.AdminKey,
-			}
-			log.Infow("updating cluster",
-				zap.Any("opts", clusterOpts),
-			)
-			// TODO we may first call AddCluster.
-			// Since now we already have the default cluster, we just call UpdateCluster.
-			if err := c.APISIX.UpdateCluster(ctx, clusterOpts); err != nil {
-				log.Errorw("failed to update cluster",
+		if acc.Spec.Admin == nil {
+			if !isDefault {
+				// The cluster is unreachable without the Admin API information.
+				c.removeCluster(acc.Name)
+				log.Warnw("ignore apisix cluster config without admin configuration",
 					zap.String("cluster_name", acc.Name),
-					zap.Error(err),
-					zap.Any("opts", clusterOpts),
 				)
-				c.RecordEvent(acc, corev1.EventTypeWarning, utils.ResourceSyncAborted, err)
-				c.recordStatus(acc, utils.ResourceSyncAborted, err, metav1.ConditionFalse, acc.GetGeneration())
-				return err
+				return nil
 			}
+		} else if err := c.syncCluster(ctx, acc); err != nil {
+			log.Errorw("failed to sync cluster",
+				zap.String("cluster_name", acc.Name),
+				zap.Error(err),
+			)
+			c.RecordEvent(acc, corev1.EventTypeWarning, utils.ResourceSyncAborted, err)
+			c.recordStatus(acc, utils.ResourceSyncAborted, err, metav1.ConditionFalse, acc.GetGeneration())
+			return err
 		}
 
+		start := time.Now()
 		globalRule, err := c.translator.TranslateClusterConfigV2(acc)
+		c.MetricsCollector.RecordTranslation(time.Since(start), "clusterConfig", err)
 		if err != nil {
 			log.Errorw("failed to translate ApisixClusterConfig",
 				zap.Error(err),
@@ -190,7 +209,6 @@ func (c *apisixClusterConfigController) sync(ctx context.Context, ev *types.Even
 			zap.Any("object", globalRule),
 		)
 
-		// TODO multiple cluster support
 		if ev.Type.IsAddEvent() {
 			_, err = c.APISIX.Cluster(acc.Name).GlobalRule().Create(ctx, globalRule, ev.Type.IsSyncEvent())
 		} else {
@@ -213,6 +231,182 @@ func (c *apisixClusterConfigController) sync(ctx context.Context, ev *types.Even
 	}
 }
 
+// syncCluster registers the APISIX cluster described by the ApisixClusterConfig,
+// or updates it if the admin configuration was changed, then waits for the
+// cluster cache to be synced.
+func (c *apisixClusterConfigController) syncCluster(ctx context.Context, acc *configv2.ApisixClusterConfig) error {
+	if c.Config.EtcdServer.Enabled {
+		log.Warnw("admin configuration is ignored in etcd server mode",
+			zap.String("cluster_name", acc.Name),
+		)
+		return nil
+	}
+
+	state, err := c.registerCluster(ctx, acc)
+	if err != nil || state == nil {
+		return err
+	}
+	// The cache sync may take long, it's waited without holding the lock,
+	// so that the other clusters can still be removed meanwhile.
+	if err = c.APISIX.Cluster(acc.Name).HasSynced(ctx); err != nil {
+		return err
+	}
+
+	c.clusterLock.Lock()
+	defer c.clusterLock.Unlock()
+
+	if c.clusters[acc.Name] != state || state.admin != *acc.Spec.Admin {
+		// The cluster was removed or changed while waiting.
+		return nil
+	}
+	state.synced = true
+
+	if acc.Name != c.Config.APISIX.DefaultClusterName && state.stopHealthCheck == nil {
+		// The health of default cluster is checked by the controller itself.
+		hcCtx, cancel := context.WithCancel(ctx)
+		state.stopHealthCheck = cancel
+		go c.checkClusterHealth(hcCtx, acc.Name)
+	}
+	if acc.Name != c.Config.APISIX.DefaultClusterName {
+		go c.onClusterSynced(acc.Name)
+	}
+	return nil
+}
+
+// registerCluster adds or updates the APISIX cluster, and returns its state
+// which waits for the cache sync. A nil state is returned if the cluster is
+// already synced with the same admin configuration.
+func (c *apisixClusterConfigController) registerCluster(ctx context.Context, acc *configv2.ApisixClusterConfig) (*clusterState, error) {
+	c.clusterLock.Lock()
+	defer c.clusterLock.Unlock()
+
+	state, ok := c.clusters[acc.Name]
+	if ok && state.synced && state.admin == *acc.Spec.Admin {
+		return nil, nil
+	}
+
+	clusterOpts := &apisix.ClusterOptions{
+		AdminAPIVersion:   c.Config.APISIX.AdminAPIVersion,
+		Name:              acc.Name,
+		BaseURL:           acc.Spec.Admin.BaseURL,
+		AdminKey:          acc.Spec.Admin.AdminKey,
+		ControlAPIBaseURL: acc.Spec.Admin.ControlAPIBaseURL,
+		Timeout:           acc.Spec.Admin.ClientTimeout.Duration,
+		MetricsCollector:  c.MetricsCollector,
+		SyncComparison:    c.Config.ApisixResourceSyncComparison,
+		SchemaSynced:      true,
+		CacheSynced:       true,
+		SSLKeyEncryptSalt: c.Config.EtcdServer.SSLKeyEncryptSalt,
+	}
+	if clusterOpts.ControlAPIBaseURL == "" && acc.Name == c.Config.APISIX.DefaultClusterName {
+		clusterOpts.ControlAPIBaseURL = c.Config.APISIX.DefaultClusterControlAPIBaseURL
+	}
+	log.Infow("syncing cluster",
+		zap.String("cluster_name", acc.Name),
+		zap.String("base_url", clusterOpts.BaseURL),
+	)
+	var err error
+	if c.APISIX.HasCluster(acc.Name) {
+		err = c.APISIX.UpdateCluster(ctx, clusterOpts)
+	} else {
+		err = c.APISIX.AddCluster(ctx, clusterOpts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		state = &clusterState{}
+		c.clusters[acc.Name] = state
+	}
+	state.admin = *acc.Spec.Admin
+	state.synced = false
+	return state, nil
+}
+
+// removeCluster stops the health check and deletes the APISIX cluster.
+func (c *apisixClusterConfigController) removeCluster(name string) {
+	c.clusterLock.Lock()
+	defer c.clusterLock.Unlock()
+
+	if state, ok := c.clusters[name]; ok {
+		if state.stopHealthCheck != nil {
+			state.stopHealthCheck()
+		}
+		delete(c.clusters, name)
+	}
+	if c.APISIX.HasCluster(name) {
+		c.APISIX.DeleteCluster(name)
+		log.Infow("apisix cluster deleted",
+			zap.String("cluster_name", name),
+		)
+	}
+}
+
+func (c *apisixClusterConfigController) stopHealthChecks() {
+	c.clusterLock.Lock()
+	defer c.clusterLock.Unlock()
+
+	for _, state := range c.clusters {
+		if state.stopHealthCheck != nil {
+			state.stopHealthCheck()
+		}
+	}
+}
+
+// checkClusterHealth checks the health of a non-default APISIX cluster
+// periodically, and reflects the changes to the ApisixClusterConfig status.
+func (c *apisixClusterConfigController) checkClusterHealth(ctx context.Context, name string) {
+	t := time.NewTicker(_clusterHealthCheckInterval)
+	defer t.Stop()
+
+	healthy := true
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		err := c.APISIX.Cluster(name).HealthCheck(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			c.MetricsCollector.IncrCheckClusterHealth(name)
+		}
+		if healthy == (err == nil) {
+			continue
+		}
+		healthy = err == nil
+
+		obj, lerr := c.ApisixClusterConfigLister.V2(name)
+		if lerr != nil {
+			log.Warnw("failed to get ApisixClusterConfig for health status",
+				zap.String("cluster_name", name),
+				zap.Error(lerr),
+			)
+			continue
+		}
+		acc := obj.V2()
+		if healthy {
+			log.Infow("apisix cluster is healthy again",
+				zap.String("cluster_name", name),
+			)
+			c.RecordEvent(acc, corev1.EventTypeNormal, utils.ResourceSynced, nil)
+			c.recordStatus(acc, utils.ResourceSynced, nil, metav1.ConditionTrue, acc.GetGeneration())
+		} else {
+			log.Warnw("apisix cluster is unhealthy",
+				zap.String("cluster_name", name),
+				zap.Error(err),
+			)
+			err = fmt.Errorf("health check failed: %s", err)
+			c.RecordEvent(acc, corev1.EventTypeWarning, utils.ClusterUnhealthy, err)
+			c.recordStatus(acc, utils.ClusterUnhealthy, err, metav1.ConditionFalse, acc.GetGeneration())
+		}
+	}
+}
+
 func (c *apisixClusterConfigController) handleSyncErr(obj interface{}, err error) {
 	if err == nil {
 		c.workqueue.Forget(obj)
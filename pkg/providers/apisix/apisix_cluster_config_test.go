@@ -0,0 +1,200 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package apisix
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apache/apisix-ingress-controller/pkg/apisix"
+	"github.com/apache/apisix-ingress-controller/pkg/config"
+	configv2 "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/apis/config/v2"
+	providertypes "github.com/apache/apisix-ingress-controller/pkg/providers/types"
+)
+
+type fakeCluster struct {
+	apisix.Cluster
+	// synced blocks HasSynced until it's closed.
+	synced chan struct{}
+}
+
+func (c *fakeCluster) HasSynced(ctx context.Context) error {
+	select {
+	case <-c.synced:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *fakeCluster) HealthCheck(context.Context) error {
+	return nil
+}
+
+type fakeAPISIX struct {
+	apisix.APISIX
+
+	sync.Mutex
+	clusters map[string]*fakeCluster
+	added    []string
+	updated  []string
+	deleted  []string
+	// registered is notified once a cluster is added or updated.
+	registered chan string
+}
+
+func (a *fakeAPISIX) AddCluster(_ context.Context, co *apisix.ClusterOptions) error {
+	a.Lock()
+	a.clusters[co.Name] = &fakeCluster{synced: make(chan struct{})}
+	a.added = append(a.added, co.Name)
+	a.Unlock()
+	a.registered <- co.Name
+	return nil
+}
+
+func (a *fakeAPISIX) UpdateCluster(_ context.Context, co *apisix.ClusterOptions) error {
+	a.Lock()
+	a.updated = append(a.updated, co.Name)
+	a.Unlock()
+	a.registered <- co.Name
+	return nil
+}
+
+func (a *fakeAPISIX) HasCluster(name string) bool {
+	a.Lock()
+	defer a.Unlock()
+	_, ok := a.clusters[name]
+	return ok
+}
+
+func (a *fakeAPISIX) Cluster(name string) apisix.Cluster {
+	a.Lock()
+	defer a.Unlock()
+	return a.clusters[name]
+}
+
+func (a *fakeAPISIX) DeleteCluster(name string) {
+	a.Lock()
+	defer a.Unlock()
+	delete(a.clusters, name)
+	a.deleted = append(a.deleted, name)
+}
+
+func newFakeClusterConfigController(apisix *fakeAPISIX, onClusterSynced func(string)) *apisixClusterConfigController {
+	return &apisixClusterConfigController{
+		apisixCommon: &apisixCommon{
+			Common: &providertypes.Common{
+				Config: config.NewDefaultConfig(),
+				APISIX: apisix,
+			},
+		},
+		clusters:        make(map[string]*clusterState),
+		onClusterSynced: onClusterSynced,
+	}
+}
+
+func newClusterConfig(name, baseURL string) *configv2.ApisixClusterConfig {
+	return &configv2.ApisixClusterConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: configv2.ApisixClusterConfigSpec{
+			Admin: &configv2.ApisixClusterAdminConfig{
+				BaseURL:  baseURL,
+				AdminKey: "edd1c9f034335f136f87ad84b625c8f1",
+			},
+		},
+	}
+}
+
+func TestSyncClusterAddAndRemove(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fake := &fakeAPISIX{
+		clusters:   make(map[string]*fakeCluster),
+		registered: make(chan string, 2),
+	}
+	syncedCh := make(chan string, 1)
+	c := newFakeClusterConfigController(fake, func(name string) {
+		syncedCh <- name
+	})
+
+	acc := newClusterConfig("edge", "http://edge:9180/apisix/admin")
+	go func() {
+		// The cache of the new cluster is synced.
+		close(fake.Cluster(<-fake.registered).(*fakeCluster).synced)
+	}()
+	assert.Nil(t, c.syncCluster(ctx, acc))
+	assert.Equal(t, "edge", <-syncedCh)
+	assert.Equal(t, []string{"edge"}, fake.added)
+	assert.True(t, c.clusters["edge"].synced)
+	assert.NotNil(t, c.clusters["edge"].stopHealthCheck)
+
+	// Nothing changed.
+	assert.Nil(t, c.syncCluster(ctx, acc))
+	assert.Len(t, fake.updated, 0)
+
+	// The admin configuration changed.
+	acc = newClusterConfig("edge", "http://edge-new:9180/apisix/admin")
+	assert.Nil(t, c.syncCluster(ctx, acc))
+	assert.Equal(t, "edge", <-fake.registered)
+	assert.Equal(t, []string{"edge"}, fake.updated)
+	assert.Equal(t, "http://edge-new:9180/apisix/admin", c.clusters["edge"].admin.BaseURL)
+	assert.True(t, c.clusters["edge"].synced)
+
+	c.removeCluster("edge")
+	assert.Equal(t, []string{"edge"}, fake.deleted)
+	assert.False(t, fake.HasCluster("edge"))
+	assert.Len(t, c.clusters, 0)
+}
+
+func TestSyncClusterRemovedWhileSyncing(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fake := &fakeAPISIX{
+		clusters:   make(map[string]*fakeCluster),
+		registered: make(chan string, 1),
+	}
+	c := newFakeClusterConfigController(fake, func(name string) {
+		t.Errorf("cluster %s removed while syncing shouldn't be reported as synced", name)
+	})
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- c.syncCluster(ctx, newClusterConfig("edge", "http://edge:9180/apisix/admin"))
+	}()
+	cluster := fake.Cluster(<-fake.registered).(*fakeCluster)
+
+	// The lock isn't held while waiting for the cache sync.
+	removed := make(chan struct{})
+	go func() {
+		c.removeCluster("edge")
+		close(removed)
+	}()
+	select {
+	case <-removed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("removing cluster is blocked by the cache sync of it")
+	}
+
+	close(cluster.synced)
+	assert.Nil(t, <-errCh)
+	assert.Len(t, c.clusters, 0)
+}
@@ -144,7 +144,9 @@ func (c *apisixConsumerController) sync(ctx context.Context, ev *types.Event) er
 	case config.ApisixV2:
 		ac := multiVersioned.V2()
 
+		start := time.Now()
 		consumer, err := c.translator.TranslateApisixConsumerV2(ac)
+		c.MetricsCollector.RecordTranslation(time.Since(start), "consumer", err)
 		if err != nil {
 			log.Errorw("failed to translate ApisixConsumer",
 				zap.Error(err),
@@ -158,13 +160,30 @@ func (c *apisixConsumerController) sync(ctx context.Context, ev *types.Event) er
 			zap.Any("ApisixConsumer", ac),
 		)
 
-		if err := c.SyncConsumer(ctx, consumer, ev.Type); err != nil {
-			log.Errorw("failed to sync Consumer to APISIX",
-				zap.Error(err),
-				zap.Any("consumer", consumer),
-			)
-			errRecord = err
-			goto updateStatus
+		clusters := c.BoundClusters(ac)
+		for _, cluster := range clusters {
+			if err := c.SyncConsumer(ctx, cluster, consumer, ev.Type); err != nil {
+				log.Errorw("failed to sync Consumer to APISIX",
+					zap.Error(err),
+					zap.Any("consumer", consumer),
+					zap.String("cluster", cluster),
+				)
+				errRecord = err
+				goto updateStatus
+			}
+		}
+		if ev.Type == types.EventUpdate && event.OldObject != nil {
+			for _, cluster := range utils.Difference(c.BoundClusters(event.OldObject), clusters) {
+				if err := c.SyncConsumer(ctx, cluster, consumer, types.EventDelete); err != nil {
+					log.Errorw("failed to delete Consumer from unbound APISIX cluster",
+						zap.Error(err),
+						zap.Any("consumer", consumer),
+						zap.String("cluster", cluster),
+					)
+					errRecord = err
+					goto updateStatus
+				}
+			}
 		}
 	}
 updateStatus:
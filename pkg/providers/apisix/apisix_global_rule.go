@@ -130,7 +130,9 @@ func (c *apisixGlobalRuleController) sync(ctx context.Context, ev *types.Event)
 		agr = ev.Tombstone.(kube.ApisixGlobalRule)
 	}
 
+	start := time.Now()
 	tctx, err := c.translator.TranslateGlobalRule(agr)
+	c.MetricsCollector.RecordTranslation(time.Since(start), "GlobalRule", err)
 	if err != nil {
 		log.Errorw("failed to translate ApisixGlobalRule v2",
 			zap.Error(err),
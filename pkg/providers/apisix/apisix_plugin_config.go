@@ -146,7 +146,9 @@ func (c *apisixPluginConfigController) sync(ctx context.Context, ev *types.Event
 		switch obj.GroupVersion {
 		case config.ApisixV2:
 			if ev.Type != types.EventDelete {
+				start := time.Now()
 				tctx, err = c.translator.TranslatePluginConfigV2(apc.V2())
+				c.MetricsCollector.RecordTranslation(time.Since(start), "PluginConfig", err)
 			} else {
 				tctx, err = c.translator.GeneratePluginConfigV2DeleteMark(apc.V2())
 			}
@@ -171,16 +173,10 @@ func (c *apisixPluginConfigController) sync(ctx context.Context, ev *types.Event
 		}
 
 		var (
-			added   *utils.Manifest
-			updated *utils.Manifest
-			deleted *utils.Manifest
+			om          *utils.Manifest
+			oldClusters []string
 		)
-
-		if ev.Type == types.EventDelete {
-			deleted = m
-		} else if ev.Type.IsAddEvent() {
-			added = m
-		} else {
+		if ev.Type == types.EventUpdate {
 			var oldCtx *translation.TranslateContext
 			switch obj.GroupVersion {
 			case config.ApisixV2:
@@ -197,13 +193,13 @@ func (c *apisixPluginConfigController) sync(ctx context.Context, ev *types.Event
 				goto updatestatus
 			}
 
-			om := &utils.Manifest{
+			om = &utils.Manifest{
 				PluginConfigs: oldCtx.PluginConfigs,
 			}
-			added, updated, deleted = m.Diff(om)
+			oldClusters = c.BoundClusters(obj.OldObject)
 		}
 
-		if err := c.SyncManifests(ctx, added, updated, deleted, ev.Type.IsSyncEvent()); err != nil {
+		if err := c.SyncBoundManifests(ctx, ev.Type, c.BoundClusters(apc), oldClusters, m, om); err != nil {
 			log.Errorw("failed to sync ApisixPluginConfig to apisix",
 				zap.Error(err),
 			)
@@ -318,7 +318,9 @@ func (c *apisixRouteController) sync(ctx context.Context, ev *types.Event) error
 		case config.ApisixV2:
 			if ev.Type != types.EventDelete {
 				if err = c.checkPluginNameIfNotEmptyV2(ctx, ar.V2()); err == nil {
+					start := time.Now()
 					tctx, err = c.translator.TranslateRouteV2(ar.V2())
+					c.MetricsCollector.RecordTranslation(time.Since(start), "route", err)
 				}
 			} else {
 				tctx, err = c.translator.GenerateRouteV2DeleteMark(ar.V2())
@@ -337,6 +339,7 @@ func (c *apisixRouteController) sync(ctx context.Context, ev *types.Event) error
 			zap.Any("upstreams", tctx.Upstreams),
 			zap.Any("apisix_route", ar),
 			zap.Any("pluginConfigs", tctx.PluginConfigs),
+			zap.Any("services", tctx.Services),
 		)
 	}
 	// sync phase: Use context update data palne
@@ -346,37 +349,32 @@ func (c *apisixRouteController) sync(ctx context.Context, ev *types.Event) error
 			Upstreams:     tctx.Upstreams,
 			StreamRoutes:  tctx.StreamRoutes,
 			PluginConfigs: tctx.PluginConfigs,
+			Services:      tctx.Services,
 		}
 		var (
-			added   *utils.Manifest
-			updated *utils.Manifest
-			deleted *utils.Manifest
+			om          *utils.Manifest
+			oldClusters []string
 		)
-
-		if ev.Type == types.EventDelete {
-			deleted = m
-		} else if ev.Type.IsAddEvent() {
-			added = m
-		} else {
+		if ev.Type == types.EventUpdate {
 			oldCtx, _ := c.translator.TranslateOldRoute(obj.OldObject)
 			if oldCtx != nil {
-				om := &utils.Manifest{
+				om = &utils.Manifest{
 					Routes:        oldCtx.Routes,
 					Upstreams:     oldCtx.Upstreams,
 					StreamRoutes:  oldCtx.StreamRoutes,
 					PluginConfigs: oldCtx.PluginConfigs,
+					Services:      oldCtx.Services,
 				}
-				added, updated, deleted = m.Diff(om)
 			}
+			oldClusters = c.BoundClusters(obj.OldObject)
 		}
 
 		log.Debugw("sync ApisixRoute to cluster",
 			zap.String("event_type", ev.Type.String()),
-			zap.Any("add", added),
-			zap.Any("update", updated),
-			zap.Any("delete", deleted),
+			zap.Any("manifest", m),
+			zap.Any("old_manifest", om),
 		)
-		if err = c.SyncManifests(ctx, added, updated, deleted, ev.Type.IsSyncEvent()); err != nil {
+		if err = c.SyncBoundManifests(ctx, ev.Type, c.BoundClusters(ar), oldClusters, m, om); err != nil {
 			log.Errorw("failed to sync ApisixRoute to apisix",
 				zap.Error(err),
 			)
@@ -395,26 +393,31 @@ updateStatus:
 }
 
 func (c *apisixRouteController) checkPluginNameIfNotEmptyV2(ctx context.Context, in *v2.ApisixRoute) error {
+	clusters := c.BoundClusters(in)
 	for _, v := range in.Spec.HTTP {
 		if v.PluginConfigName != "" {
 			ns := in.Namespace
 			if v.PluginConfigNamespace != "" {
 				ns = v.PluginConfigNamespace
 			}
-			_, err := c.APISIX.Cluster(c.Config.APISIX.DefaultClusterName).PluginConfig().Get(ctx, apisixv1.ComposePluginConfigName(ns, v.PluginConfigName))
-			if err != nil {
-				if err == apisixcache.ErrNotFound {
-					log.Errorw("checkPluginNameIfNotEmptyV2 error: plugin_config not found",
-						zap.String("name", apisixv1.ComposePluginConfigName(ns, v.PluginConfigName)),
-						zap.Any("obj", in),
-						zap.Error(err))
-				} else {
-					log.Errorw("checkPluginNameIfNotEmptyV2 PluginConfig get failed",
-						zap.String("name", apisixv1.ComposePluginConfigName(ns, v.PluginConfigName)),
-						zap.Any("obj", in),
-						zap.Error(err))
+			for _, cluster := range clusters {
+				_, err := c.APISIX.Cluster(cluster).PluginConfig().Get(ctx, apisixv1.ComposePluginConfigName(ns, v.PluginConfigName))
+				if err != nil {
+					if err == apisixcache.ErrNotFound {
+						log.Errorw("checkPluginNameIfNotEmptyV2 error: plugin_config not found",
+							zap.String("name", apisixv1.ComposePluginConfigName(ns, v.PluginConfigName)),
+							zap.String("cluster", cluster),
+							zap.Any("obj", in),
+							zap.Error(err))
+					} else {
+						log.Errorw("checkPluginNameIfNotEmptyV2 PluginConfig get failed",
+							zap.String("name", apisixv1.ComposePluginConfigName(ns, v.PluginConfigName)),
+							zap.String("cluster", cluster),
+							zap.Any("obj", in),
+							zap.Error(err))
+					}
+					return err
 				}
-				return err
 			}
 		}
 	}
@@ -16,6 +16,7 @@ package apisix
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -33,11 +34,14 @@ import (
 	"k8s.io/client-go/util/workqueue"
 
 	"github.com/apache/apisix-ingress-controller/pkg/config"
+	"github.com/apache/apisix-ingress-controller/pkg/id"
 	"github.com/apache/apisix-ingress-controller/pkg/kube"
 	configv2 "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/apis/config/v2"
 	"github.com/apache/apisix-ingress-controller/pkg/log"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/translation"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/utils"
 	"github.com/apache/apisix-ingress-controller/pkg/types"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
 )
 
 type apisixTlsController struct {
@@ -161,7 +165,20 @@ func (c *apisixTlsController) sync(ctx context.Context, ev *types.Event) error {
 			}
 		}
 
+		start := time.Now()
 		ssl, err := c.translator.TranslateSSLV2(tls)
+		c.MetricsCollector.RecordTranslation(time.Since(start), "TLS", err)
+		var certErr *translation.CertificateError
+		if err != nil && ev.Type == types.EventDelete && errors.As(err, &certErr) {
+			// The certificate doesn't matter when deleting the SSL object.
+			ssl = &apisixv1.Ssl{
+				ID: id.GenID(tls.Namespace + "_" + tls.Name),
+			}
+			for _, host := range tls.Spec.Hosts {
+				ssl.Snis = append(ssl.Snis, string(host))
+			}
+			err = nil
+		}
 		if err != nil {
 			log.Errorw("failed to translate ApisixTls",
 				zap.Error(err),
@@ -174,14 +191,41 @@ func (c *apisixTlsController) sync(ctx context.Context, ev *types.Event) error {
 			zap.Any("ssl", ssl),
 			zap.Any("ApisixTls", tls),
 		)
+		c.MonitorCertificate(tls, secretKey, ssl, ev.Type)
+		if ev.Type == types.EventUpdate && event.OldObject != nil {
+			old := event.OldObject.V2()
+			oldSecretKey := old.Spec.Secret.Namespace + "/" + old.Spec.Secret.Name
+			oldHosts := make([]string, 0, len(old.Spec.Hosts))
+			for _, host := range old.Spec.Hosts {
+				oldHosts = append(oldHosts, string(host))
+			}
+			c.DeleteStaleCertificateExpiry(map[string][]string{oldSecretKey: oldHosts}, map[string][]string{secretKey: ssl.Snis})
+		}
 
-		if err := c.SyncSSL(ctx, ssl, ev.Type); err != nil {
-			log.Errorw("failed to sync SSL to APISIX",
-				zap.Error(err),
-				zap.Any("ssl", ssl),
-			)
-			errRecord = err
-			goto updateStatus
+		clusters := c.BoundClusters(tls)
+		for _, cluster := range clusters {
+			if err := c.SyncSSL(ctx, cluster, ssl, ev.Type); err != nil {
+				log.Errorw("failed to sync SSL to APISIX",
+					zap.Error(err),
+					zap.Any("ssl", ssl),
+					zap.String("cluster", cluster),
+				)
+				errRecord = err
+				goto updateStatus
+			}
+		}
+		if ev.Type == types.EventUpdate && event.OldObject != nil {
+			for _, cluster := range utils.Difference(c.BoundClusters(event.OldObject), clusters) {
+				if err := c.SyncSSL(ctx, cluster, ssl, types.EventDelete); err != nil {
+					log.Errorw("failed to delete SSL from unbound APISIX cluster",
+						zap.Error(err),
+						zap.Any("ssl", ssl),
+						zap.String("cluster", cluster),
+					)
+					errRecord = err
+					goto updateStatus
+				}
+			}
 		}
 	}
 updateStatus:
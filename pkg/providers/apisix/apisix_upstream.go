@@ -17,8 +17,11 @@ package apisix
 import (
 	"context"
 	"fmt"
+	"net"
 	"reflect"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -28,12 +31,14 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 
 	"github.com/apache/apisix-ingress-controller/pkg/apisix"
 	"github.com/apache/apisix-ingress-controller/pkg/config"
+	"github.com/apache/apisix-ingress-controller/pkg/id"
 	"github.com/apache/apisix-ingress-controller/pkg/kube"
 	configv2 "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/apis/config/v2"
 	"github.com/apache/apisix-ingress-controller/pkg/log"
@@ -57,6 +62,11 @@ type apisixUpstreamController struct {
 	// ApisixRouteController don't know how service change affect ApisixUpstream
 	// So we need to notify it here
 	notifyApisixUpstreamChange func(string)
+
+	// upstream name -> exported unhealthy nodes metric
+	unhealthyUpstreams map[string]struct{}
+	// ApisixUpstream key -> unhealthy nodes reported last time
+	unhealthyNodes map[string]string
 }
 
 func newApisixUpstreamController(common *apisixCommon, notifyApisixUpstreamChange func(string)) *apisixUpstreamController {
@@ -69,6 +79,8 @@ func newApisixUpstreamController(common *apisixCommon, notifyApisixUpstreamChang
 
 		externalServiceMap:         make(map[string]map[string]struct{}),
 		notifyApisixUpstreamChange: notifyApisixUpstreamChange,
+		unhealthyUpstreams:         make(map[string]struct{}),
+		unhealthyNodes:             make(map[string]string),
 	}
 
 	c.ApisixUpstreamInformer.AddEventHandler(
@@ -99,6 +111,9 @@ func (c *apisixUpstreamController) run(ctx context.Context) {
 		go c.runWorker(ctx)
 		go c.runSvcWorker(ctx)
 	}
+	if c.HealthStatusSyncInterval.Duration > 0 {
+		go c.runHealthStatusSync(ctx)
+	}
 
 	<-ctx.Done()
 }
@@ -197,7 +212,9 @@ func (c *apisixUpstreamController) sync(ctx context.Context, ev *types.Event) er
 			var newUps *apisixv1.Upstream
 			if ev.Type != types.EventDelete {
 				cfg := &au.Spec.ApisixUpstreamConfig
+				start := time.Now()
 				newUps, err = c.translator.TranslateUpstreamConfigV2(cfg)
+				c.MetricsCollector.RecordTranslation(time.Since(start), "upstream", err)
 				if err != nil {
 					log.Errorw("failed to translate upstream config",
 						zap.Any("object", au),
@@ -221,7 +238,7 @@ func (c *apisixUpstreamController) sync(ctx context.Context, ev *types.Event) er
 			}
 			// updateUpstream for real
 			upsName := apisixv1.ComposeExternalUpstreamName(au.Namespace, au.Name)
-			errRecord = c.updateUpstream(ctx, upsName, &au.Spec.ApisixUpstreamConfig, ev.Type.IsSyncEvent())
+			errRecord = c.updateUpstream(ctx, c.BoundClusters(au), upsName, &au.Spec.ApisixUpstreamConfig, nil, ev.Type.IsSyncEvent())
 			if err == apisix.ErrNotFound {
 				errRecord = fmt.Errorf("%s", "upstream doesn't exist. It will be created after ApisixRoute is created referencing it.")
 			}
@@ -243,11 +260,24 @@ func (c *apisixUpstreamController) sync(ctx context.Context, ev *types.Event) er
 			goto updateStatus
 		}
 
+		// The nodes are translated again since they depend on the subsets
+		// and the topology aware routing settings.
+		ep, err := c.EpLister.GetEndpoint(namespace, name)
+		if err != nil {
+			log.Warnw("failed to get endpoints, keep the upstream nodes as is",
+				zap.Error(err),
+				zap.String("namespace", namespace),
+				zap.String("name", name),
+			)
+			ep = nil
+		}
+
 		var subsets []configv2.ApisixUpstreamSubset
 		subsets = append(subsets, configv2.ApisixUpstreamSubset{})
 		if len(au.Spec.Subsets) > 0 {
 			subsets = append(subsets, au.Spec.Subsets...)
 		}
+		clusters := c.BoundClusters(au)
 		for _, port := range svc.Spec.Ports {
 			for _, subset := range subsets {
 				var cfg configv2.ApisixUpstreamConfig
@@ -258,7 +288,20 @@ func (c *apisixUpstreamController) sync(ctx context.Context, ev *types.Event) er
 						cfg = au.Spec.ApisixUpstreamConfig
 					}
 				}
-				err := c.updateUpstream(ctx, apisixv1.ComposeUpstreamName(namespace, name, subset.Name, port.Port, types.ResolveGranularity.Endpoint), &cfg, ev.Type.IsSyncEvent())
+				var nodes apisixv1.UpstreamNodes
+				if ep != nil {
+					nodes, err = c.translator.TranslateEndpoint(ep, port.Port, subset.Labels)
+					if err != nil {
+						log.Warnw("failed to translate upstream nodes, keep them as is",
+							zap.Error(err),
+							zap.String("namespace", namespace),
+							zap.String("name", name),
+							zap.Int32("port", port.Port),
+						)
+						nodes = nil
+					}
+				}
+				err := c.updateUpstream(ctx, clusters, apisixv1.ComposeUpstreamName(namespace, name, subset.Name, port.Port, types.ResolveGranularity.Endpoint), &cfg, nodes, ev.Type.IsSyncEvent())
 				if err != nil {
 					if err == apisix.ErrNotFound {
 						errRecord = fmt.Errorf("%s", "upstream doesn't exist. It will be created after ApisixRoute is created referencing it.")
@@ -267,7 +310,7 @@ func (c *apisixUpstreamController) sync(ctx context.Context, ev *types.Event) er
 					}
 					goto updateStatus
 				}
-				err = c.updateUpstream(ctx, apisixv1.ComposeUpstreamName(namespace, name, subset.Name, port.Port, types.ResolveGranularity.Service), &cfg, ev.Type.IsSyncEvent())
+				err = c.updateUpstream(ctx, clusters, apisixv1.ComposeUpstreamName(namespace, name, subset.Name, port.Port, types.ResolveGranularity.Service), &cfg, nil, ev.Type.IsSyncEvent())
 				if err != nil {
 					if err == apisix.ErrNotFound {
 						errRecord = fmt.Errorf("%s", "upstream doesn't exist. It will be created after ApisixRoute is created referencing it.")
@@ -335,17 +378,16 @@ func (c *apisixUpstreamController) updateStatus(obj kube.ApisixUpstream, statusE
 	}
 }
 
-func (c *apisixUpstreamController) updateUpstream(ctx context.Context, upsName string, cfg *configv2.ApisixUpstreamConfig, shouldCompare bool) error {
-	// TODO: multi cluster
-	clusterName := c.Config.APISIX.DefaultClusterName
-
-	ups, err := c.APISIX.Cluster(clusterName).Upstream().Get(ctx, upsName)
-	if err != nil {
-		return apisix.ErrNotFound
-	}
+// updateUpstream updates the upstream in every given cluster that has it,
+// apisix.ErrNotFound is returned if none of them has the upstream. The nodes
+// of the upstream are kept if the given nodes are nil.
+func (c *apisixUpstreamController) updateUpstream(ctx context.Context, clusters []string, upsName string, cfg *configv2.ApisixUpstreamConfig, nodes apisixv1.UpstreamNodes, shouldCompare bool) error {
 	var newUps *apisixv1.Upstream
 	if cfg != nil {
+		var err error
+		start := time.Now()
 		newUps, err = c.translator.TranslateUpstreamConfigV2(cfg)
+		c.MetricsCollector.RecordTranslation(time.Since(start), "upstream", err)
 		if err != nil {
 			log.Errorw("ApisixUpstream conversion cannot be completed, or the format is incorrect",
 				zap.String("ApisixUpstream name", upsName),
@@ -357,47 +399,68 @@ func (c *apisixUpstreamController) updateUpstream(ctx context.Context, upsName s
 		newUps = apisixv1.NewDefaultUpstream()
 	}
 
-	newUps.Metadata = ups.Metadata
-	newUps.Nodes = ups.Nodes
-	log.Debugw("updating upstream since ApisixUpstream changed",
-		zap.Any("upstream", newUps),
-		zap.String("ApisixUpstream name", upsName),
-	)
-	if _, err := c.APISIX.Cluster(clusterName).Upstream().Update(ctx, newUps, shouldCompare); err != nil {
-		log.Errorw("failed to update upstream",
-			zap.Error(err),
-			zap.Any("upstream", newUps),
+	found := false
+	for _, clusterName := range clusters {
+		ups, err := c.APISIX.Cluster(clusterName).Upstream().Get(ctx, upsName)
+		if err != nil {
+			continue
+		}
+		found = true
+
+		clusterUps := newUps.DeepCopy()
+		clusterUps.Metadata = ups.Metadata
+		clusterUps.Nodes = ups.Nodes
+		// The upstream of a GRPCRoute proxies gRPC, don't reset it to the
+		// default scheme when the ApisixUpstream doesn't set one.
+		if cfg != nil && cfg.Scheme == "" && (ups.Scheme == apisixv1.SchemeGRPC || ups.Scheme == apisixv1.SchemeGRPCS) {
+			clusterUps.Scheme = ups.Scheme
+		}
+		if nodes != nil {
+			clusterUps.Nodes = nodes
+		}
+		log.Debugw("updating upstream since ApisixUpstream changed",
+			zap.Any("upstream", clusterUps),
 			zap.String("ApisixUpstream name", upsName),
 			zap.String("cluster", clusterName),
 		)
-		return err
+		if _, err := c.APISIX.Cluster(clusterName).Upstream().Update(ctx, clusterUps, shouldCompare); err != nil {
+			log.Errorw("failed to update upstream",
+				zap.Error(err),
+				zap.Any("upstream", clusterUps),
+				zap.String("ApisixUpstream name", upsName),
+				zap.String("cluster", clusterName),
+			)
+			return err
+		}
+	}
+	if !found {
+		return apisix.ErrNotFound
 	}
 	return nil
 }
 
 func (c *apisixUpstreamController) updateExternalNodes(ctx context.Context, au *configv2.ApisixUpstream, old *configv2.ApisixUpstream, newUps *apisixv1.Upstream, ns, name string, shouldCompare bool) error {
-	clusterName := c.Config.APISIX.DefaultClusterName
-
 	// TODO: if old is not nil, diff the external nodes change first
 
 	upsName := apisixv1.ComposeExternalUpstreamName(ns, name)
-	ups, err := c.APISIX.Cluster(clusterName).Upstream().Get(ctx, upsName)
-	if err != nil {
-		if err == apisix.ErrNotFound {
-			log.Debugw("upstream is not referenced",
-				zap.String("cluster", clusterName),
-				zap.String("upstream", upsName),
-			)
-			err = fmt.Errorf("%s", "upstream doesn't exist. It will be created after ApisixRoute is created referencing it.")
-			c.RecordEvent(au, corev1.EventTypeWarning, utils.ResourceSyncAborted, err)
-			c.recordStatus(au, utils.ResourceSyncAborted, err, metav1.ConditionFalse, au.GetGeneration())
-		} else {
+	found := false
+	for _, clusterName := range c.BoundClusters(au) {
+		ups, err := c.APISIX.Cluster(clusterName).Upstream().Get(ctx, upsName)
+		if err != nil {
+			if err == apisix.ErrNotFound {
+				log.Debugw("upstream is not referenced",
+					zap.String("cluster", clusterName),
+					zap.String("upstream", upsName),
+				)
+				continue
+			}
 			c.RecordEvent(au, corev1.EventTypeWarning, utils.ResourceSyncAborted, err)
 			c.recordStatus(au, utils.ResourceSyncAborted, err, metav1.ConditionFalse, au.GetGeneration())
 			log.Errorf("failed to get upstream %s: %s", upsName, err)
+			return err
 		}
-		return err
-	} else if ups != nil {
+		found = true
+
 		nodes, err := c.translator.TranslateApisixUpstreamExternalNodes(au)
 		if err != nil {
 			log.Errorf("failed to translate upstream external nodes %s: %s", upsName, err)
@@ -406,8 +469,9 @@ func (c *apisixUpstreamController) updateExternalNodes(ctx context.Context, au *
 			return err
 		}
 		if newUps != nil {
-			newUps.Metadata = ups.Metadata
-			ups = newUps
+			clusterUps := newUps.DeepCopy()
+			clusterUps.Metadata = ups.Metadata
+			ups = clusterUps
 		}
 
 		ups.Nodes = nodes
@@ -423,6 +487,12 @@ func (c *apisixUpstreamController) updateExternalNodes(ctx context.Context, au *
 			return err
 		}
 	}
+	if !found {
+		err := fmt.Errorf("%s", "upstream doesn't exist. It will be created after ApisixRoute is created referencing it.")
+		c.RecordEvent(au, corev1.EventTypeWarning, utils.ResourceSyncAborted, err)
+		c.recordStatus(au, utils.ResourceSyncAborted, err, metav1.ConditionFalse, au.GetGeneration())
+		return err
+	}
 	return nil
 }
 
@@ -832,20 +902,24 @@ func (c *apisixUpstreamController) handleSvcErr(key string, errOrigin error) {
 
 // recordStatus record resources status
 func (c *apisixUpstreamController) recordStatus(at interface{}, reason string, err error, status metav1.ConditionStatus, generation int64) {
-	if c.Kubernetes.DisableStatusUpdates {
-		return
-	}
 	// build condition
 	message := utils.CommonSuccessMessage
 	if err != nil {
 		message = err.Error()
 	}
-	condition := metav1.Condition{
+	c.recordCondition(at, metav1.Condition{
 		Type:               utils.ConditionType,
 		Reason:             reason,
 		Status:             status,
 		Message:            message,
 		ObservedGeneration: generation,
+	})
+}
+
+// recordCondition sets the condition to the resource status
+func (c *apisixUpstreamController) recordCondition(at interface{}, condition metav1.Condition) {
+	if c.Kubernetes.DisableStatusUpdates {
+		return
 	}
 	apisixClient := c.KubeClient.APISIXClient
 
@@ -889,3 +963,206 @@ func (c *apisixUpstreamController) isEffective(au kube.ApisixUpstream) bool {
 	// Compatible with legacy versions
 	return true
 }
+
+// runHealthStatusSync polls the health status of the upstream nodes from
+// the APISIX clusters with control API periodically.
+func (c *apisixUpstreamController) runHealthStatusSync(ctx context.Context) {
+	t := time.NewTicker(c.HealthStatusSyncInterval.Duration)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		c.syncHealthStatus(ctx)
+	}
+}
+
+// syncHealthStatus exposes the unhealthy nodes of each upstream reported by
+// the health checkers of APISIX as metrics, and reflects them to the
+// NodesHealthy condition of the ApisixUpstream.
+func (c *apisixUpstreamController) syncHealthStatus(ctx context.Context) {
+	// upstream id -> unhealthy nodes (host:port) in any of the clusters
+	unhealthy := make(map[string]map[string]struct{})
+	listed := false
+	for _, cluster := range c.APISIX.ListClusters() {
+		statuses, err := cluster.HealthStatus().List(ctx)
+		if err == apisix.ErrFunctionDisabled {
+			// The control API of the cluster isn't configured.
+			continue
+		}
+		if err != nil {
+			// Skip the round rather than reporting the nodes of the
+			// cluster as healthy.
+			if ctx.Err() == nil {
+				log.Warnw("failed to list upstream health status",
+					zap.String("cluster", cluster.String()),
+					zap.Error(err),
+				)
+			}
+			return
+		}
+		listed = true
+		for _, status := range statuses {
+			nodes, ok := unhealthy[status.ID]
+			if !ok {
+				nodes = make(map[string]struct{})
+				unhealthy[status.ID] = nodes
+			}
+			for _, node := range status.UnhealthyNodes() {
+				nodes[net.JoinHostPort(node.Host, strconv.Itoa(node.Port))] = struct{}{}
+			}
+		}
+	}
+	if !listed {
+		return
+	}
+
+	aus, err := c.ApisixUpstreamLister.ListV2("")
+	if err != nil {
+		log.Errorw("failed to list ApisixUpstream",
+			zap.Error(err),
+		)
+		return
+	}
+	exported := make(map[string]struct{})
+	seen := make(map[string]struct{}, len(aus))
+	for _, au := range aus {
+		key := au.Namespace + "/" + au.Name
+		if au.Spec == nil || !c.namespaceProvider.IsWatchingNamespace(key) || !c.isEffective(kube.MustNewApisixUpstream(au)) {
+			continue
+		}
+
+		found := false
+		nodes := make(map[string]struct{})
+		for _, upsName := range c.upstreamNames(au) {
+			upsNodes, ok := unhealthy[id.GenID(upsName)]
+			if !ok {
+				continue
+			}
+			found = true
+			c.MetricsCollector.SetUpstreamUnhealthyNodes(upsName, len(upsNodes))
+			exported[upsName] = struct{}{}
+			for node := range upsNodes {
+				nodes[node] = struct{}{}
+			}
+		}
+		if !found {
+			continue
+		}
+		seen[key] = struct{}{}
+		c.recordNodesHealth(au, nodes)
+	}
+
+	for upsName := range c.unhealthyUpstreams {
+		if _, ok := exported[upsName]; !ok {
+			c.MetricsCollector.DeleteUpstreamUnhealthyNodes(upsName)
+		}
+	}
+	c.unhealthyUpstreams = exported
+	for key := range c.unhealthyNodes {
+		if _, ok := seen[key]; !ok {
+			delete(c.unhealthyNodes, key)
+		}
+	}
+}
+
+// upstreamNames returns the names of the upstreams in APISIX that the
+// ApisixUpstream applies to.
+func (c *apisixUpstreamController) upstreamNames(au *configv2.ApisixUpstream) []string {
+	if len(au.Spec.ExternalNodes) != 0 || au.Spec.Discovery != nil {
+		return []string{apisixv1.ComposeExternalUpstreamName(au.Namespace, au.Name)}
+	}
+	svc, err := c.SvcLister.Services(au.Namespace).Get(au.Name)
+	if err != nil {
+		return nil
+	}
+	subsets := []string{""}
+	for _, subset := range au.Spec.Subsets {
+		subsets = append(subsets, subset.Name)
+	}
+	var names []string
+	for _, port := range svc.Spec.Ports {
+		for _, subset := range subsets {
+			names = append(names,
+				apisixv1.ComposeUpstreamName(au.Namespace, au.Name, subset, port.Port, types.ResolveGranularity.Endpoint),
+				apisixv1.ComposeUpstreamName(au.Namespace, au.Name, subset, port.Port, types.ResolveGranularity.Service),
+			)
+		}
+	}
+	return names
+}
+
+// recordNodesHealth sets the NodesHealthy condition of the ApisixUpstream
+// once the unhealthy nodes change, and records a warning event naming the
+// pods behind the unhealthy nodes if enabled.
+func (c *apisixUpstreamController) recordNodesHealth(au *configv2.ApisixUpstream, nodes map[string]struct{}) {
+	if !c.Elector.IsLeader() {
+		return
+	}
+	unhealthy := make([]string, 0, len(nodes))
+	for node := range nodes {
+		unhealthy = append(unhealthy, node)
+	}
+	sort.Strings(unhealthy)
+
+	key := au.Namespace + "/" + au.Name
+	msg := strings.Join(unhealthy, ", ")
+	if last, ok := c.unhealthyNodes[key]; ok && last == msg {
+		return
+	}
+	c.unhealthyNodes[key] = msg
+
+	condition := metav1.Condition{
+		Type:               utils.NodesHealthyConditionType,
+		Reason:             utils.UpstreamNodesHealthy,
+		Status:             metav1.ConditionTrue,
+		Message:            "All upstream nodes are healthy",
+		ObservedGeneration: au.GetGeneration(),
+	}
+	if len(unhealthy) > 0 {
+		condition.Reason = utils.UpstreamNodesUnhealthy
+		condition.Status = metav1.ConditionFalse
+		condition.Message = fmt.Sprintf("Unhealthy upstream nodes: %s", msg)
+		log.Warnw("upstream nodes are unhealthy",
+			zap.String("ApisixUpstream", key),
+			zap.Strings("nodes", unhealthy),
+		)
+	}
+	c.recordCondition(au, condition)
+
+	if len(unhealthy) > 0 && c.HealthStatusEvents {
+		c.RecordEventS(au, corev1.EventTypeWarning, utils.UpstreamNodesUnhealthy,
+			fmt.Sprintf("Unhealthy upstream nodes: %s", strings.Join(c.describeNodes(au.Namespace, unhealthy), ", ")))
+	}
+}
+
+// describeNodes appends the name of the pod to each node (ip:port) if the
+// node is a pod in the namespace.
+func (c *apisixUpstreamController) describeNodes(namespace string, nodes []string) []string {
+	pods, err := c.PodLister.Pods(namespace).List(labels.Everything())
+	if err != nil {
+		log.Warnw("failed to list pods",
+			zap.String("namespace", namespace),
+			zap.Error(err),
+		)
+		return nodes
+	}
+	podNames := make(map[string]string, len(pods))
+	for _, pod := range pods {
+		if pod.Status.PodIP != "" {
+			podNames[pod.Status.PodIP] = pod.Name
+		}
+	}
+	described := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		host, _, _ := net.SplitHostPort(node)
+		if name, ok := podNames[host]; ok {
+			node = fmt.Sprintf("%s (pod %s)", node, name)
+		}
+		described = append(described, node)
+	}
+	return described
+}
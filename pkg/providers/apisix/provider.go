@@ -20,9 +20,11 @@ import (
 	"context"
 	"time"
 
+	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/apache/apisix-ingress-controller/pkg/config"
+	"github.com/apache/apisix-ingress-controller/pkg/log"
 	apisixtranslation "github.com/apache/apisix-ingress-controller/pkg/providers/apisix/translation"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/k8s/namespace"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/translation"
@@ -95,7 +97,7 @@ func NewProvider(common *providertypes.Common, namespaceProvider namespace.Watch
 	p.apisixUpstreamController = newApisixUpstreamController(c, p.NotifyApisixUpstreamChange)
 	p.apisixRouteController = newApisixRouteController(c)
 	p.apisixTlsController = newApisixTlsController(c)
-	p.apisixClusterConfigController = newApisixClusterConfigController(c)
+	p.apisixClusterConfigController = newApisixClusterConfigController(c, p.onClusterSynced)
 	p.apisixConsumerController = newApisixConsumerController(c)
 	p.apisixPluginConfigController = newApisixPluginConfigController(c)
 	if p.common.Kubernetes.APIVersion == config.ApisixV2 {
@@ -165,6 +167,33 @@ func (p *apisixProvider) ResourceSync(interval time.Duration, namespace string)
 	e.Wait()
 }
 
+// onClusterSynced re-syncs the resources which may be bound to the
+// newly registered APISIX cluster.
+func (p *apisixProvider) onClusterSynced(name string) {
+	log.Infow("resync resources for apisix cluster",
+		zap.String("cluster_name", name),
+	)
+	e := utils.ParallelExecutor{}
+
+	e.Add(func() {
+		p.apisixPluginConfigController.ResourceSync(0, "")
+	})
+	e.Add(func() {
+		p.apisixRouteController.ResourceSync(0, "")
+	})
+	e.Add(func() {
+		p.apisixUpstreamController.ResourceSync(0, "")
+	})
+	e.Add(func() {
+		p.apisixTlsController.ResourceSync(0, "")
+	})
+	e.Add(func() {
+		p.apisixConsumerController.ResourceSync(0, "")
+	})
+
+	e.Wait()
+}
+
 func (p *apisixProvider) NotifyServiceAdd(key string) {
 	p.apisixRouteController.NotifyServiceAdd(key)
 }
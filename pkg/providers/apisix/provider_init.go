@@ -39,6 +39,7 @@ func (p *apisixProvider) Init(ctx context.Context) error {
 		sslMapK8S          = new(sync.Map)
 		consumerMapK8S     = new(sync.Map)
 		pluginConfigMapK8S = new(sync.Map)
+		serviceMapK8S      = new(sync.Map)
 
 		routeMapA6        = make(map[string]string)
 		streamRouteMapA6  = make(map[string]string)
@@ -46,6 +47,7 @@ func (p *apisixProvider) Init(ctx context.Context) error {
 		sslMapA6          = make(map[string]string)
 		consumerMapA6     = make(map[string]string)
 		pluginConfigMapA6 = make(map[string]string)
+		serviceMapA6      = make(map[string]string)
 	)
 
 	namespaces := p.namespaceProvider.WatchingNamespaces()
@@ -89,6 +91,10 @@ func (p *apisixProvider) Init(ctx context.Context) error {
 							for _, pluginConfig := range tc.PluginConfigs {
 								pluginConfigMapK8S.Store(pluginConfig.ID, pluginConfig.ID)
 							}
+							// services
+							for _, service := range tc.Services {
+								serviceMapK8S.Store(service.ID, service.ID)
+							}
 						}
 					}
 				}
@@ -162,6 +168,9 @@ func (p *apisixProvider) Init(ctx context.Context) error {
 	if err := p.listPluginConfigCache(ctx, pluginConfigMapA6); err != nil {
 		return err
 	}
+	if err := p.listServiceCache(ctx, serviceMapA6); err != nil {
+		return err
+	}
 	// 3.compare
 	routeResult := findRedundant(routeMapA6, routeMapK8S)
 	streamRouteResult := findRedundant(streamRouteMapA6, streamRouteMapK8S)
@@ -169,6 +178,7 @@ func (p *apisixProvider) Init(ctx context.Context) error {
 	sslResult := findRedundant(sslMapA6, sslMapK8S)
 	consumerResult := findRedundant(consumerMapA6, consumerMapK8S)
 	pluginConfigResult := findRedundant(pluginConfigMapA6, pluginConfigMapK8S)
+	serviceResult := findRedundant(serviceMapA6, serviceMapK8S)
 	// 4.warn
 	warnRedundantResources(routeResult, "route")
 	warnRedundantResources(streamRouteResult, "streamRoute")
@@ -176,6 +186,7 @@ func (p *apisixProvider) Init(ctx context.Context) error {
 	warnRedundantResources(sslResult, "ssl")
 	warnRedundantResources(consumerResult, "consumer")
 	warnRedundantResources(pluginConfigResult, "pluginConfig")
+	warnRedundantResources(serviceResult, "service")
 
 	return nil
 }
@@ -270,3 +281,15 @@ func (p *apisixProvider) listPluginConfigCache(ctx context.Context, pluginConfig
 	}
 	return nil
 }
+
+func (p *apisixProvider) listServiceCache(ctx context.Context, serviceMapA6 map[string]string) error {
+	servicesInA6, err := p.common.APISIX.Cluster(p.common.Config.APISIX.DefaultClusterName).Service().List(ctx)
+	if err != nil {
+		return err
+	} else {
+		for _, s := range servicesInA6 {
+			serviceMapA6[s.ID] = s.ID
+		}
+	}
+	return nil
+}
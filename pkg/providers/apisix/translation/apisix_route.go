@@ -16,6 +16,7 @@ package translation
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
@@ -330,6 +331,56 @@ func (t *translator) translateHTTPRouteV2(ctx *translation.TranslateContext, ar
 			ctx.AddUpstream(up)
 		}
 	}
+	return t.translateSharedServices(ctx, ar)
+}
+
+// translateSharedServices moves the upstream and the plugins shared by several
+// rules of the ApisixRoute to an APISIX service referenced by their routes, so
+// that they are not duplicated in each route. The service is named after the
+// first rule referencing it.
+func (t *translator) translateSharedServices(ctx *translation.TranslateContext, ar *configv2.ApisixRoute) error {
+	// Each rule is translated to exactly one route in order.
+	if len(ctx.Routes) != len(ar.Spec.HTTP) {
+		return nil
+	}
+	var keys []string
+	groups := make(map[string][]int)
+	for i, route := range ctx.Routes {
+		if route.UpstreamId == "" {
+			continue
+		}
+		plugins, err := json.Marshal(route.Plugins)
+		if err != nil {
+			return err
+		}
+		key := route.UpstreamId + "/" + string(plugins)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+	for _, key := range keys {
+		indexes := groups[key]
+		if len(indexes) < 2 {
+			continue
+		}
+		first := ctx.Routes[indexes[0]]
+		svc := apisixv1.NewDefaultService()
+		svc.Name = apisixv1.ComposeServiceName(ar.Namespace, ar.Name, ar.Spec.HTTP[indexes[0]].Name)
+		svc.ID = id.GenID(svc.Name)
+		svc.UpstreamId = first.UpstreamId
+		svc.Plugins = first.Plugins
+		for k, v := range ar.ObjectMeta.Labels {
+			svc.Metadata.Labels[k] = v
+		}
+		for _, i := range indexes {
+			route := ctx.Routes[i]
+			route.ServiceId = svc.ID
+			route.UpstreamId = ""
+			route.Plugins = nil
+		}
+		ctx.AddService(svc)
+	}
 	return nil
 }
 
@@ -468,6 +519,11 @@ func (t *translator) generateHTTPRouteV2DeleteMark(ctx *translation.TranslateCon
 		route := apisixv1.NewDefaultRoute()
 		route.Name = apisixv1.ComposeRouteName(ar.Namespace, ar.Name, part.Name)
 		route.ID = id.GenID(route.Name)
+		// Any rule might be the first one referencing a shared service.
+		svc := apisixv1.NewDefaultService()
+		svc.Name = apisixv1.ComposeServiceName(ar.Namespace, ar.Name, part.Name)
+		svc.ID = id.GenID(svc.Name)
+		ctx.AddService(svc)
 		if part.PluginConfigName != "" {
 			ns := ar.Namespace
 			if part.PluginConfigNamespace != "" {
@@ -687,10 +743,13 @@ func (t *translator) TranslateOldRoute(ar kube.ApisixRoute) (*translation.Transl
 
 func (t *translator) translateOldRouteV2(ar *configv2.ApisixRoute) (*translation.TranslateContext, error) {
 	oldCtx := translation.DefaultEmptyTranslateContext()
+	// All bound clusters share the same objects, so looking up
+	// the first one is enough.
+	clusterName := utils.BoundClusters(ar.Annotations, t.ClusterName)[0]
 
 	for _, part := range ar.Spec.Stream {
 		name := apisixv1.ComposeStreamRouteName(ar.Namespace, ar.Name, part.Name)
-		sr, err := t.Apisix.Cluster(t.ClusterName).StreamRoute().Get(context.Background(), name)
+		sr, err := t.Apisix.Cluster(clusterName).StreamRoute().Get(context.Background(), name)
 		if err != nil || sr == nil {
 			continue
 		}
@@ -701,9 +760,10 @@ func (t *translator) translateOldRouteV2(ar *configv2.ApisixRoute) (*translation
 		}
 		oldCtx.AddStreamRoute(sr)
 	}
+	services := make(map[string]struct{})
 	for _, part := range ar.Spec.HTTP {
 		name := apisixv1.ComposeRouteName(ar.Namespace, ar.Name, part.Name)
-		r, err := t.Apisix.Cluster(t.ClusterName).Route().Get(context.Background(), name)
+		r, err := t.Apisix.Cluster(clusterName).Route().Get(context.Background(), name)
 		if err != nil || r == nil {
 			continue
 		}
@@ -712,6 +772,22 @@ func (t *translator) translateOldRouteV2(ar *configv2.ApisixRoute) (*translation
 			ups.ID = r.UpstreamId
 			oldCtx.AddUpstream(ups)
 		}
+		if _, ok := services[r.ServiceId]; r.ServiceId != "" && !ok {
+			services[r.ServiceId] = struct{}{}
+			// The service is named after the first rule referencing it.
+			svcName := apisixv1.ComposeServiceName(ar.Namespace, ar.Name, part.Name)
+			svc, err := t.Apisix.Cluster(clusterName).Service().Get(context.Background(), svcName)
+			if err != nil || svc == nil || svc.ID != r.ServiceId {
+				svc = apisixv1.NewDefaultService()
+				svc.ID = r.ServiceId
+			}
+			if svc.UpstreamId != "" {
+				ups := apisixv1.NewDefaultUpstream()
+				ups.ID = svc.UpstreamId
+				oldCtx.AddUpstream(ups)
+			}
+			oldCtx.AddService(svc)
+		}
 		oldCtx.AddRoute(r)
 	}
 	return oldCtx, nil
@@ -315,6 +315,79 @@ func TestTranslateApisixRouteV2WithEmptyPluginConfigName(t *testing.T) {
 	assert.Equal(t, "", res.Routes[2].PluginConfigId)
 }
 
+func TestTranslateApisixRouteV2WithSharedService(t *testing.T) {
+	tr, processCh := mockTranslatorV2(t)
+	<-processCh
+	<-processCh
+
+	backends := []configv2.ApisixRouteHTTPBackend{
+		{
+			ServiceName: "svc",
+			ServicePort: intstr.IntOrString{
+				IntVal: 80,
+			},
+		},
+	}
+	plugins := []configv2.ApisixRoutePlugin{
+		{
+			Name:   "cors",
+			Enable: true,
+		},
+	}
+	ar := &configv2.ApisixRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ar",
+			Namespace: "test",
+		},
+		Spec: configv2.ApisixRouteSpec{
+			HTTP: []configv2.ApisixRouteHTTP{
+				{
+					Name: "rule1",
+					Match: configv2.ApisixRouteHTTPMatch{
+						Paths: []string{"/foo"},
+					},
+					Backends: backends,
+					Plugins:  plugins,
+				},
+				{
+					Name: "rule2",
+					Match: configv2.ApisixRouteHTTPMatch{
+						Paths: []string{"/bar"},
+					},
+					Backends: backends,
+					Plugins:  plugins,
+				},
+				{
+					Name: "rule3",
+					Match: configv2.ApisixRouteHTTPMatch{
+						Paths: []string{"/baz"},
+					},
+					Backends: backends,
+				},
+			},
+		},
+	}
+	res, err := tr.TranslateRouteV2(ar)
+	assert.NoError(t, err)
+	assert.Len(t, res.Routes, 3)
+	assert.Len(t, res.Upstreams, 1)
+	assert.Len(t, res.Services, 1)
+
+	svc := res.Services[0]
+	assert.Equal(t, "test_ar_rule1_service", svc.Name)
+	assert.Equal(t, id.GenID(svc.Name), svc.ID)
+	assert.Equal(t, res.Upstreams[0].ID, svc.UpstreamId)
+	assert.Contains(t, svc.Plugins, "cors")
+
+	for _, r := range res.Routes[:2] {
+		assert.Equal(t, svc.ID, r.ServiceId)
+		assert.Equal(t, "", r.UpstreamId)
+		assert.Len(t, r.Plugins, 0)
+	}
+	assert.Equal(t, "", res.Routes[2].ServiceId)
+	assert.Equal(t, res.Upstreams[0].ID, res.Routes[2].UpstreamId)
+}
+
 func TestTranslateApisixRouteV2WithPluginConfigNamespace(t *testing.T) {
 	tr, processCh := mockTranslatorV2(t)
 	<-processCh
@@ -431,6 +504,10 @@ func TestGenerateApisixRouteV2DeleteMark(t *testing.T) {
 
 	assert.Equal(t, id.GenID("test_svc1_81"), tx.Upstreams[0].ID, "upstream1 id error")
 	assert.Equal(t, id.GenID("test_svc2_82"), tx.Upstreams[1].ID, "upstream2 id error")
+
+	assert.Equal(t, 2, len(tx.Services), "There should be 2 services")
+	assert.Equal(t, id.GenID("test_ar_rule1_service"), tx.Services[0].ID, "service1 id error")
+	assert.Equal(t, id.GenID("test_ar_rule2_service"), tx.Services[1].ID, "service2 id error")
 }
 
 func ptrOf[T interface{}](v T) *T {
@@ -30,6 +30,12 @@ func (t *translator) TranslateSSLV2(tls *configv2.ApisixTls) (*apisixv1.Ssl, err
 	if err != nil {
 		return nil, err
 	}
+	// Reject the certificate which APISIX can't serve, rather than finding
+	// it out when clients fail.
+	secretKey := tls.Spec.Secret.Namespace + "/" + tls.Spec.Secret.Name
+	if _, err := translation.ValidateKeyPair(secretKey, cert, key); err != nil {
+		return nil, err
+	}
 
 	var snis []string
 	for _, host := range tls.Spec.Hosts {
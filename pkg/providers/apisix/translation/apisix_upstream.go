@@ -120,6 +120,10 @@ func (t *translator) TranslateApisixUpstreamExternalNodes(au *v2.ApisixUpstream)
 	return nodes, nil
 }
 
+func (t *translator) TranslateExternalApisixUpstream(namespace, name string) (*apisixv1.Upstream, error) {
+	return t.translateExternalApisixUpstream(namespace, name)
+}
+
 // TODO: Retry when ApisixUpstream/ExternalName service not found
 func (t *translator) translateExternalApisixUpstream(namespace, upstream string) (*apisixv1.Upstream, error) {
 	multiVersioned, err := t.ApisixUpstreamLister.V2(namespace, upstream)
@@ -72,6 +72,9 @@ type ApisixTranslator interface {
 
 	// TranslateApisixUpstreamExternalNodes translates an ApisixUpstream with external nodes to APISIX nodes.
 	TranslateApisixUpstreamExternalNodes(au *configv2.ApisixUpstream) ([]apisixv1.UpstreamNode, error)
+	// TranslateExternalApisixUpstream translates an ApisixUpstream with external nodes or
+	// service discovery to an APISIX upstream.
+	TranslateExternalApisixUpstream(namespace, name string) (*apisixv1.Upstream, error)
 
 	TranslateGlobalRule(kube.ApisixGlobalRule) (*translation.TranslateContext, error)
 }
@@ -38,8 +38,10 @@ import (
 	"k8s.io/client-go/tools/record"
 
 	"github.com/apache/apisix-ingress-controller/pkg/api"
+	"github.com/apache/apisix-ingress-controller/pkg/api/validation"
 	"github.com/apache/apisix-ingress-controller/pkg/apisix"
 	"github.com/apache/apisix-ingress-controller/pkg/config"
+	"github.com/apache/apisix-ingress-controller/pkg/id"
 	"github.com/apache/apisix-ingress-controller/pkg/kube"
 	apisixscheme "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/client/clientset/versioned/scheme"
 	v2 "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/client/listers/config/v2"
@@ -105,6 +107,9 @@ func NewController(cfg *config.Config) (*Controller, error) {
 	if podNamespace == "" {
 		podNamespace = "default"
 	}
+	if err := id.SetScheme(cfg.ResourceIDScheme, cfg.ResourceIDMigration); err != nil {
+		return nil, err
+	}
 	client, err := apisix.NewClient(cfg.APISIX.AdminAPIVersion)
 	if err != nil {
 		return nil, err
@@ -283,6 +288,9 @@ func (c *Controller) initSharedInformers() *providertypes.ListerInformer {
 
 		ingressListerV1      networkingv1.IngressLister
 		ingressListerV1beta1 networkingv1beta1.IngressLister
+
+		ingressClassInformer cache.SharedIndexInformer
+		ingressClassLister   networkingv1.IngressClassLister
 	)
 
 	var (
@@ -353,6 +361,8 @@ func (c *Controller) initSharedInformers() *providertypes.ListerInformer {
 	default:
 		ingressInformer = kubeFactory.Networking().V1().Ingresses().Informer()
 		ingressListerV1 = kubeFactory.Networking().V1().Ingresses().Lister()
+		ingressClassInformer = kubeFactory.Networking().V1().IngressClasses().Informer()
+		ingressClassLister = kubeFactory.Networking().V1().IngressClasses().Lister()
 	}
 
 	ingressLister := kube.NewIngressLister(ingressListerV1, ingressListerV1beta1)
@@ -374,6 +384,9 @@ func (c *Controller) initSharedInformers() *providertypes.ListerInformer {
 		IngressInformer:   ingressInformer,
 		IngressLister:     ingressLister,
 
+		IngressClassInformer: ingressClassInformer,
+		IngressClassLister:   ingressClassLister,
+
 		ApisixUpstreamLister:      apisixUpstreamLister,
 		ApisixRouteLister:         apisixRouteLister,
 		ApisixConsumerLister:      apisixConsumerLister,
@@ -409,6 +422,7 @@ func (c *Controller) run(ctx context.Context) error {
 		Name:              c.cfg.APISIX.DefaultClusterName,
 		AdminKey:          c.cfg.APISIX.DefaultClusterAdminKey,
 		BaseURL:           c.cfg.APISIX.DefaultClusterBaseURL,
+		ControlAPIBaseURL: c.cfg.APISIX.DefaultClusterControlAPIBaseURL,
 		MetricsCollector:  c.MetricsCollector,
 		SyncComparison:    c.cfg.ApisixResourceSyncComparison,
 		EnableEtcdServer:  c.cfg.EtcdServer.Enabled,
@@ -435,6 +449,14 @@ func (c *Controller) run(ctx context.Context) error {
 
 	log.Info("creating controller")
 	c.informers = c.initSharedInformers()
+	if c.cfg.Kubernetes.EnableAdmission {
+		// Index the routes for the conflict detection of the admission webhook.
+		routeIndex := validation.GetRouteIndex()
+		routeIndex.SetIngressClass(c.cfg.Kubernetes.IngressClass)
+		routeIndex.SetIngressClassLister(c.informers.IngressClassLister)
+		c.informers.ApisixRouteInformer.AddEventHandler(routeIndex.ApisixRouteEventHandler())
+		c.informers.IngressInformer.AddEventHandler(routeIndex.IngressEventHandler())
+	}
 	common := &providertypes.Common{
 		ControllerNamespace: c.namespace,
 		ListerInformer:      c.informers,
@@ -465,6 +487,8 @@ func (c *Controller) run(ctx context.Context) error {
 		ApisixUpstreamLister: c.informers.ApisixUpstreamLister,
 		PodProvider:          c.podProvider,
 		IngressClassName:     c.cfg.Kubernetes.IngressClass,
+		TopologyAwareRouting: c.cfg.Kubernetes.TopologyAwareRouting,
+		TopologyZone:         c.cfg.Kubernetes.TopologyZone,
 	})
 
 	c.apisixProvider, c.apisixTranslator, err = apisixprovider.NewProvider(common, c.namespaceProvider, c.translator)
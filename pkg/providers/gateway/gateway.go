@@ -17,6 +17,7 @@ package gateway
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"time"
 
 	"go.uber.org/zap"
@@ -28,6 +29,7 @@ import (
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/apache/apisix-ingress-controller/pkg/log"
+	gatewaytypes "github.com/apache/apisix-ingress-controller/pkg/providers/gateway/types"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/utils"
 	"github.com/apache/apisix-ingress-controller/pkg/types"
 )
@@ -58,7 +60,8 @@ func (c *gatewayController) run(ctx context.Context) {
 	defer log.Info("gateway controller exited")
 	defer c.workqueue.ShutDown()
 
-	if !cache.WaitForCacheSync(ctx.Done(), c.controller.gatewayInformer.HasSynced) {
+	if !cache.WaitForCacheSync(ctx.Done(), c.controller.gatewayInformer.HasSynced, c.controller.referenceGrantInformer.HasSynced,
+		c.controller.ListerInformer.SecretInformer.HasSynced) {
 		log.Error("cache sync failed")
 		return
 	}
@@ -122,23 +125,38 @@ func (c *gatewayController) sync(ctx context.Context, ev *types.Event) error {
 		}
 		gateway = ev.Tombstone.(*gatewayv1beta1.Gateway)
 
+		c.controller.storeSecretReferences(key, nil)
+		previous, _ := c.controller.QueryListeners(gateway.Namespace, gateway.Name)
 		err = c.controller.RemoveListeners(gateway.Namespace, gateway.Name)
 		if err != nil {
 			return err
 		}
+		c.controller.resyncAttachedRoutes(gateway.Namespace, gateway.Name)
+		return c.syncSSLs(ctx, previous, nil)
 	} else {
 		gatewayClassName := string(gateway.Spec.GatewayClassName)
 		if c.controller.HasGatewayClass(gatewayClassName) {
-			// TODO: handle listeners
+			// Secrets are referenced even if they don't exist yet, so that the
+			// listeners are re-translated once they are created.
+			c.controller.storeSecretReferences(key, gateway)
+			start := time.Now()
 			listeners, err := c.controller.translator.TranslateGatewayV1beta1(gateway)
+			c.controller.MetricsCollector.RecordTranslation(time.Since(start), "gateway", err)
 			if err != nil {
 				return err
 			}
 
+			previous, _ := c.controller.QueryListeners(gateway.Namespace, gateway.Name)
 			err = c.controller.AddListeners(gateway.Namespace, gateway.Name, listeners)
 			if err != nil {
 				return err
 			}
+			if !reflect.DeepEqual(previous, listeners) {
+				c.controller.resyncAttachedRoutes(gateway.Namespace, gateway.Name)
+			}
+			if err = c.syncSSLs(ctx, previous, listeners); err != nil {
+				return err
+			}
 		} else {
 			gatewayClass, err := c.controller.gatewayClassLister.Get(gatewayClassName)
 			if err != nil {
@@ -148,6 +166,8 @@ func (c *gatewayController) sync(ctx context.Context, ev *types.Event) error {
 				log.Warn("gatewayClass not synced")
 				return fmt.Errorf("wait gatewayClass %s synced", gatewayClassName)
 			}
+			// The Gateway is managed by another controller.
+			return nil
 		}
 	}
 
@@ -160,6 +180,27 @@ func (c *gatewayController) sync(ctx context.Context, ev *types.Event) error {
 	return nil
 }
 
+// syncSSLs syncs the SSLs of the listeners terminating TLS to APISIX, the
+// SSLs of previous listeners which no longer exist are deleted. All the SSLs
+// are compared with the cache and created if they differ, so that a failed
+// sync is fixed by the retry although the listeners are already recorded.
+func (c *gatewayController) syncSSLs(ctx context.Context, previous, listeners map[string]*gatewaytypes.ListenerConf) error {
+	om := &utils.Manifest{}
+	for _, listener := range previous {
+		if listener.SSL != nil {
+			om.SSLs = append(om.SSLs, listener.SSL)
+		}
+	}
+	m := &utils.Manifest{}
+	for _, listener := range listeners {
+		if listener.SSL != nil {
+			m.SSLs = append(m.SSLs, listener.SSL)
+		}
+	}
+	_, _, deleted := m.Diff(om)
+	return utils.SyncManifests(ctx, c.controller.APISIX, c.controller.APISIXClusterName, m, nil, deleted, true)
+}
+
 func (c *gatewayController) handleSyncErr(obj interface{}, err error) {
 	if err == nil {
 		c.workqueue.Forget(obj)
@@ -206,6 +247,33 @@ func (c *gatewayController) onAdd(obj interface{}) {
 }
 
 func (c *gatewayController) onUpdate(oldObj, newObj interface{}) {
+	oldGateway := oldObj.(*gatewayv1beta1.Gateway)
+	newGateway := newObj.(*gatewayv1beta1.Gateway)
+	// Status updates, including the ones of this controller, don't change the generation.
+	if oldGateway.ResourceVersion >= newGateway.ResourceVersion || oldGateway.Generation == newGateway.Generation {
+		return
+	}
+	key, err := cache.MetaNamespaceKeyFunc(newObj)
+	if err != nil {
+		log.Errorw("found gateway resource with bad meta namespace key",
+			zap.Error(err),
+			zap.Any("obj", newObj),
+		)
+		return
+	}
+	if !c.controller.NamespaceProvider.IsWatchingNamespace(key) {
+		return
+	}
+	log.Debugw("gateway update event arrived",
+		zap.Any("old object", oldObj),
+		zap.Any("new object", newObj),
+	)
+
+	c.workqueue.Add(&types.Event{
+		Type:      types.EventUpdate,
+		Object:    key,
+		OldObject: oldGateway,
+	})
 }
 
 func (c *gatewayController) OnDelete(obj interface{}) {
@@ -250,12 +318,8 @@ func (c *gatewayController) recordStatus(v *gatewayv1beta1.Gateway, reason strin
 		ObservedGeneration: generation,
 	}
 
-	if v.Status.Conditions == nil {
-		conditions := make([]metav1.Condition, 0)
-		v.Status.Conditions = conditions
-	} else {
-		meta.SetStatusCondition(&v.Status.Conditions, gatewayCondition)
-	}
+	meta.SetStatusCondition(&v.Status.Conditions, gatewayCondition)
+	v.Status.Listeners = c.listenersStatus(v)
 
 	lbips, err := utils.IngressLBStatusIPs(c.controller.Cfg.IngressPublishService, c.controller.Cfg.IngressStatusAddress, c.controller.ListerInformer.SvcLister)
 	if err != nil {
@@ -273,3 +337,73 @@ func (c *gatewayController) recordStatus(v *gatewayv1beta1.Gateway, reason strin
 		)
 	}
 }
+
+// listenersStatus returns the status of every listener of the Gateway, with
+// the number of routes attached to it.
+func (c *gatewayController) listenersStatus(gateway *gatewayv1beta1.Gateway) []gatewayv1beta1.ListenerStatus {
+	// Listeners failed the translation are not registered.
+	listeners, _ := c.controller.QueryListeners(gateway.Namespace, gateway.Name)
+
+	statuses := make([]gatewayv1beta1.ListenerStatus, 0, len(gateway.Spec.Listeners))
+	for _, listener := range gateway.Spec.Listeners {
+		status := gatewayv1beta1.ListenerStatus{
+			Name:           listener.Name,
+			SupportedKinds: []gatewayv1beta1.RouteGroupKind{},
+			Conditions:     []metav1.Condition{},
+		}
+		for _, existing := range gateway.Status.Listeners {
+			if existing.Name == listener.Name {
+				status.Conditions = existing.Conditions
+				break
+			}
+		}
+
+		if conf, ok := listeners[string(listener.Name)]; ok {
+			status.SupportedKinds = conf.AllowedKinds
+			status.AttachedRoutes = c.controller.AttachedRoutes(gateway.Namespace, gateway.Name, string(listener.Name))
+			meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+				Type:               string(gatewayv1beta1.ListenerConditionAccepted),
+				Status:             metav1.ConditionTrue,
+				Reason:             string(gatewayv1beta1.ListenerReasonAccepted),
+				Message:            "Listener is accepted",
+				ObservedGeneration: gateway.Generation,
+			})
+			meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+				Type:               string(gatewayv1beta1.ListenerConditionProgrammed),
+				Status:             metav1.ConditionTrue,
+				Reason:             string(gatewayv1beta1.ListenerReasonProgrammed),
+				Message:            "Listener is programmed",
+				ObservedGeneration: gateway.Generation,
+			})
+			meta.SetStatusCondition(&status.Conditions, listenerResolvedRefsCondition(listener, gateway.Generation))
+		} else {
+			meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+				Type:               string(gatewayv1beta1.ListenerConditionProgrammed),
+				Status:             metav1.ConditionFalse,
+				Reason:             string(gatewayv1beta1.ListenerReasonInvalid),
+				Message:            "Listener is invalid",
+				ObservedGeneration: gateway.Generation,
+			})
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// listenerResolvedRefsCondition reports the certificates of the listener which
+// don't take effect, since only the first one is translated to an APISIX SSL.
+func listenerResolvedRefsCondition(listener gatewayv1beta1.Listener, generation int64) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               string(gatewayv1beta1.ListenerConditionResolvedRefs),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(gatewayv1beta1.ListenerReasonResolvedRefs),
+		Message:            "All references are resolved",
+		ObservedGeneration: generation,
+	}
+	if listener.TLS != nil && len(listener.TLS.CertificateRefs) > 1 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(gatewayv1beta1.ListenerReasonInvalidCertificateRef)
+		condition.Message = fmt.Sprintf("only the first of %d CertificateRefs takes effect", len(listener.TLS.CertificateRefs))
+	}
+	return condition
+}
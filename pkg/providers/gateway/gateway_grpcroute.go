@@ -0,0 +1,378 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/apache/apisix-ingress-controller/pkg/log"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/translation"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/utils"
+	"github.com/apache/apisix-ingress-controller/pkg/types"
+)
+
+type gatewayGRPCRouteController struct {
+	controller *Provider
+	workqueue  workqueue.RateLimitingInterface
+	workers    int
+}
+
+func newGatewayGRPCRouteController(c *Provider) *gatewayGRPCRouteController {
+	ctrl := &gatewayGRPCRouteController{
+		controller: c,
+		workqueue:  workqueue.NewNamedRateLimitingQueue(workqueue.NewItemFastSlowRateLimiter(1*time.Second, 60*time.Second, 5), "GatewayGRPCRoute"),
+		workers:    1,
+	}
+
+	ctrl.controller.gatewayGRPCRouteInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    ctrl.onAdd,
+		UpdateFunc: ctrl.onUpdate,
+		DeleteFunc: ctrl.OnDelete,
+	})
+	return ctrl
+}
+
+func (c *gatewayGRPCRouteController) run(ctx context.Context) {
+	log.Info("gateway GRPCRoute controller started")
+	defer log.Info("gateway GRPCRoute controller exited")
+	defer c.workqueue.ShutDown()
+
+	if !cache.WaitForCacheSync(ctx.Done(), c.controller.gatewayGRPCRouteInformer.HasSynced, c.controller.referenceGrantInformer.HasSynced) {
+		log.Error("sync Gateway GRPCRoute cache failed")
+		return
+	}
+
+	for i := 0; i < c.workers; i++ {
+		go c.runWorker(ctx)
+	}
+	<-ctx.Done()
+}
+
+func (c *gatewayGRPCRouteController) runWorker(ctx context.Context) {
+	for {
+		obj, quit := c.workqueue.Get()
+		if quit {
+			return
+		}
+		err := c.sync(ctx, obj.(*types.Event))
+		c.workqueue.Done(obj)
+		c.handleSyncErr(obj, err)
+	}
+}
+
+func (c *gatewayGRPCRouteController) sync(ctx context.Context, ev *types.Event) error {
+	key := ev.Object.(string)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Errorw("found Gateway GRPCRoute resource with invalid key",
+			zap.Error(err),
+			zap.String("key", key),
+		)
+		return err
+	}
+
+	log.Debugw("sync GRPCRoute", zap.String("key", key))
+
+	grpcRoute, err := c.controller.gatewayGRPCRouteLister.GRPCRoutes(namespace).Get(name)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			log.Errorw("failed to get Gateway GRPCRoute",
+				zap.Error(err),
+				zap.String("key", key),
+			)
+			return err
+		}
+		if ev.Type != types.EventDelete {
+			log.Warnw("Gateway GRPCRoute was deleted before process",
+				zap.String("key", key),
+			)
+			// Don't need to retry.
+			return nil
+		}
+	}
+
+	if ev.Type == types.EventDelete {
+		if grpcRoute != nil {
+			// We still find the resource while we are processing the DELETE event,
+			// that means object with same namespace and name was created, discarding
+			// this stale DELETE event.
+			log.Warnw("discard the stale Gateway delete event since it exists",
+				zap.String("key", key),
+			)
+			return nil
+		}
+		grpcRoute = ev.Tombstone.(*gatewayv1alpha2.GRPCRoute)
+	}
+	routeKey := "GRPCRoute/" + key
+	var tctx *translation.TranslateContext
+	if ev.Type == types.EventDelete {
+		c.controller.detachRoute(routeKey)
+		tctx, err = c.controller.translator.TranslateGatewayGRPCRouteV1Alpha2(grpcRoute, nil)
+	} else {
+		var results []*ParentRefResult
+		results, err = c.controller.validator.ValidateParentRefs(grpcRoute)
+		if err != nil {
+			log.Errorw("failed to validate gateway GRPCRoute",
+				zap.Error(err),
+				zap.Any("object", grpcRoute),
+			)
+			return err
+		}
+		c.controller.attachRoute(routeKey, results)
+
+		refErr := c.controller.validator.ValidateBackendRefs(grpcRoute)
+		if refErr == nil {
+			refErr = c.controller.validator.ValidateBackendFilters(grpcRoute)
+		}
+		acceptedErr := acceptedError(results)
+		if acceptedErr == nil {
+			start := time.Now()
+			tctx, err = c.controller.translator.TranslateGatewayGRPCRouteV1Alpha2(grpcRoute, acceptedListeners(results))
+			c.controller.MetricsCollector.RecordTranslation(time.Since(start), "gateway_grpcroute", err)
+			if refErr == nil {
+				refErr = err
+			}
+		}
+		c.recordStatus(grpcRoute, results, resolvedRefsCondition(refErr, grpcRoute.Generation))
+
+		if acceptedErr != nil {
+			log.Errorw("failed to validate gateway GRPCRoute",
+				zap.Error(acceptedErr),
+				zap.Any("object", grpcRoute),
+			)
+			// The GRPCRoute is resynced once it or the listeners of its parents change.
+			return c.deleteStaleRoutes(ctx, ev, grpcRoute)
+		}
+	}
+	if err != nil {
+		log.Errorw("failed to translate gateway GRPCRoute",
+			zap.Error(err),
+			zap.Any("object", grpcRoute),
+		)
+		if ev.Type != types.EventDelete {
+			if derr := c.deleteStaleRoutes(ctx, ev, grpcRoute); derr != nil {
+				log.Errorw("failed to delete stale routes of gateway GRPCRoute",
+					zap.Error(derr),
+					zap.Any("object", grpcRoute),
+				)
+			}
+		}
+		return err
+	}
+
+	log.Debugw("translated GRPCRoute",
+		zap.Any("routes", tctx.Routes),
+		zap.Any("upstreams", tctx.Upstreams),
+	)
+	m := &utils.Manifest{
+		Routes:    tctx.Routes,
+		Upstreams: tctx.Upstreams,
+	}
+
+	var (
+		added   *utils.Manifest
+		updated *utils.Manifest
+		deleted *utils.Manifest
+	)
+
+	if ev.Type == types.EventDelete {
+		deleted = m
+	} else if ev.Type.IsAddEvent() {
+		added = m
+		if om := c.controller.syncedRoute(routeKey); om != nil {
+			// Objects which are no longer translated, e.g. for the backends
+			// no longer permitted by a ReferenceGrant, must stop serving.
+			_, _, deleted = m.Diff(om)
+		}
+	} else {
+		var oldCtx *translation.TranslateContext
+		oldObj := ev.OldObject.(*gatewayv1alpha2.GRPCRoute)
+		oldCtx, _ = c.controller.translator.TranslateGatewayGRPCRouteV1Alpha2(oldObj, nil)
+		if oldCtx != nil {
+
+			om := &utils.Manifest{
+				Routes:    oldCtx.Routes,
+				Upstreams: oldCtx.Upstreams,
+			}
+			added, updated, deleted = m.Diff(om)
+		} else {
+			added = m
+		}
+	}
+
+	if err = utils.SyncManifests(ctx, c.controller.APISIX, c.controller.APISIXClusterName, added, updated, deleted, ev.Type.IsSyncEvent()); err != nil {
+		return err
+	}
+	c.controller.recordSyncedRoute(routeKey, ev.Type, m)
+	return nil
+}
+
+// deleteStaleRoutes removes the resources synced for the previous version of
+// the GRPCRoute, which must not keep serving traffic once the current version is
+// rejected or can't be translated.
+func (c *gatewayGRPCRouteController) deleteStaleRoutes(ctx context.Context, ev *types.Event, grpcRoute *gatewayv1alpha2.GRPCRoute) error {
+	obj := grpcRoute
+	if ev.Type == types.EventUpdate {
+		obj = ev.OldObject.(*gatewayv1alpha2.GRPCRoute)
+	}
+	tctx, err := c.controller.translator.TranslateGatewayGRPCRouteV1Alpha2(obj, nil)
+	if err != nil {
+		log.Warnw("failed to translate stale GRPCRoute, stale resources may be left",
+			zap.Error(err),
+			zap.Any("object", obj),
+		)
+		return nil
+	}
+	deleted := &utils.Manifest{
+		Routes:    tctx.Routes,
+		Upstreams: tctx.Upstreams,
+	}
+	return utils.SyncManifests(ctx, c.controller.APISIX, c.controller.APISIXClusterName, nil, nil, deleted, false)
+}
+
+func (c *gatewayGRPCRouteController) handleSyncErr(obj interface{}, err error) {
+	if err == nil {
+		c.workqueue.Forget(obj)
+		c.controller.MetricsCollector.IncrSyncOperation("gateway_grpcroute", "success")
+		return
+	}
+	event := obj.(*types.Event)
+	if k8serrors.IsNotFound(err) && event.Type != types.EventDelete {
+		log.Infow("sync gateway GRPCRoute but not found, ignore",
+			zap.String("event_type", event.Type.String()),
+			zap.String("GRPCRoute ", event.Object.(string)),
+		)
+		c.workqueue.Forget(event)
+		return
+	}
+	log.Warnw("sync gateway GRPCRoute failed, will retry",
+		zap.Any("object", obj),
+		zap.Error(err),
+	)
+	c.workqueue.AddRateLimited(obj)
+	c.controller.MetricsCollector.IncrSyncOperation("gateway_grpcroute", "failure")
+}
+
+func (c *gatewayGRPCRouteController) onAdd(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		log.Errorw("found gateway GRPCRoute resource with bad meta namespace key",
+			zap.Error(err),
+		)
+		return
+	}
+	if !c.controller.NamespaceProvider.IsWatchingNamespace(key) {
+		return
+	}
+	log.Debugw("gateway GRPCRoute add event arrived",
+		zap.String("key", key),
+		zap.Any("object", obj),
+	)
+
+	c.workqueue.Add(&types.Event{
+		Type:   types.EventAdd,
+		Object: key,
+	})
+}
+
+func (c *gatewayGRPCRouteController) onUpdate(oldObj, newObj interface{}) {
+	oldGRPCRoute := oldObj.(*gatewayv1alpha2.GRPCRoute)
+	newGRPCRoute := newObj.(*gatewayv1alpha2.GRPCRoute)
+	if oldGRPCRoute.ResourceVersion >= newGRPCRoute.ResourceVersion {
+		return
+	}
+	key, err := cache.MetaNamespaceKeyFunc(oldObj)
+	if err != nil {
+		log.Errorw("found gateway GRPCRoute resource with bad meta namespace key",
+			zap.Error(err),
+		)
+		return
+	}
+	if !c.controller.NamespaceProvider.IsWatchingNamespace(key) {
+		return
+	}
+	log.Debugw("Gateway GRPCRoute update event arrived",
+		zap.String("key", key),
+		zap.Any("old object", oldObj),
+		zap.Any("new object", newObj),
+	)
+
+	c.workqueue.Add(&types.Event{
+		Type:      types.EventUpdate,
+		Object:    key,
+		OldObject: oldGRPCRoute,
+	})
+}
+
+func (c *gatewayGRPCRouteController) OnDelete(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		log.Errorw("found Gateway GRPCRoute resource with bad meta namespace key",
+			zap.Error(err),
+		)
+		return
+	}
+	if !c.controller.NamespaceProvider.IsWatchingNamespace(key) {
+		return
+	}
+	grpcRoute, ok := obj.(*gatewayv1alpha2.GRPCRoute)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			log.Errorw("GRPCRoute in bad tombstone state",
+				zap.String("key", key),
+				zap.Any("obj", obj),
+			)
+			return
+		}
+		grpcRoute, ok = tombstone.Obj.(*gatewayv1alpha2.GRPCRoute)
+		if !ok {
+			return
+		}
+	}
+	log.Debugw("Gateway GRPCRoute delete event arrived",
+		zap.String("key", key),
+		zap.Any("object", obj),
+	)
+
+	c.workqueue.Add(&types.Event{
+		Type:      types.EventDelete,
+		Object:    key,
+		Tombstone: grpcRoute,
+	})
+}
+
+// recordStatus records the status of the GRPCRoute for every parent managed by this controller.
+func (c *gatewayGRPCRouteController) recordStatus(grpcRoute *gatewayv1alpha2.GRPCRoute, results []*ParentRefResult, resolvedRefs metav1.Condition) {
+	route := grpcRoute.DeepCopy()
+	if !c.controller.setRouteStatus(&route.Status.RouteStatus, route.Namespace, results, resolvedRefs) {
+		return
+	}
+	if _, err := c.controller.gatewayClient.GatewayV1alpha2().GRPCRoutes(route.Namespace).UpdateStatus(context.TODO(), route, metav1.UpdateOptions{}); err != nil {
+		log.Errorw("failed to record status change for GRPCRoute",
+			zap.Error(err),
+			zap.String("name", route.Name),
+			zap.String("namespace", route.Namespace),
+		)
+	}
+}
@@ -20,6 +20,7 @@ import (
 
 	"go.uber.org/zap"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
@@ -56,7 +57,7 @@ func (c *gatewayHTTPRouteController) run(ctx context.Context) {
 	defer log.Info("gateway HTTPRoute controller exited")
 	defer c.workqueue.ShutDown()
 
-	if !cache.WaitForCacheSync(ctx.Done(), c.controller.gatewayHTTPRouteInformer.HasSynced) {
+	if !cache.WaitForCacheSync(ctx.Done(), c.controller.gatewayHTTPRouteInformer.HasSynced, c.controller.referenceGrantInformer.HasSynced) {
 		log.Error("sync Gateway HTTPRoute cache failed")
 		return
 	}
@@ -122,22 +123,60 @@ func (c *gatewayHTTPRouteController) sync(ctx context.Context, ev *types.Event)
 		}
 		httpRoute = ev.Tombstone.(*gatewayv1beta1.HTTPRoute)
 	}
-	err = c.controller.validator.ValidateCommonRoute(httpRoute)
-	if err != nil {
-		log.Errorw("failed to validate gateway HTTPRoute",
-			zap.Error(err),
-			zap.Any("object", httpRoute),
-		)
-		return err
-	}
+	routeKey := "HTTPRoute/" + key
+	var tctx *translation.TranslateContext
+	if ev.Type == types.EventDelete {
+		c.controller.detachRoute(routeKey)
+		tctx, err = c.controller.translator.TranslateGatewayHTTPRouteV1beta1(httpRoute, nil)
+	} else {
+		var results []*ParentRefResult
+		results, err = c.controller.validator.ValidateParentRefs(httpRoute)
+		if err != nil {
+			log.Errorw("failed to validate gateway HTTPRoute",
+				zap.Error(err),
+				zap.Any("object", httpRoute),
+			)
+			return err
+		}
+		c.controller.attachRoute(routeKey, results)
 
-	tctx, err := c.controller.translator.TranslateGatewayHTTPRouteV1beta1(httpRoute)
+		refErr := c.controller.validator.ValidateBackendRefs(httpRoute)
+		if refErr == nil {
+			refErr = c.controller.validator.ValidateBackendFilters(httpRoute)
+		}
+		acceptedErr := acceptedError(results)
+		if acceptedErr == nil {
+			start := time.Now()
+			tctx, err = c.controller.translator.TranslateGatewayHTTPRouteV1beta1(httpRoute, acceptedListeners(results))
+			c.controller.MetricsCollector.RecordTranslation(time.Since(start), "gateway_httproute", err)
+			if refErr == nil {
+				refErr = err
+			}
+		}
+		c.recordStatus(httpRoute, results, resolvedRefsCondition(refErr, httpRoute.Generation))
 
+		if acceptedErr != nil {
+			log.Errorw("failed to validate gateway HTTPRoute",
+				zap.Error(acceptedErr),
+				zap.Any("object", httpRoute),
+			)
+			// The HTTPRoute is resynced once it or the listeners of its parents change.
+			return c.deleteStaleRoutes(ctx, ev, httpRoute)
+		}
+	}
 	if err != nil {
 		log.Errorw("failed to translate gateway HTTPRoute",
 			zap.Error(err),
 			zap.Any("object", httpRoute),
 		)
+		if ev.Type != types.EventDelete {
+			if derr := c.deleteStaleRoutes(ctx, ev, httpRoute); derr != nil {
+				log.Errorw("failed to delete stale routes of gateway HTTPRoute",
+					zap.Error(derr),
+					zap.Any("object", httpRoute),
+				)
+			}
+		}
 		return err
 	}
 
@@ -158,12 +197,17 @@ func (c *gatewayHTTPRouteController) sync(ctx context.Context, ev *types.Event)
 
 	if ev.Type == types.EventDelete {
 		deleted = m
-	} else if ev.Type == types.EventAdd {
+	} else if ev.Type.IsAddEvent() {
 		added = m
+		if om := c.controller.syncedRoute(routeKey); om != nil {
+			// Objects which are no longer translated, e.g. for the backends
+			// no longer permitted by a ReferenceGrant, must stop serving.
+			_, _, deleted = m.Diff(om)
+		}
 	} else {
 		var oldCtx *translation.TranslateContext
 		oldObj := ev.OldObject.(*gatewayv1beta1.HTTPRoute)
-		oldCtx, _ = c.controller.translator.TranslateGatewayHTTPRouteV1beta1(oldObj)
+		oldCtx, _ = c.controller.translator.TranslateGatewayHTTPRouteV1beta1(oldObj, nil)
 		if oldCtx != nil {
 
 			om := &utils.Manifest{
@@ -176,7 +220,34 @@ func (c *gatewayHTTPRouteController) sync(ctx context.Context, ev *types.Event)
 		}
 	}
 
-	return utils.SyncManifests(ctx, c.controller.APISIX, c.controller.APISIXClusterName, added, updated, deleted, false)
+	if err = utils.SyncManifests(ctx, c.controller.APISIX, c.controller.APISIXClusterName, added, updated, deleted, ev.Type.IsSyncEvent()); err != nil {
+		return err
+	}
+	c.controller.recordSyncedRoute(routeKey, ev.Type, m)
+	return nil
+}
+
+// deleteStaleRoutes removes the resources synced for the previous version of
+// the HTTPRoute, which must not keep serving traffic once the current version is
+// rejected or can't be translated.
+func (c *gatewayHTTPRouteController) deleteStaleRoutes(ctx context.Context, ev *types.Event, httpRoute *gatewayv1beta1.HTTPRoute) error {
+	obj := httpRoute
+	if ev.Type == types.EventUpdate {
+		obj = ev.OldObject.(*gatewayv1beta1.HTTPRoute)
+	}
+	tctx, err := c.controller.translator.TranslateGatewayHTTPRouteV1beta1(obj, nil)
+	if err != nil {
+		log.Warnw("failed to translate stale HTTPRoute, stale resources may be left",
+			zap.Error(err),
+			zap.Any("object", obj),
+		)
+		return nil
+	}
+	deleted := &utils.Manifest{
+		Routes:    tctx.Routes,
+		Upstreams: tctx.Upstreams,
+	}
+	return utils.SyncManifests(ctx, c.controller.APISIX, c.controller.APISIXClusterName, nil, nil, deleted, false)
 }
 
 func (c *gatewayHTTPRouteController) handleSyncErr(obj interface{}, err error) {
@@ -275,3 +346,18 @@ func (c *gatewayHTTPRouteController) OnDelete(obj interface{}) {
 		Tombstone: obj,
 	})
 }
+
+// recordStatus records the status of the HTTPRoute for every parent managed by this controller.
+func (c *gatewayHTTPRouteController) recordStatus(httpRoute *gatewayv1beta1.HTTPRoute, results []*ParentRefResult, resolvedRefs metav1.Condition) {
+	route := httpRoute.DeepCopy()
+	if !c.controller.setRouteStatus(&route.Status.RouteStatus, route.Namespace, results, resolvedRefs) {
+		return
+	}
+	if _, err := c.controller.gatewayClient.GatewayV1beta1().HTTPRoutes(route.Namespace).UpdateStatus(context.TODO(), route, metav1.UpdateOptions{}); err != nil {
+		log.Errorw("failed to record status change for HTTPRoute",
+			zap.Error(err),
+			zap.String("name", route.Name),
+			zap.String("namespace", route.Namespace),
+		)
+	}
+}
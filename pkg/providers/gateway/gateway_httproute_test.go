@@ -0,0 +1,143 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+	gatewaylistersv1beta1 "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1beta1"
+
+	"github.com/apache/apisix-ingress-controller/pkg/apisix"
+	gatewaytranslation "github.com/apache/apisix-ingress-controller/pkg/providers/gateway/translation"
+	gatewaytypes "github.com/apache/apisix-ingress-controller/pkg/providers/gateway/types"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/translation"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/utils"
+	"github.com/apache/apisix-ingress-controller/pkg/types"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+type fakeRoute struct {
+	apisix.Route
+	created []string
+	deleted []string
+}
+
+func (r *fakeRoute) Create(_ context.Context, obj *apisixv1.Route, _ bool) (*apisixv1.Route, error) {
+	r.created = append(r.created, obj.ID)
+	return obj, nil
+}
+
+func (r *fakeRoute) Delete(_ context.Context, obj *apisixv1.Route) error {
+	r.deleted = append(r.deleted, obj.ID)
+	return nil
+}
+
+// httpRouteTranslator translates every rule of the HTTPRoute whose backends
+// are permitted to a route, like the translator skips the forbidden ones.
+type httpRouteTranslator struct {
+	gatewaytranslation.Translator
+	referenceGrantLister gatewaylistersv1beta1.ReferenceGrantLister
+}
+
+func (t *httpRouteTranslator) TranslateGatewayHTTPRouteV1beta1(httpRoute *gatewayv1beta1.HTTPRoute, _ []*gatewaytypes.ListenerConf) (*translation.TranslateContext, error) {
+	tctx := &translation.TranslateContext{}
+	for i, rule := range httpRoute.Spec.Rules {
+		permitted := true
+		for _, backend := range rule.BackendRefs {
+			if !gatewaytranslation.IsBackendRefPermitted(t.referenceGrantLister, gatewaytypes.KindHTTPRoute, httpRoute.Namespace, backend.BackendObjectReference) {
+				permitted = false
+			}
+		}
+		if permitted {
+			tctx.Routes = append(tctx.Routes, &apisixv1.Route{Metadata: apisixv1.Metadata{ID: fmt.Sprintf("rule-%d", i)}})
+		}
+	}
+	return tctx, nil
+}
+
+func TestHTTPRouteReferenceGrantRevocation(t *testing.T) {
+	otherNamespace := gatewayv1beta1.Namespace("other")
+	httpRoute := &gatewayv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "route"},
+		Spec: gatewayv1beta1.HTTPRouteSpec{
+			Rules: []gatewayv1beta1.HTTPRouteRule{
+				{
+					BackendRefs: []gatewayv1beta1.HTTPBackendRef{
+						{BackendRef: gatewayv1beta1.BackendRef{BackendObjectReference: gatewayv1beta1.BackendObjectReference{Name: "local"}}},
+					},
+				},
+				{
+					BackendRefs: []gatewayv1beta1.HTTPBackendRef{
+						{BackendRef: gatewayv1beta1.BackendRef{BackendObjectReference: gatewayv1beta1.BackendObjectReference{Name: "remote", Namespace: &otherNamespace}}},
+					},
+				},
+			},
+		},
+	}
+	grant := &gatewayv1beta1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "grant"},
+		Spec: gatewayv1beta1.ReferenceGrantSpec{
+			From: []gatewayv1beta1.ReferenceGrantFrom{
+				{Group: gatewayv1beta1.GroupName, Kind: gatewaytypes.KindHTTPRoute, Namespace: "default"},
+			},
+			To: []gatewayv1beta1.ReferenceGrantTo{
+				{Group: "", Kind: "Service"},
+			},
+		},
+	}
+	routeIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.Nil(t, routeIndexer.Add(httpRoute))
+	grantIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	assert.Nil(t, grantIndexer.Add(grant))
+	grantLister := gatewaylistersv1beta1.NewReferenceGrantLister(grantIndexer)
+
+	route := &fakeRoute{}
+	p := &Provider{
+		attachedRoutes: make(map[string]map[string]struct{}),
+		syncedRoutes:   make(map[string]*utils.Manifest),
+		ProviderOptions: &ProviderOptions{
+			APISIX:           &fakeAPISIX{cluster: &fakeCluster{route: route}},
+			MetricsCollector: &fakeCollector{},
+		},
+		gatewayClient:          gatewayfake.NewSimpleClientset(httpRoute),
+		translator:             &httpRouteTranslator{referenceGrantLister: grantLister},
+		gatewayHTTPRouteLister: gatewaylistersv1beta1.NewHTTPRouteLister(routeIndexer),
+		referenceGrantLister:   grantLister,
+	}
+	p.validator = *newValidator(p)
+	c := &gatewayHTTPRouteController{controller: p}
+
+	err := c.sync(context.Background(), &types.Event{Type: types.EventAdd, Object: "default/route"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"rule-0", "rule-1"}, route.created)
+	assert.Len(t, route.deleted, 0)
+
+	// The ReferenceGrant is revoked, the route of the rule referring to the
+	// Service in another namespace must be removed.
+	assert.Nil(t, grantIndexer.Delete(grant))
+	route.created = nil
+	err = c.sync(context.Background(), &types.Event{Type: types.EventSync, Object: "default/route"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"rule-0"}, route.created)
+	assert.Equal(t, []string{"rule-1"}, route.deleted)
+}
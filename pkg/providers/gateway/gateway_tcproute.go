@@ -22,6 +22,7 @@ import (
 
 	"go.uber.org/zap"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -94,21 +95,54 @@ func (c *gatewayTCPRouteController) sync(ctx context.Context, ev *types.Event) e
 		}
 		tcpRoute = ev.Tombstone.(*gatewayv1alpha2.TCPRoute)
 	}
-	err = c.controller.validator.ValidateCommonRoute(tcpRoute)
-	if err != nil {
-		log.Errorw("failed to validate gateway TCPRoute",
-			zap.Error(err),
-			zap.Any("object", tcpRoute),
-		)
-		return err
-	}
+	routeKey := "TCPRoute/" + key
+	var tctx *translation.TranslateContext
+	if ev.Type == types.EventDelete {
+		c.controller.detachRoute(routeKey)
+		tctx, err = c.controller.translator.TranslateGatewayTCPRouteV1Alpha2(tcpRoute)
+	} else {
+		var results []*ParentRefResult
+		results, err = c.controller.validator.ValidateParentRefs(tcpRoute)
+		if err != nil {
+			log.Errorw("failed to validate gateway TCPRoute",
+				zap.Error(err),
+				zap.Any("object", tcpRoute),
+			)
+			return err
+		}
+		c.controller.attachRoute(routeKey, results)
 
-	tctx, err := c.controller.translator.TranslateGatewayTCPRouteV1Alpha2(tcpRoute)
+		refErr := c.controller.validator.ValidateBackendRefs(tcpRoute)
+		start := time.Now()
+		tctx, err = c.controller.translator.TranslateGatewayTCPRouteV1Alpha2(tcpRoute)
+		c.controller.MetricsCollector.RecordTranslation(time.Since(start), "gateway_tcproute", err)
+		if refErr == nil {
+			refErr = err
+		}
+		c.recordStatus(tcpRoute, results, resolvedRefsCondition(refErr, tcpRoute.Generation))
+
+		if acceptedErr := acceptedError(results); acceptedErr != nil {
+			log.Errorw("failed to validate gateway TCPRoute",
+				zap.Error(acceptedErr),
+				zap.Any("object", tcpRoute),
+			)
+			// The TCPRoute is resynced once it or the listeners of its parents change.
+			return c.deleteStaleRoutes(ctx, ev, tcpRoute)
+		}
+	}
 	if err != nil {
 		log.Errorw("failed to translate gateway TCPRoute",
 			zap.Error(err),
 			zap.Any("object", tcpRoute),
 		)
+		if ev.Type != types.EventDelete {
+			if derr := c.deleteStaleRoutes(ctx, ev, tcpRoute); derr != nil {
+				log.Errorw("failed to delete stale routes of gateway TCPRoute",
+					zap.Error(derr),
+					zap.Any("object", tcpRoute),
+				)
+			}
+		}
 		return err
 	}
 
@@ -129,8 +163,13 @@ func (c *gatewayTCPRouteController) sync(ctx context.Context, ev *types.Event) e
 
 	if ev.Type == types.EventDelete {
 		deleted = m
-	} else if ev.Type == types.EventAdd {
+	} else if ev.Type.IsAddEvent() {
 		added = m
+		if om := c.controller.syncedRoute(routeKey); om != nil {
+			// Objects which are no longer translated, e.g. for the backends
+			// no longer permitted by a ReferenceGrant, must stop serving.
+			_, _, deleted = m.Diff(om)
+		}
 	} else {
 		var oldCtx *translation.TranslateContext
 		oldObj := ev.OldObject.(*gatewayv1alpha2.TCPRoute)
@@ -147,7 +186,11 @@ func (c *gatewayTCPRouteController) sync(ctx context.Context, ev *types.Event) e
 
 	}
 
-	return utils.SyncManifests(ctx, c.controller.APISIX, c.controller.APISIXClusterName, added, updated, deleted, false)
+	if err = utils.SyncManifests(ctx, c.controller.APISIX, c.controller.APISIXClusterName, added, updated, deleted, ev.Type.IsSyncEvent()); err != nil {
+		return err
+	}
+	c.controller.recordSyncedRoute(routeKey, ev.Type, m)
+	return nil
 }
 
 func (c *gatewayTCPRouteController) run(ctx context.Context) {
@@ -155,7 +198,7 @@ func (c *gatewayTCPRouteController) run(ctx context.Context) {
 	defer log.Info("gateway TCPRoute controller exited")
 	defer c.workqueue.ShutDown()
 
-	if !cache.WaitForCacheSync(ctx.Done(), c.controller.gatewayTCPRouteInformer.HasSynced) {
+	if !cache.WaitForCacheSync(ctx.Done(), c.controller.gatewayTCPRouteInformer.HasSynced, c.controller.referenceGrantInformer.HasSynced) {
 		log.Error("sync Gateway TCPRoute cache failed")
 		return
 	}
@@ -177,6 +220,29 @@ func (c *gatewayTCPRouteController) runWorker(ctx context.Context) {
 	}
 }
 
+// deleteStaleRoutes removes the resources synced for the previous version of
+// the TCPRoute, which must not keep serving traffic once the current version is
+// rejected or can't be translated.
+func (c *gatewayTCPRouteController) deleteStaleRoutes(ctx context.Context, ev *types.Event, tcpRoute *gatewayv1alpha2.TCPRoute) error {
+	obj := tcpRoute
+	if ev.Type == types.EventUpdate {
+		obj = ev.OldObject.(*gatewayv1alpha2.TCPRoute)
+	}
+	tctx, err := c.controller.translator.TranslateGatewayTCPRouteV1Alpha2(obj)
+	if err != nil {
+		log.Warnw("failed to translate stale TCPRoute, stale resources may be left",
+			zap.Error(err),
+			zap.Any("object", obj),
+		)
+		return nil
+	}
+	deleted := &utils.Manifest{
+		StreamRoutes: tctx.StreamRoutes,
+		Upstreams:    tctx.Upstreams,
+	}
+	return utils.SyncManifests(ctx, c.controller.APISIX, c.controller.APISIXClusterName, nil, nil, deleted, false)
+}
+
 func (c *gatewayTCPRouteController) handleSyncErr(obj interface{}, err error) {
 	if err == nil {
 		c.workqueue.Forget(obj)
@@ -267,3 +333,18 @@ func (c *gatewayTCPRouteController) OnDelete(obj interface{}) {
 		Tombstone: obj,
 	})
 }
+
+// recordStatus records the status of the TCPRoute for every parent managed by this controller.
+func (c *gatewayTCPRouteController) recordStatus(tcpRoute *gatewayv1alpha2.TCPRoute, results []*ParentRefResult, resolvedRefs metav1.Condition) {
+	route := tcpRoute.DeepCopy()
+	if !c.controller.setRouteStatus(&route.Status.RouteStatus, route.Namespace, results, resolvedRefs) {
+		return
+	}
+	if _, err := c.controller.gatewayClient.GatewayV1alpha2().TCPRoutes(route.Namespace).UpdateStatus(context.TODO(), route, metav1.UpdateOptions{}); err != nil {
+		log.Errorw("failed to record status change for TCPRoute",
+			zap.Error(err),
+			zap.String("name", route.Name),
+			zap.String("namespace", route.Namespace),
+		)
+	}
+}
@@ -0,0 +1,113 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/apache/apisix-ingress-controller/pkg/apisix"
+	gatewaytranslation "github.com/apache/apisix-ingress-controller/pkg/providers/gateway/translation"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/translation"
+	"github.com/apache/apisix-ingress-controller/pkg/types"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+type fakeStreamRoute struct {
+	apisix.StreamRoute
+	deleted []string
+}
+
+func (sr *fakeStreamRoute) Delete(_ context.Context, obj *apisixv1.StreamRoute) error {
+	sr.deleted = append(sr.deleted, obj.ID)
+	return nil
+}
+
+type fakeCluster struct {
+	apisix.Cluster
+	streamRoute *fakeStreamRoute
+	route       *fakeRoute
+}
+
+func (c *fakeCluster) StreamRoute() apisix.StreamRoute {
+	return c.streamRoute
+}
+
+func (c *fakeCluster) Route() apisix.Route {
+	return c.route
+}
+
+type fakeAPISIX struct {
+	apisix.APISIX
+	cluster *fakeCluster
+}
+
+func (a *fakeAPISIX) Cluster(string) apisix.Cluster {
+	return a.cluster
+}
+
+type fakeTranslator struct {
+	gatewaytranslation.Translator
+}
+
+func (t *fakeTranslator) TranslateGatewayTCPRouteV1Alpha2(tcpRoute *gatewayv1alpha2.TCPRoute) (*translation.TranslateContext, error) {
+	return &translation.TranslateContext{
+		StreamRoutes: []*apisixv1.StreamRoute{
+			{ID: tcpRoute.ResourceVersion},
+		},
+	}, nil
+}
+
+func TestTCPRouteDeleteStaleRoutes(t *testing.T) {
+	sr := &fakeStreamRoute{}
+	c := &gatewayTCPRouteController{
+		controller: &Provider{
+			ProviderOptions: &ProviderOptions{
+				APISIX: &fakeAPISIX{cluster: &fakeCluster{streamRoute: sr}},
+			},
+			translator: &fakeTranslator{},
+		},
+	}
+	newTCPRoute := func(resourceVersion string) *gatewayv1alpha2.TCPRoute {
+		return &gatewayv1alpha2.TCPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "tcp_route",
+				Namespace:       "test",
+				ResourceVersion: resourceVersion,
+			},
+		}
+	}
+
+	// A rejected update removes what was synced for the old object.
+	err := c.deleteStaleRoutes(context.Background(), &types.Event{
+		Type:      types.EventUpdate,
+		OldObject: newTCPRoute("1"),
+	}, newTCPRoute("2"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1"}, sr.deleted)
+
+	// A rejected route found when resyncing has nothing older to compare with.
+	sr.deleted = nil
+	err = c.deleteStaleRoutes(context.Background(), &types.Event{
+		Type: types.EventSync,
+	}, newTCPRoute("2"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2"}, sr.deleted)
+}
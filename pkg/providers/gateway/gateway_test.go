@@ -0,0 +1,195 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+	gatewaylistersv1alpha2 "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1alpha2"
+	gatewaylistersv1beta1 "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1beta1"
+
+	"github.com/apache/apisix-ingress-controller/pkg/config"
+	"github.com/apache/apisix-ingress-controller/pkg/metrics"
+	gatewaytypes "github.com/apache/apisix-ingress-controller/pkg/providers/gateway/types"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/k8s/namespace"
+	providertypes "github.com/apache/apisix-ingress-controller/pkg/providers/types"
+	"github.com/apache/apisix-ingress-controller/pkg/types"
+)
+
+func TestListenerResolvedRefsCondition(t *testing.T) {
+	listener := gatewayv1beta1.Listener{
+		Name:     "https",
+		Protocol: gatewayv1beta1.HTTPSProtocolType,
+		TLS: &gatewayv1beta1.GatewayTLSConfig{
+			CertificateRefs: []gatewayv1beta1.SecretObjectReference{
+				{Name: "cert"},
+			},
+		},
+	}
+	cond := listenerResolvedRefsCondition(listener, 2)
+	assert.Equal(t, string(gatewayv1beta1.ListenerConditionResolvedRefs), cond.Type)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, int64(2), cond.ObservedGeneration)
+
+	listener.TLS.CertificateRefs = append(listener.TLS.CertificateRefs, gatewayv1beta1.SecretObjectReference{Name: "ecdsa-cert"})
+	cond = listenerResolvedRefsCondition(listener, 3)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, string(gatewayv1beta1.ListenerReasonInvalidCertificateRef), cond.Reason)
+	assert.Equal(t, "only the first of 2 CertificateRefs takes effect", cond.Message)
+}
+
+type fakeNamespaceProvider struct {
+	namespace.WatchingNamespaceProvider
+}
+
+func (p *fakeNamespaceProvider) IsWatchingNamespace(string) bool {
+	return true
+}
+
+type fakeCollector struct {
+	metrics.Collector
+}
+
+func (c *fakeCollector) RecordTranslation(time.Duration, string, error) {}
+
+func (t *fakeTranslator) TranslateGatewayV1beta1(gateway *gatewayv1beta1.Gateway) (map[string]*gatewaytypes.ListenerConf, error) {
+	listeners := make(map[string]*gatewaytypes.ListenerConf)
+	for _, listener := range gateway.Spec.Listeners {
+		listeners[string(listener.Name)] = &gatewaytypes.ListenerConf{
+			Namespace:   gateway.Namespace,
+			Name:        gateway.Name,
+			SectionName: string(listener.Name),
+			Protocol:    listener.Protocol,
+			Port:        listener.Port,
+			Hostname:    listener.Hostname,
+		}
+	}
+	return listeners, nil
+}
+
+func TestGatewaySyncResyncsAttachedRoutes(t *testing.T) {
+	gateway := &gatewayv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw"},
+		Spec: gatewayv1beta1.GatewaySpec{
+			GatewayClassName: "apisix",
+			Listeners: []gatewayv1beta1.Listener{
+				{Name: "http", Protocol: gatewayv1beta1.HTTPProtocolType, Port: 80},
+			},
+		},
+	}
+	gatewayIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.Nil(t, gatewayIndexer.Add(gateway))
+
+	// The HTTPRoutes are created before the Gateway.
+	gatewayNamespace := gatewayv1beta1.Namespace("default")
+	routeIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.Nil(t, routeIndexer.Add(&gatewayv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "attached"},
+		Spec: gatewayv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1beta1.CommonRouteSpec{
+				ParentRefs: []gatewayv1beta1.ParentReference{{Name: "gw"}},
+			},
+		},
+	}))
+	assert.Nil(t, routeIndexer.Add(&gatewayv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "attached"},
+		Spec: gatewayv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1beta1.CommonRouteSpec{
+				ParentRefs: []gatewayv1beta1.ParentReference{{Name: "gw", Namespace: &gatewayNamespace}},
+			},
+		},
+	}))
+	assert.Nil(t, routeIndexer.Add(&gatewayv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "unattached"},
+		Spec: gatewayv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1beta1.CommonRouteSpec{
+				ParentRefs: []gatewayv1beta1.ParentReference{{Name: "gw"}},
+			},
+		},
+	}))
+
+	cfg := config.NewDefaultConfig()
+	cfg.IngressStatusAddress = []string{"127.0.0.1"}
+	emptyIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	p := &Provider{
+		gatewayClasses: map[string]struct{}{"apisix": {}},
+		listeners:      make(map[string]map[string]*gatewaytypes.ListenerConf),
+		portListeners:  make(map[gatewayv1beta1.PortNumber]*gatewaytypes.ListenerConf),
+		attachedRoutes: make(map[string]map[string]struct{}),
+		secretRefs:     make(map[string]map[string]struct{}),
+		ProviderOptions: &ProviderOptions{
+			Cfg:               cfg,
+			APISIX:            &fakeAPISIX{cluster: &fakeCluster{}},
+			MetricsCollector:  &fakeCollector{},
+			NamespaceProvider: &fakeNamespaceProvider{},
+			ListerInformer:    &providertypes.ListerInformer{},
+		},
+		gatewayClient:          gatewayfake.NewSimpleClientset(gateway),
+		translator:             &fakeTranslator{},
+		gatewayLister:          gatewaylistersv1beta1.NewGatewayLister(gatewayIndexer),
+		gatewayHTTPRouteLister: gatewaylistersv1beta1.NewHTTPRouteLister(routeIndexer),
+		gatewayGRPCRouteLister: gatewaylistersv1alpha2.NewGRPCRouteLister(emptyIndexer),
+		gatewayTLSRouteLister:  gatewaylistersv1alpha2.NewTLSRouteLister(emptyIndexer),
+		gatewayTCPRouteLister:  gatewaylistersv1alpha2.NewTCPRouteLister(emptyIndexer),
+		gatewayUDPRouteLister:  gatewaylistersv1alpha2.NewUDPRouteLister(emptyIndexer),
+	}
+	p.gatewayHTTPRouteController = &gatewayHTTPRouteController{controller: p, workqueue: workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())}
+	c := &gatewayController{controller: p, workqueue: workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())}
+
+	queued := func() []string {
+		var keys []string
+		for p.gatewayHTTPRouteController.workqueue.Len() > 0 {
+			obj, _ := p.gatewayHTTPRouteController.workqueue.Get()
+			p.gatewayHTTPRouteController.workqueue.Done(obj)
+			ev := obj.(*types.Event)
+			assert.True(t, ev.Type.IsSyncEvent())
+			keys = append(keys, ev.Object.(string))
+		}
+		return keys
+	}
+
+	// The listeners are registered, the routes rejected before are retried.
+	err := c.sync(context.Background(), &types.Event{Type: types.EventAdd, Object: "default/gw"})
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"default/attached", "app/attached"}, queued())
+
+	// Nothing changed.
+	err = c.sync(context.Background(), &types.Event{Type: types.EventSync, Object: "default/gw"})
+	assert.Nil(t, err)
+	assert.Len(t, queued(), 0)
+
+	// The hostname of the listener changed.
+	hostname := gatewayv1beta1.Hostname("foo.com")
+	gateway.Spec.Listeners[0].Hostname = &hostname
+	assert.Nil(t, gatewayIndexer.Update(gateway))
+	err = c.sync(context.Background(), &types.Event{Type: types.EventUpdate, Object: "default/gw"})
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"default/attached", "app/attached"}, queued())
+
+	// The Gateway is deleted.
+	assert.Nil(t, gatewayIndexer.Delete(gateway))
+	err = c.sync(context.Background(), &types.Event{Type: types.EventDelete, Object: "default/gw", Tombstone: gateway})
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"default/attached", "app/attached"}, queued())
+}
@@ -37,6 +37,7 @@ import (
 
 	"go.uber.org/zap"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -73,7 +74,7 @@ func (c *gatewayTLSRouteController) run(ctx context.Context) {
 	defer log.Info("gateway TLSRoute controller exited")
 	defer c.workqueue.ShutDown()
 
-	if !cache.WaitForCacheSync(ctx.Done(), c.controller.gatewayTLSRouteInformer.HasSynced) {
+	if !cache.WaitForCacheSync(ctx.Done(), c.controller.gatewayTLSRouteInformer.HasSynced, c.controller.referenceGrantInformer.HasSynced) {
 		log.Error("sync Gateway TLSRoute cache failed")
 		return
 	}
@@ -139,21 +140,54 @@ func (c *gatewayTLSRouteController) sync(ctx context.Context, ev *types.Event) e
 		}
 		tlsRoute = ev.Tombstone.(*gatewayv1alpha2.TLSRoute)
 	}
-	err = c.controller.validator.ValidateCommonRoute(tlsRoute)
-	if err != nil {
-		log.Errorw("failed to validate gateway HTTPRoute",
-			zap.Error(err),
-			zap.Any("object", tlsRoute),
-		)
-		return err
-	}
+	routeKey := "TLSRoute/" + key
+	var tctx *translation.TranslateContext
+	if ev.Type == types.EventDelete {
+		c.controller.detachRoute(routeKey)
+		tctx, err = c.controller.translator.GenerateGatewayTLSRouteV1Alpha2DeleteMark(tlsRoute)
+	} else {
+		var results []*ParentRefResult
+		results, err = c.controller.validator.ValidateParentRefs(tlsRoute)
+		if err != nil {
+			log.Errorw("failed to validate gateway TLSRoute",
+				zap.Error(err),
+				zap.Any("object", tlsRoute),
+			)
+			return err
+		}
+		c.controller.attachRoute(routeKey, results)
+
+		refErr := c.controller.validator.ValidateBackendRefs(tlsRoute)
+		start := time.Now()
+		tctx, err = c.controller.translator.TranslateGatewayTLSRouteV1Alpha2(tlsRoute)
+		c.controller.MetricsCollector.RecordTranslation(time.Since(start), "gateway_tlsroute", err)
+		if refErr == nil {
+			refErr = err
+		}
+		c.recordStatus(tlsRoute, results, resolvedRefsCondition(refErr, tlsRoute.Generation))
 
-	tctx, err := c.controller.translator.TranslateGatewayTLSRouteV1Alpha2(tlsRoute)
+		if acceptedErr := acceptedError(results); acceptedErr != nil {
+			log.Errorw("failed to validate gateway TLSRoute",
+				zap.Error(acceptedErr),
+				zap.Any("object", tlsRoute),
+			)
+			// The TLSRoute is resynced once it or the listeners of its parents change.
+			return c.deleteStaleRoutes(ctx, ev, tlsRoute)
+		}
+	}
 	if err != nil {
-		log.Warnw("failed to translate gateway TLSRoute",
+		log.Errorw("failed to translate gateway TLSRoute",
 			zap.Error(err),
 			zap.Any("object", tlsRoute),
 		)
+		if ev.Type != types.EventDelete {
+			if derr := c.deleteStaleRoutes(ctx, ev, tlsRoute); derr != nil {
+				log.Errorw("failed to delete stale routes of gateway TLSRoute",
+					zap.Error(derr),
+					zap.Any("object", tlsRoute),
+				)
+			}
+		}
 		return err
 	}
 
@@ -174,30 +208,60 @@ func (c *gatewayTLSRouteController) sync(ctx context.Context, ev *types.Event) e
 
 	if ev.Type == types.EventDelete {
 		deleted = m
-	} else if ev.Type == types.EventAdd {
+	} else if ev.Type.IsAddEvent() {
 		added = m
+		if om := c.controller.syncedRoute(routeKey); om != nil {
+			// Objects which are no longer translated, e.g. for the backends
+			// no longer permitted by a ReferenceGrant, must stop serving.
+			_, _, deleted = m.Diff(om)
+		}
 	} else {
 		var oldCtx *translation.TranslateContext
 		oldObj := ev.OldObject.(*gatewayv1alpha2.TLSRoute)
 		oldCtx, err = c.controller.translator.TranslateGatewayTLSRouteV1Alpha2(oldObj)
 		if err != nil {
-			log.Errorw("failed to translate old TLSRoute",
-				zap.String("version", oldObj.APIVersion),
-				zap.String("event_type", "update"),
+			log.Warnw("failed to translate old TLSRoute, stale resources may be left",
 				zap.Any("TLSRoute", oldObj),
 				zap.Error(err),
 			)
-			return err
+			added = m
+		} else {
+			om := &utils.Manifest{
+				StreamRoutes: oldCtx.StreamRoutes,
+				Upstreams:    oldCtx.Upstreams,
+			}
+			added, updated, deleted = m.Diff(om)
 		}
+	}
 
-		om := &utils.Manifest{
-			StreamRoutes: oldCtx.StreamRoutes,
-			Upstreams:    oldCtx.Upstreams,
-		}
-		added, updated, deleted = m.Diff(om)
+	if err = utils.SyncManifests(ctx, c.controller.APISIX, c.controller.APISIXClusterName, added, updated, deleted, ev.Type.IsSyncEvent()); err != nil {
+		return err
 	}
+	c.controller.recordSyncedRoute(routeKey, ev.Type, m)
+	return nil
+}
 
-	return utils.SyncManifests(ctx, c.controller.APISIX, c.controller.APISIXClusterName, added, updated, deleted, false)
+// deleteStaleRoutes removes the resources synced for the previous version of
+// the TLSRoute, which must not keep serving traffic once the current version is
+// rejected or can't be translated.
+func (c *gatewayTLSRouteController) deleteStaleRoutes(ctx context.Context, ev *types.Event, tlsRoute *gatewayv1alpha2.TLSRoute) error {
+	obj := tlsRoute
+	if ev.Type == types.EventUpdate {
+		obj = ev.OldObject.(*gatewayv1alpha2.TLSRoute)
+	}
+	tctx, err := c.controller.translator.TranslateGatewayTLSRouteV1Alpha2(obj)
+	if err != nil {
+		log.Warnw("failed to translate stale TLSRoute, stale resources may be left",
+			zap.Error(err),
+			zap.Any("object", obj),
+		)
+		return nil
+	}
+	deleted := &utils.Manifest{
+		StreamRoutes: tctx.StreamRoutes,
+		Upstreams:    tctx.Upstreams,
+	}
+	return utils.SyncManifests(ctx, c.controller.APISIX, c.controller.APISIXClusterName, nil, nil, deleted, false)
 }
 
 func (c *gatewayTLSRouteController) handleSyncErr(obj interface{}, err error) {
@@ -244,5 +308,81 @@ func (c *gatewayTLSRouteController) onAdd(obj interface{}) {
 		Object: key,
 	})
 }
-func (c *gatewayTLSRouteController) onUpdate(oldObj, newObj interface{}) {}
-func (c *gatewayTLSRouteController) OnDelete(obj interface{})            {}
+
+func (c *gatewayTLSRouteController) onUpdate(oldObj, newObj interface{}) {
+	oldTLSRoute := oldObj.(*gatewayv1alpha2.TLSRoute)
+	newTLSRoute := newObj.(*gatewayv1alpha2.TLSRoute)
+	if oldTLSRoute.ResourceVersion >= newTLSRoute.ResourceVersion {
+		return
+	}
+	key, err := cache.MetaNamespaceKeyFunc(oldObj)
+	if err != nil {
+		log.Errorw("found gateway TLSRoute resource with bad meta namespace key",
+			zap.Error(err),
+		)
+		return
+	}
+	if !c.controller.NamespaceProvider.IsWatchingNamespace(key) {
+		return
+	}
+	log.Debugw("gateway TLSRoute update event arrived",
+		zap.Any("old object", oldObj),
+		zap.Any("new object", newObj),
+	)
+	c.workqueue.Add(&types.Event{
+		Type:      types.EventUpdate,
+		Object:    key,
+		OldObject: oldTLSRoute,
+	})
+}
+
+func (c *gatewayTLSRouteController) OnDelete(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		log.Errorw("found gateway TLSRoute resource with bad meta namespace key",
+			zap.Error(err),
+		)
+		return
+	}
+	if !c.controller.NamespaceProvider.IsWatchingNamespace(key) {
+		return
+	}
+	tlsRoute, ok := obj.(*gatewayv1alpha2.TLSRoute)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			log.Errorw("TLSRoute in bad tombstone state",
+				zap.String("key", key),
+				zap.Any("obj", obj),
+			)
+			return
+		}
+		tlsRoute, ok = tombstone.Obj.(*gatewayv1alpha2.TLSRoute)
+		if !ok {
+			return
+		}
+	}
+	log.Debugw("gateway TLSRoute delete event arrived",
+		zap.Any("object", obj),
+	)
+	c.workqueue.Add(&types.Event{
+		Type:      types.EventDelete,
+		Object:    key,
+		Tombstone: tlsRoute,
+	})
+}
+
+// recordStatus records the status of the TLSRoute for every parent managed by this controller.
+func (c *gatewayTLSRouteController) recordStatus(tlsRoute *gatewayv1alpha2.TLSRoute, results []*ParentRefResult, resolvedRefs metav1.Condition) {
+	route := tlsRoute.DeepCopy()
+	if !c.controller.setRouteStatus(&route.Status.RouteStatus, route.Namespace, results, resolvedRefs) {
+		return
+	}
+	if _, err := c.controller.gatewayClient.GatewayV1alpha2().TLSRoutes(route.Namespace).UpdateStatus(context.TODO(), route, metav1.UpdateOptions{}); err != nil {
+		log.Errorw("failed to record status change for TLSRoute",
+			zap.Error(err),
+			zap.String("name", route.Name),
+			zap.String("namespace", route.Namespace),
+		)
+	}
+}
@@ -20,6 +20,7 @@ import (
 
 	"go.uber.org/zap"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -56,7 +57,7 @@ func (c *gatewayUDPRouteController) run(ctx context.Context) {
 	defer log.Info("gateway UDPRoute controller exited")
 	defer c.workqueue.ShutDown()
 
-	if !cache.WaitForCacheSync(ctx.Done(), c.controller.gatewayUDPRouteInformer.HasSynced) {
+	if !cache.WaitForCacheSync(ctx.Done(), c.controller.gatewayUDPRouteInformer.HasSynced, c.controller.referenceGrantInformer.HasSynced) {
 		log.Error("sync Gateway UDPRoute cache failed")
 		return
 	}
@@ -122,20 +123,54 @@ func (c *gatewayUDPRouteController) sync(ctx context.Context, ev *types.Event) e
 		}
 		udpRoute = ev.Tombstone.(*gatewayv1alpha2.UDPRoute)
 	}
-	err = c.controller.validator.ValidateCommonRoute(udpRoute)
-	if err != nil {
-		log.Errorw("failed to validate gateway UDPRoute",
-			zap.Error(err),
-			zap.Any("object", udpRoute),
-		)
-		return err
+	routeKey := "UDPRoute/" + key
+	var tctx *translation.TranslateContext
+	if ev.Type == types.EventDelete {
+		c.controller.detachRoute(routeKey)
+		tctx, err = c.controller.translator.GenerateGatewayUDPRouteV1Alpha2DeleteMark(udpRoute)
+	} else {
+		var results []*ParentRefResult
+		results, err = c.controller.validator.ValidateParentRefs(udpRoute)
+		if err != nil {
+			log.Errorw("failed to validate gateway UDPRoute",
+				zap.Error(err),
+				zap.Any("object", udpRoute),
+			)
+			return err
+		}
+		c.controller.attachRoute(routeKey, results)
+
+		refErr := c.controller.validator.ValidateBackendRefs(udpRoute)
+		start := time.Now()
+		tctx, err = c.controller.translator.TranslateGatewayUDPRouteV1Alpha2(udpRoute)
+		c.controller.MetricsCollector.RecordTranslation(time.Since(start), "gateway_udproute", err)
+		if refErr == nil {
+			refErr = err
+		}
+		c.recordStatus(udpRoute, results, resolvedRefsCondition(refErr, udpRoute.Generation))
+
+		if acceptedErr := acceptedError(results); acceptedErr != nil {
+			log.Errorw("failed to validate gateway UDPRoute",
+				zap.Error(acceptedErr),
+				zap.Any("object", udpRoute),
+			)
+			// The UDPRoute is resynced once it or the listeners of its parents change.
+			return c.deleteStaleRoutes(ctx, ev, udpRoute)
+		}
 	}
-	tctx, err := c.controller.translator.TranslateGatewayUDPRouteV1Alpha2(udpRoute)
 	if err != nil {
 		log.Errorw("failed to translate gateway UDPRoute",
 			zap.Error(err),
 			zap.Any("object", udpRoute),
 		)
+		if ev.Type != types.EventDelete {
+			if derr := c.deleteStaleRoutes(ctx, ev, udpRoute); derr != nil {
+				log.Errorw("failed to delete stale routes of gateway UDPRoute",
+					zap.Error(derr),
+					zap.Any("object", udpRoute),
+				)
+			}
+		}
 		return err
 	}
 
@@ -156,30 +191,60 @@ func (c *gatewayUDPRouteController) sync(ctx context.Context, ev *types.Event) e
 
 	if ev.Type == types.EventDelete {
 		deleted = m
-	} else if ev.Type == types.EventAdd {
+	} else if ev.Type.IsAddEvent() {
 		added = m
+		if om := c.controller.syncedRoute(routeKey); om != nil {
+			// Objects which are no longer translated, e.g. for the backends
+			// no longer permitted by a ReferenceGrant, must stop serving.
+			_, _, deleted = m.Diff(om)
+		}
 	} else {
 		var oldCtx *translation.TranslateContext
 		oldObj := ev.OldObject.(*gatewayv1alpha2.UDPRoute)
 		oldCtx, err = c.controller.translator.TranslateGatewayUDPRouteV1Alpha2(oldObj)
 		if err != nil {
-			log.Errorw("failed to translate old UDPRoute",
-				zap.String("version", oldObj.APIVersion),
-				zap.String("event_type", "update"),
+			log.Warnw("failed to translate old UDPRoute, stale resources may be left",
 				zap.Any("UDPRoute", oldObj),
 				zap.Error(err),
 			)
-			return err
+			added = m
+		} else {
+			om := &utils.Manifest{
+				StreamRoutes: oldCtx.StreamRoutes,
+				Upstreams:    oldCtx.Upstreams,
+			}
+			added, updated, deleted = m.Diff(om)
 		}
+	}
 
-		om := &utils.Manifest{
-			Routes:    oldCtx.Routes,
-			Upstreams: oldCtx.Upstreams,
-		}
-		added, updated, deleted = m.Diff(om)
+	if err = utils.SyncManifests(ctx, c.controller.APISIX, c.controller.APISIXClusterName, added, updated, deleted, ev.Type.IsSyncEvent()); err != nil {
+		return err
 	}
+	c.controller.recordSyncedRoute(routeKey, ev.Type, m)
+	return nil
+}
 
-	return utils.SyncManifests(ctx, c.controller.APISIX, c.controller.APISIXClusterName, added, updated, deleted, false)
+// deleteStaleRoutes removes the resources synced for the previous version of
+// the UDPRoute, which must not keep serving traffic once the current version is
+// rejected or can't be translated.
+func (c *gatewayUDPRouteController) deleteStaleRoutes(ctx context.Context, ev *types.Event, udpRoute *gatewayv1alpha2.UDPRoute) error {
+	obj := udpRoute
+	if ev.Type == types.EventUpdate {
+		obj = ev.OldObject.(*gatewayv1alpha2.UDPRoute)
+	}
+	tctx, err := c.controller.translator.TranslateGatewayUDPRouteV1Alpha2(obj)
+	if err != nil {
+		log.Warnw("failed to translate stale UDPRoute, stale resources may be left",
+			zap.Error(err),
+			zap.Any("object", obj),
+		)
+		return nil
+	}
+	deleted := &utils.Manifest{
+		StreamRoutes: tctx.StreamRoutes,
+		Upstreams:    tctx.Upstreams,
+	}
+	return utils.SyncManifests(ctx, c.controller.APISIX, c.controller.APISIXClusterName, nil, nil, deleted, false)
 }
 
 func (c *gatewayUDPRouteController) handleSyncErr(obj interface{}, err error) {
@@ -226,5 +291,81 @@ func (c *gatewayUDPRouteController) onAdd(obj interface{}) {
 		Object: key,
 	})
 }
-func (c *gatewayUDPRouteController) onUpdate(oldObj, newObj interface{}) {}
-func (c *gatewayUDPRouteController) OnDelete(obj interface{})            {}
+
+func (c *gatewayUDPRouteController) onUpdate(oldObj, newObj interface{}) {
+	oldUDPRoute := oldObj.(*gatewayv1alpha2.UDPRoute)
+	newUDPRoute := newObj.(*gatewayv1alpha2.UDPRoute)
+	if oldUDPRoute.ResourceVersion >= newUDPRoute.ResourceVersion {
+		return
+	}
+	key, err := cache.MetaNamespaceKeyFunc(oldObj)
+	if err != nil {
+		log.Errorw("found gateway UDPRoute resource with bad meta namespace key",
+			zap.Error(err),
+		)
+		return
+	}
+	if !c.controller.NamespaceProvider.IsWatchingNamespace(key) {
+		return
+	}
+	log.Debugw("gateway UDPRoute update event arrived",
+		zap.Any("old object", oldObj),
+		zap.Any("new object", newObj),
+	)
+	c.workqueue.Add(&types.Event{
+		Type:      types.EventUpdate,
+		Object:    key,
+		OldObject: oldUDPRoute,
+	})
+}
+
+func (c *gatewayUDPRouteController) OnDelete(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		log.Errorw("found gateway UDPRoute resource with bad meta namespace key",
+			zap.Error(err),
+		)
+		return
+	}
+	if !c.controller.NamespaceProvider.IsWatchingNamespace(key) {
+		return
+	}
+	udpRoute, ok := obj.(*gatewayv1alpha2.UDPRoute)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			log.Errorw("UDPRoute in bad tombstone state",
+				zap.String("key", key),
+				zap.Any("obj", obj),
+			)
+			return
+		}
+		udpRoute, ok = tombstone.Obj.(*gatewayv1alpha2.UDPRoute)
+		if !ok {
+			return
+		}
+	}
+	log.Debugw("gateway UDPRoute delete event arrived",
+		zap.Any("object", obj),
+	)
+	c.workqueue.Add(&types.Event{
+		Type:      types.EventDelete,
+		Object:    key,
+		Tombstone: udpRoute,
+	})
+}
+
+// recordStatus records the status of the UDPRoute for every parent managed by this controller.
+func (c *gatewayUDPRouteController) recordStatus(udpRoute *gatewayv1alpha2.UDPRoute, results []*ParentRefResult, resolvedRefs metav1.Condition) {
+	route := udpRoute.DeepCopy()
+	if !c.controller.setRouteStatus(&route.Status.RouteStatus, route.Namespace, results, resolvedRefs) {
+		return
+	}
+	if _, err := c.controller.gatewayClient.GatewayV1alpha2().UDPRoutes(route.Namespace).UpdateStatus(context.TODO(), route, metav1.UpdateOptions{}); err != nil {
+		log.Errorw("failed to record status change for UDPRoute",
+			zap.Error(err),
+			zap.String("name", route.Name),
+			zap.String("namespace", route.Namespace),
+		)
+	}
+}
@@ -32,6 +32,7 @@ import (
 	gatewaylistersv1alpha2 "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1alpha2"
 	gatewaylistersv1beta1 "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1beta1"
 
+	"github.com/apache/apisix-ingress-controller/pkg/api/validation"
 	"github.com/apache/apisix-ingress-controller/pkg/apisix"
 	"github.com/apache/apisix-ingress-controller/pkg/config"
 	"github.com/apache/apisix-ingress-controller/pkg/kube"
@@ -62,6 +63,18 @@ type Provider struct {
 	listeners     map[string]map[string]*types.ListenerConf
 	portListeners map[gatewayv1beta1.PortNumber]*types.ListenerConf
 
+	attachedRoutesLock sync.RWMutex
+	// route key ("kind/ns/name") -> listener keys ("ns/name/section") the route attaches to
+	attachedRoutes map[string]map[string]struct{}
+
+	syncedRoutesLock sync.RWMutex
+	// route key ("kind/ns/name") -> manifest last synced to APISIX for the route
+	syncedRoutes map[string]*utils.Manifest
+
+	secretRefsLock sync.RWMutex
+	// Secret key ("ns/name") -> keys ("ns/name") of Gateways referencing it in certificateRefs
+	secretRefs map[string]map[string]struct{}
+
 	*ProviderOptions
 	gatewayClient gatewayclientset.Interface
 	runtimeClient runtimeclient.Client
@@ -81,6 +94,10 @@ type Provider struct {
 	gatewayHTTPRouteInformer   cache.SharedIndexInformer
 	gatewayHTTPRouteLister     gatewaylistersv1beta1.HTTPRouteLister
 
+	gatewayGRPCRouteController *gatewayGRPCRouteController
+	gatewayGRPCRouteInformer   cache.SharedIndexInformer
+	gatewayGRPCRouteLister     gatewaylistersv1alpha2.GRPCRouteLister
+
 	gatewayTLSRouteController *gatewayTLSRouteController
 	gatewayTLSRouteInformer   cache.SharedIndexInformer
 	gatewayTLSRouteLister     gatewaylistersv1alpha2.TLSRouteLister
@@ -92,6 +109,9 @@ type Provider struct {
 	gatewayUDPRouteController *gatewayUDPRouteController
 	gatewayUDPRouteInformer   cache.SharedIndexInformer
 	gatewayUDPRouteLister     gatewaylistersv1alpha2.UDPRouteLister
+
+	referenceGrantInformer cache.SharedIndexInformer
+	referenceGrantLister   gatewaylistersv1beta1.ReferenceGrantLister
 }
 
 type ProviderOptions struct {
@@ -133,13 +153,13 @@ func NewGatewayProvider(opts *ProviderOptions) (*Provider, error) {
 		listeners:     make(map[string]map[string]*types.ListenerConf),
 		portListeners: make(map[gatewayv1beta1.PortNumber]*types.ListenerConf),
 
+		attachedRoutes: make(map[string]map[string]struct{}),
+		syncedRoutes:   make(map[string]*utils.Manifest),
+		secretRefs:     make(map[string]map[string]struct{}),
+
 		ProviderOptions: opts,
 		gatewayClient:   gatewayKubeClient,
 		runtimeClient:   rClient,
-
-		translator: gatewaytranslation.NewTranslator(&gatewaytranslation.TranslatorOptions{
-			KubeTranslator: opts.KubeTranslator,
-		}),
 	}
 
 	gatewayFactory := gatewayexternalversions.NewSharedInformerFactory(p.gatewayClient, p.Cfg.Kubernetes.ResyncInterval.Duration)
@@ -153,6 +173,9 @@ func NewGatewayProvider(opts *ProviderOptions) (*Provider, error) {
 	p.gatewayHTTPRouteLister = gatewayFactory.Gateway().V1beta1().HTTPRoutes().Lister()
 	p.gatewayHTTPRouteInformer = gatewayFactory.Gateway().V1beta1().HTTPRoutes().Informer()
 
+	p.gatewayGRPCRouteLister = gatewayFactory.Gateway().V1alpha2().GRPCRoutes().Lister()
+	p.gatewayGRPCRouteInformer = gatewayFactory.Gateway().V1alpha2().GRPCRoutes().Informer()
+
 	p.gatewayTLSRouteLister = gatewayFactory.Gateway().V1alpha2().TLSRoutes().Lister()
 	p.gatewayTLSRouteInformer = gatewayFactory.Gateway().V1alpha2().TLSRoutes().Informer()
 
@@ -162,6 +185,15 @@ func NewGatewayProvider(opts *ProviderOptions) (*Provider, error) {
 	p.gatewayUDPRouteLister = gatewayFactory.Gateway().V1alpha2().UDPRoutes().Lister()
 	p.gatewayUDPRouteInformer = gatewayFactory.Gateway().V1alpha2().UDPRoutes().Informer()
 
+	p.referenceGrantLister = gatewayFactory.Gateway().V1beta1().ReferenceGrants().Lister()
+	p.referenceGrantInformer = gatewayFactory.Gateway().V1beta1().ReferenceGrants().Informer()
+
+	p.translator = gatewaytranslation.NewTranslator(&gatewaytranslation.TranslatorOptions{
+		KubeTranslator:       opts.KubeTranslator,
+		ReferenceGrantLister: p.referenceGrantLister,
+		SecretLister:         opts.ListerInformer.SecretLister,
+	})
+
 	p.gatewayController = newGatewayController(p)
 	p.validator = *newValidator(p)
 
@@ -171,12 +203,28 @@ func NewGatewayProvider(opts *ProviderOptions) (*Provider, error) {
 	}
 
 	p.gatewayHTTPRouteController = newGatewayHTTPRouteController(p)
+	p.gatewayGRPCRouteController = newGatewayGRPCRouteController(p)
 
 	p.gatewayTLSRouteController = newGatewayTLSRouteController(p)
 	p.gatewayUDPRouteController = newGatewayUDPRouteController(p)
 
 	p.gatewayTCPRouteController = newGatewayTCPRouteController(p)
 
+	p.referenceGrantInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    p.onReferenceGrantAdd,
+		UpdateFunc: p.onReferenceGrantUpdate,
+		DeleteFunc: p.onReferenceGrantDelete,
+	})
+	// The Secret informer is shared with other providers and run by the controller.
+	p.ListerInformer.SecretInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    p.onSecretAdd,
+		UpdateFunc: p.onSecretUpdate,
+		DeleteFunc: p.onSecretDelete,
+	})
+	if opts.Cfg.Kubernetes.EnableAdmission {
+		p.gatewayHTTPRouteInformer.AddEventHandler(validation.GetRouteIndex().HTTPRouteEventHandler())
+	}
+
 	return p, nil
 }
 
@@ -193,12 +241,18 @@ func (p *Provider) Run(ctx context.Context) {
 	e.Add(func() {
 		p.gatewayHTTPRouteInformer.Run(ctx.Done())
 	})
+	e.Add(func() {
+		p.gatewayGRPCRouteInformer.Run(ctx.Done())
+	})
 	e.Add(func() {
 		p.gatewayTLSRouteInformer.Run(ctx.Done())
 	})
 	e.Add(func() {
 		p.gatewayTCPRouteInformer.Run(ctx.Done())
 	})
+	e.Add(func() {
+		p.referenceGrantInformer.Run(ctx.Done())
+	})
 
 	// Run Controller
 	e.Add(func() {
@@ -214,6 +268,9 @@ func (p *Provider) Run(ctx context.Context) {
 	e.Add(func() {
 		p.gatewayHTTPRouteController.run(ctx)
 	})
+	e.Add(func() {
+		p.gatewayGRPCRouteController.run(ctx)
+	})
 	e.Add(func() {
 		p.gatewayTLSRouteController.run(ctx)
 	})
@@ -256,9 +313,12 @@ func (p *Provider) AddListeners(ns, name string, listeners map[string]*types.Lis
 
 	key := ns + "/" + name
 
-	// Check port conflicts
+	// Check port conflicts. The ports held by the previous listeners of the
+	// same Gateway don't conflict, since a Gateway is re-synced without being
+	// changed, e.g. when a ReferenceGrant for its certificateRefs changes.
 	for _, listenerConf := range listeners {
-		if allocated, found := p.portListeners[listenerConf.Port]; found {
+		if allocated, found := p.portListeners[listenerConf.Port]; found &&
+			(allocated.Namespace != ns || allocated.Name != name) {
 			// TODO: support multi-error
 			return fmt.Errorf("port %d already allocated by %s/%s section %s",
 				listenerConf.Port, allocated.Namespace, allocated.Name, allocated.SectionName)
@@ -284,6 +344,16 @@ func (p *Provider) AddListeners(ns, name string, listeners map[string]*types.Lis
 }
 
 func (p *Provider) RemoveListeners(ns, name string) error {
+	p.listenersLock.Lock()
+	defer p.listenersLock.Unlock()
+
+	key := ns + "/" + name
+	for _, listenerConf := range p.listeners[key] {
+		if allocated, found := p.portListeners[listenerConf.Port]; found && allocated == listenerConf {
+			delete(p.portListeners, listenerConf.Port)
+		}
+	}
+	delete(p.listeners, key)
 
 	return nil
 }
@@ -0,0 +1,49 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/apache/apisix-ingress-controller/pkg/providers/gateway/types"
+)
+
+func TestAddListenersPortConflicts(t *testing.T) {
+	p := &Provider{
+		listeners:     make(map[string]map[string]*types.ListenerConf),
+		portListeners: make(map[gatewayv1beta1.PortNumber]*types.ListenerConf),
+	}
+	listeners := func(ns, name string, port gatewayv1beta1.PortNumber) map[string]*types.ListenerConf {
+		return map[string]*types.ListenerConf{
+			"http": {Namespace: ns, Name: name, SectionName: "http", Port: port},
+		}
+	}
+
+	assert.Nil(t, p.AddListeners("default", "foo", listeners("default", "foo", 80)))
+	// Re-syncing the same Gateway keeps its ports.
+	assert.Nil(t, p.AddListeners("default", "foo", listeners("default", "foo", 80)))
+	assert.Equal(t, "foo", p.portListeners[80].Name)
+
+	err := p.AddListeners("default", "bar", listeners("default", "bar", 80))
+	assert.Contains(t, err.Error(), "port 80 already allocated by default/foo")
+
+	// Ports released by the Gateway can be taken by others.
+	assert.Nil(t, p.AddListeners("default", "foo", listeners("default", "foo", 8080)))
+	assert.Nil(t, p.AddListeners("default", "bar", listeners("default", "bar", 80)))
+}
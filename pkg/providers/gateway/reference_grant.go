@@ -0,0 +1,223 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package gateway
+
+import (
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/apache/apisix-ingress-controller/pkg/log"
+	gatewaytypes "github.com/apache/apisix-ingress-controller/pkg/providers/gateway/types"
+	"github.com/apache/apisix-ingress-controller/pkg/types"
+)
+
+func (p *Provider) onReferenceGrantAdd(obj interface{}) {
+	grant := obj.(*gatewayv1beta1.ReferenceGrant)
+	log.Debugw("ReferenceGrant add event arrived",
+		zap.Any("object", grant),
+	)
+	p.resyncReferencingObjects(grant)
+}
+
+func (p *Provider) onReferenceGrantUpdate(oldObj, newObj interface{}) {
+	oldGrant := oldObj.(*gatewayv1beta1.ReferenceGrant)
+	newGrant := newObj.(*gatewayv1beta1.ReferenceGrant)
+	if oldGrant.ResourceVersion >= newGrant.ResourceVersion {
+		return
+	}
+	log.Debugw("ReferenceGrant update event arrived",
+		zap.Any("old object", oldGrant),
+		zap.Any("new object", newGrant),
+	)
+	// Objects permitted only by the old grant have to be re-reconciled too.
+	p.resyncReferencingObjects(oldGrant)
+	p.resyncReferencingObjects(newGrant)
+}
+
+func (p *Provider) onReferenceGrantDelete(obj interface{}) {
+	grant, ok := obj.(*gatewayv1beta1.ReferenceGrant)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			log.Errorw("ReferenceGrant in bad tombstone state",
+				zap.Any("obj", obj),
+			)
+			return
+		}
+		grant, ok = tombstone.Obj.(*gatewayv1beta1.ReferenceGrant)
+		if !ok {
+			return
+		}
+	}
+	log.Debugw("ReferenceGrant delete event arrived",
+		zap.Any("object", grant),
+	)
+	p.resyncReferencingObjects(grant)
+}
+
+// resyncReferencingObjects re-reconciles the routes and Gateways that the
+// ReferenceGrant may permit to reference objects in its namespace.
+func (p *Provider) resyncReferencingObjects(grant *gatewayv1beta1.ReferenceGrant) {
+	for _, from := range grant.Spec.From {
+		if from.Group != gatewayv1beta1.GroupName {
+			continue
+		}
+		ns := string(from.Namespace)
+		switch from.Kind {
+		case gatewaytypes.KindHTTPRoute:
+			routes, err := p.gatewayHTTPRouteLister.HTTPRoutes(ns).List(labels.Everything())
+			if err != nil {
+				log.Errorw("failed to list HTTPRoutes", zap.Error(err), zap.String("namespace", ns))
+				continue
+			}
+			for _, route := range routes {
+				p.enqueueSync(p.gatewayHTTPRouteController.workqueue, route)
+			}
+		case gatewaytypes.KindGRPCRoute:
+			routes, err := p.gatewayGRPCRouteLister.GRPCRoutes(ns).List(labels.Everything())
+			if err != nil {
+				log.Errorw("failed to list GRPCRoutes", zap.Error(err), zap.String("namespace", ns))
+				continue
+			}
+			for _, route := range routes {
+				p.enqueueSync(p.gatewayGRPCRouteController.workqueue, route)
+			}
+		case gatewaytypes.KindTLSRoute:
+			routes, err := p.gatewayTLSRouteLister.TLSRoutes(ns).List(labels.Everything())
+			if err != nil {
+				log.Errorw("failed to list TLSRoutes", zap.Error(err), zap.String("namespace", ns))
+				continue
+			}
+			for _, route := range routes {
+				p.enqueueSync(p.gatewayTLSRouteController.workqueue, route)
+			}
+		case gatewaytypes.KindTCPRoute:
+			routes, err := p.gatewayTCPRouteLister.TCPRoutes(ns).List(labels.Everything())
+			if err != nil {
+				log.Errorw("failed to list TCPRoutes", zap.Error(err), zap.String("namespace", ns))
+				continue
+			}
+			for _, route := range routes {
+				p.enqueueSync(p.gatewayTCPRouteController.workqueue, route)
+			}
+		case gatewaytypes.KindUDPRoute:
+			routes, err := p.gatewayUDPRouteLister.UDPRoutes(ns).List(labels.Everything())
+			if err != nil {
+				log.Errorw("failed to list UDPRoutes", zap.Error(err), zap.String("namespace", ns))
+				continue
+			}
+			for _, route := range routes {
+				p.enqueueSync(p.gatewayUDPRouteController.workqueue, route)
+			}
+		case "Gateway":
+			gateways, err := p.gatewayLister.Gateways(ns).List(labels.Everything())
+			if err != nil {
+				log.Errorw("failed to list Gateways", zap.Error(err), zap.String("namespace", ns))
+				continue
+			}
+			for _, gateway := range gateways {
+				p.enqueueSync(p.gatewayController.workqueue, gateway)
+			}
+		}
+	}
+}
+
+// resyncAttachedRoutes re-reconciles the routes whose ParentRefs refer to the
+// Gateway, after its listeners are registered, changed or removed. Routes
+// synced before the listeners exist are rejected and never retried otherwise.
+func (p *Provider) resyncAttachedRoutes(ns, name string) {
+	refersTo := func(route any) bool {
+		r, err := parseToCommentRoute(route)
+		if err != nil {
+			return false
+		}
+		for _, parentRef := range r.parentRefs {
+			if parentRef.Kind != nil && *parentRef.Kind != "Gateway" {
+				continue
+			}
+			namespace := r.routeNamespace
+			if parentRef.Namespace != nil {
+				namespace = string(*parentRef.Namespace)
+			}
+			if namespace == ns && string(parentRef.Name) == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	httpRoutes, err := p.gatewayHTTPRouteLister.List(labels.Everything())
+	if err != nil {
+		log.Errorw("failed to list HTTPRoutes", zap.Error(err))
+	}
+	for _, route := range httpRoutes {
+		if refersTo(route) {
+			p.enqueueSync(p.gatewayHTTPRouteController.workqueue, route)
+		}
+	}
+	grpcRoutes, err := p.gatewayGRPCRouteLister.List(labels.Everything())
+	if err != nil {
+		log.Errorw("failed to list GRPCRoutes", zap.Error(err))
+	}
+	for _, route := range grpcRoutes {
+		if refersTo(route) {
+			p.enqueueSync(p.gatewayGRPCRouteController.workqueue, route)
+		}
+	}
+	tlsRoutes, err := p.gatewayTLSRouteLister.List(labels.Everything())
+	if err != nil {
+		log.Errorw("failed to list TLSRoutes", zap.Error(err))
+	}
+	for _, route := range tlsRoutes {
+		if refersTo(route) {
+			p.enqueueSync(p.gatewayTLSRouteController.workqueue, route)
+		}
+	}
+	tcpRoutes, err := p.gatewayTCPRouteLister.List(labels.Everything())
+	if err != nil {
+		log.Errorw("failed to list TCPRoutes", zap.Error(err))
+	}
+	for _, route := range tcpRoutes {
+		if refersTo(route) {
+			p.enqueueSync(p.gatewayTCPRouteController.workqueue, route)
+		}
+	}
+	udpRoutes, err := p.gatewayUDPRouteLister.List(labels.Everything())
+	if err != nil {
+		log.Errorw("failed to list UDPRoutes", zap.Error(err))
+	}
+	for _, route := range udpRoutes {
+		if refersTo(route) {
+			p.enqueueSync(p.gatewayUDPRouteController.workqueue, route)
+		}
+	}
+}
+
+func (p *Provider) enqueueSync(queue workqueue.RateLimitingInterface, obj metav1.Object) {
+	key := obj.GetNamespace() + "/" + obj.GetName()
+	if !p.NamespaceProvider.IsWatchingNamespace(key) {
+		return
+	}
+	queue.Add(&types.Event{
+		Type:   types.EventSync,
+		Object: key,
+	})
+}
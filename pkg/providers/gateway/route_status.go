@@ -0,0 +1,221 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package gateway
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/apache/apisix-ingress-controller/pkg/providers/utils"
+	"github.com/apache/apisix-ingress-controller/pkg/types"
+)
+
+// acceptedCondition returns the Accepted condition of a route for a parent.
+func acceptedCondition(result *ParentRefResult, generation int64) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               string(gatewayv1beta1.RouteConditionAccepted),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(gatewayv1beta1.RouteReasonAccepted),
+		Message:            "Route is accepted",
+		ObservedGeneration: generation,
+	}
+	if !result.Accepted() {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(result.Reason)
+		condition.Message = result.Message
+	}
+	return condition
+}
+
+// resolvedRefsCondition returns the ResolvedRefs condition of a route, err is
+// the result of Validator.ValidateBackendRefs or of the translation.
+func resolvedRefsCondition(err error, generation int64) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               string(gatewayv1beta1.RouteConditionResolvedRefs),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(gatewayv1beta1.RouteReasonResolvedRefs),
+		Message:            "All references are resolved",
+		ObservedGeneration: generation,
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(gatewayv1beta1.RouteReasonBackendNotFound)
+		condition.Message = err.Error()
+		var refErr *RefError
+		if errors.As(err, &refErr) {
+			condition.Reason = string(refErr.Reason)
+		}
+	}
+	return condition
+}
+
+// isManagedParentRef reports whether the parent of a route is a Gateway of
+// a GatewayClass managed by this controller.
+func (p *Provider) isManagedParentRef(routeNamespace string, ref gatewayv1beta1.ParentReference) bool {
+	if ref.Kind != nil && *ref.Kind != "Gateway" {
+		return false
+	}
+	ns := routeNamespace
+	if ref.Namespace != nil {
+		ns = string(*ref.Namespace)
+	}
+	gateway, err := p.gatewayLister.Gateways(ns).Get(string(ref.Name))
+	if err != nil {
+		return false
+	}
+	return p.HasGatewayClass(string(gateway.Spec.GatewayClassName))
+}
+
+// setRouteStatus sets the Accepted and ResolvedRefs conditions on the status
+// of every parent managed by this controller, and keeps the statuses written
+// by other controllers. It reports whether the status was changed.
+func (p *Provider) setRouteStatus(status *gatewayv1beta1.RouteStatus, routeNamespace string, results []*ParentRefResult,
+	resolvedRefs metav1.Condition) bool {
+	changed := false
+	for _, result := range results {
+		if !p.isManagedParentRef(routeNamespace, result.ParentRef) {
+			continue
+		}
+		accepted := acceptedCondition(result, resolvedRefs.ObservedGeneration)
+		if setRouteParentConditions(status, result.ParentRef, accepted, resolvedRefs) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// setRouteParentConditions sets the conditions on the status of the parent.
+// It reports whether the status was changed.
+func setRouteParentConditions(status *gatewayv1beta1.RouteStatus, ref gatewayv1beta1.ParentReference,
+	conditions ...metav1.Condition) bool {
+	changed := false
+	var parent *gatewayv1beta1.RouteParentStatus
+	for i := range status.Parents {
+		if status.Parents[i].ControllerName == GatewayClassName && reflect.DeepEqual(status.Parents[i].ParentRef, ref) {
+			parent = &status.Parents[i]
+			break
+		}
+	}
+	if parent == nil {
+		status.Parents = append(status.Parents, gatewayv1beta1.RouteParentStatus{
+			ParentRef:      ref,
+			ControllerName: GatewayClassName,
+		})
+		parent = &status.Parents[len(status.Parents)-1]
+		changed = true
+	}
+	for _, condition := range conditions {
+		existing := meta.FindStatusCondition(parent.Conditions, condition.Type)
+		if existing != nil && existing.Status == condition.Status && existing.Reason == condition.Reason &&
+			existing.Message == condition.Message && existing.ObservedGeneration == condition.ObservedGeneration {
+			continue
+		}
+		meta.SetStatusCondition(&parent.Conditions, condition)
+		changed = true
+	}
+	return changed
+}
+
+// attachRoute records the listeners the route attaches to, and re-reconciles
+// the Gateways whose number of attached routes changed.
+func (p *Provider) attachRoute(routeKey string, results []*ParentRefResult) {
+	listeners := make(map[string]struct{})
+	for _, result := range results {
+		for _, listener := range result.Listeners {
+			listeners[listener.Namespace+"/"+listener.Name+"/"+listener.SectionName] = struct{}{}
+		}
+	}
+
+	p.attachedRoutesLock.Lock()
+	previous := p.attachedRoutes[routeKey]
+	if len(listeners) == 0 {
+		delete(p.attachedRoutes, routeKey)
+	} else {
+		p.attachedRoutes[routeKey] = listeners
+	}
+	p.attachedRoutesLock.Unlock()
+
+	gateways := make(map[string]struct{})
+	for key := range listeners {
+		if _, ok := previous[key]; !ok {
+			gateways[gatewayKeyOfListener(key)] = struct{}{}
+		}
+	}
+	for key := range previous {
+		if _, ok := listeners[key]; !ok {
+			gateways[gatewayKeyOfListener(key)] = struct{}{}
+		}
+	}
+	for key := range gateways {
+		if p.NamespaceProvider.IsWatchingNamespace(key) {
+			p.gatewayController.workqueue.Add(&types.Event{
+				Type:   types.EventSync,
+				Object: key,
+			})
+		}
+	}
+}
+
+// detachRoute removes the route from all the listeners it attached to.
+func (p *Provider) detachRoute(routeKey string) {
+	p.attachRoute(routeKey, nil)
+}
+
+// syncedRoute returns the manifest last synced to APISIX for the route, nil
+// is returned if the route wasn't synced since the controller started.
+func (p *Provider) syncedRoute(routeKey string) *utils.Manifest {
+	p.syncedRoutesLock.RLock()
+	defer p.syncedRoutesLock.RUnlock()
+
+	return p.syncedRoutes[routeKey]
+}
+
+// recordSyncedRoute records the manifest synced to APISIX for the route.
+func (p *Provider) recordSyncedRoute(routeKey string, event types.EventType, m *utils.Manifest) {
+	p.syncedRoutesLock.Lock()
+	defer p.syncedRoutesLock.Unlock()
+
+	if event == types.EventDelete {
+		delete(p.syncedRoutes, routeKey)
+	} else {
+		p.syncedRoutes[routeKey] = m
+	}
+}
+
+// AttachedRoutes returns the number of routes attached to the listener.
+func (p *Provider) AttachedRoutes(ns, name, sectionName string) int32 {
+	p.attachedRoutesLock.RLock()
+	defer p.attachedRoutesLock.RUnlock()
+
+	key := ns + "/" + name + "/" + sectionName
+	var count int32
+	for _, listeners := range p.attachedRoutes {
+		if _, ok := listeners[key]; ok {
+			count++
+		}
+	}
+	return count
+}
+
+func gatewayKeyOfListener(listenerKey string) string {
+	return listenerKey[:strings.LastIndex(listenerKey, "/")]
+}
@@ -0,0 +1,145 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/apache/apisix-ingress-controller/pkg/providers/gateway/types"
+)
+
+func TestRouteConditions(t *testing.T) {
+	accepted := acceptedCondition(&ParentRefResult{
+		Listeners: []*types.ListenerConf{{Name: "gateway", SectionName: "http"}},
+	}, 2)
+	assert.Equal(t, metav1.ConditionTrue, accepted.Status)
+	assert.Equal(t, string(gatewayv1beta1.RouteReasonAccepted), accepted.Reason)
+	assert.Equal(t, int64(2), accepted.ObservedGeneration)
+
+	accepted = acceptedCondition(&ParentRefResult{
+		Reason:  gatewayv1beta1.RouteReasonNoMatchingListenerHostname,
+		Message: "no listener hostname matches the route hostnames",
+	}, 2)
+	assert.Equal(t, metav1.ConditionFalse, accepted.Status)
+	assert.Equal(t, string(gatewayv1beta1.RouteReasonNoMatchingListenerHostname), accepted.Reason)
+
+	resolved := resolvedRefsCondition(nil, 1)
+	assert.Equal(t, metav1.ConditionTrue, resolved.Status)
+	resolved = resolvedRefsCondition(&RefError{Reason: gatewayv1beta1.RouteReasonRefNotPermitted, Message: "not permitted"}, 1)
+	assert.Equal(t, metav1.ConditionFalse, resolved.Status)
+	assert.Equal(t, string(gatewayv1beta1.RouteReasonRefNotPermitted), resolved.Reason)
+	resolved = resolvedRefsCondition(errors.New("service not found"), 1)
+	assert.Equal(t, string(gatewayv1beta1.RouteReasonBackendNotFound), resolved.Reason)
+}
+
+func TestSetRouteParentConditions(t *testing.T) {
+	ref := gatewayv1beta1.ParentReference{Name: "gateway"}
+	status := &gatewayv1beta1.RouteStatus{
+		Parents: []gatewayv1beta1.RouteParentStatus{
+			{
+				ParentRef:      ref,
+				ControllerName: "example.com/other-controller",
+			},
+		},
+	}
+	condition := resolvedRefsCondition(nil, 1)
+
+	assert.True(t, setRouteParentConditions(status, ref, condition))
+	// The status written by the other controller is kept.
+	assert.Len(t, status.Parents, 2)
+	assert.Equal(t, gatewayv1beta1.GatewayController(GatewayClassName), status.Parents[1].ControllerName)
+	assert.Len(t, status.Parents[1].Conditions, 1)
+
+	// Nothing changes when the condition is the same.
+	assert.False(t, setRouteParentConditions(status, ref, condition))
+
+	condition = resolvedRefsCondition(&RefError{Reason: gatewayv1beta1.RouteReasonInvalidKind, Message: "invalid"}, 2)
+	assert.True(t, setRouteParentConditions(status, ref, condition))
+	assert.Len(t, status.Parents, 2)
+	assert.Equal(t, metav1.ConditionFalse, status.Parents[1].Conditions[0].Status)
+}
+
+func TestGatewayKeyOfListener(t *testing.T) {
+	assert.Equal(t, "default/gateway", gatewayKeyOfListener("default/gateway/http"))
+}
+
+func TestValidateBackendFilters(t *testing.T) {
+	backend := func(name string, filters ...gatewayv1beta1.HTTPRouteFilter) gatewayv1beta1.HTTPBackendRef {
+		return gatewayv1beta1.HTTPBackendRef{
+			BackendRef: gatewayv1beta1.BackendRef{
+				BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+					Name: gatewayv1beta1.ObjectName(name),
+				},
+			},
+			Filters: filters,
+		}
+	}
+	filter := gatewayv1beta1.HTTPRouteFilter{
+		Type: gatewayv1beta1.HTTPRouteFilterRequestHeaderModifier,
+		RequestHeaderModifier: &gatewayv1beta1.HTTPHeaderFilter{
+			Set: []gatewayv1beta1.HTTPHeader{{Name: "X-Backend", Value: "a"}},
+		},
+	}
+	route := &gatewayv1beta1.HTTPRoute{
+		Spec: gatewayv1beta1.HTTPRouteSpec{
+			Rules: []gatewayv1beta1.HTTPRouteRule{
+				{BackendRefs: []gatewayv1beta1.HTTPBackendRef{backend("a", filter), backend("b", filter)}},
+			},
+		},
+	}
+	v := &Validator{}
+	assert.Nil(t, v.ValidateBackendFilters(route))
+
+	route.Spec.Rules = append(route.Spec.Rules, gatewayv1beta1.HTTPRouteRule{
+		BackendRefs: []gatewayv1beta1.HTTPBackendRef{backend("a", filter), backend("b")},
+	})
+	condition := resolvedRefsCondition(v.ValidateBackendFilters(route), 1)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, string(gatewayv1beta1.RouteReasonIncompatibleFilters), condition.Reason)
+	assert.Contains(t, condition.Message, "Rules[1]")
+}
+
+func TestValidateGRPCBackendFilters(t *testing.T) {
+	filter := gatewayv1alpha2.GRPCRouteFilter{
+		Type: gatewayv1alpha2.GRPCRouteFilterRequestHeaderModifier,
+		RequestHeaderModifier: &gatewayv1beta1.HTTPHeaderFilter{
+			Set: []gatewayv1beta1.HTTPHeader{{Name: "X-Backend", Value: "a"}},
+		},
+	}
+	route := &gatewayv1alpha2.GRPCRoute{
+		Spec: gatewayv1alpha2.GRPCRouteSpec{
+			Rules: []gatewayv1alpha2.GRPCRouteRule{
+				{
+					BackendRefs: []gatewayv1alpha2.GRPCBackendRef{
+						{Filters: []gatewayv1alpha2.GRPCRouteFilter{filter}},
+						{},
+					},
+				},
+			},
+		},
+	}
+	err := (&Validator{}).ValidateBackendFilters(route)
+	var refErr *RefError
+	assert.True(t, errors.As(err, &refErr))
+	assert.Equal(t, gatewayv1beta1.RouteReasonIncompatibleFilters, refErr.Reason)
+	assert.Contains(t, refErr.Message, "Rules[0]")
+}
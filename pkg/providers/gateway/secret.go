@@ -0,0 +1,119 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package gateway
+
+import (
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/apache/apisix-ingress-controller/pkg/log"
+	"github.com/apache/apisix-ingress-controller/pkg/types"
+)
+
+func (p *Provider) onSecretAdd(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return
+	}
+	p.resyncReferencingGateways(key)
+}
+
+func (p *Provider) onSecretUpdate(oldObj, newObj interface{}) {
+	oldSecret := oldObj.(*corev1.Secret)
+	newSecret := newObj.(*corev1.Secret)
+	if oldSecret.ResourceVersion >= newSecret.ResourceVersion {
+		return
+	}
+	key, err := cache.MetaNamespaceKeyFunc(newObj)
+	if err != nil {
+		return
+	}
+	p.resyncReferencingGateways(key)
+}
+
+func (p *Provider) onSecretDelete(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return
+	}
+	p.resyncReferencingGateways(key)
+}
+
+// resyncReferencingGateways re-reconciles the Gateways which reference the
+// Secret in the certificateRefs of their listeners.
+func (p *Provider) resyncReferencingGateways(secretKey string) {
+	p.secretRefsLock.RLock()
+	gateways := make([]string, 0, len(p.secretRefs[secretKey]))
+	for gateway := range p.secretRefs[secretKey] {
+		gateways = append(gateways, gateway)
+	}
+	p.secretRefsLock.RUnlock()
+
+	for _, gateway := range gateways {
+		log.Debugw("Secret referenced by Gateway changed",
+			zap.String("secret", secretKey),
+			zap.String("gateway", gateway),
+		)
+		p.gatewayController.workqueue.Add(&types.Event{
+			Type:   types.EventSync,
+			Object: gateway,
+		})
+	}
+}
+
+// storeSecretReferences records the Secrets referenced by the listeners of the
+// Gateway, the references are removed if gateway is nil.
+func (p *Provider) storeSecretReferences(gatewayKey string, gateway *gatewayv1beta1.Gateway) {
+	secrets := make(map[string]struct{})
+	if gateway != nil {
+		for _, listener := range gateway.Spec.Listeners {
+			if listener.TLS == nil {
+				continue
+			}
+			for _, ref := range listener.TLS.CertificateRefs {
+				ns := gateway.Namespace
+				if ref.Namespace != nil {
+					ns = string(*ref.Namespace)
+				}
+				secrets[ns+"/"+string(ref.Name)] = struct{}{}
+			}
+		}
+	}
+
+	p.secretRefsLock.Lock()
+	defer p.secretRefsLock.Unlock()
+
+	for secret, refs := range p.secretRefs {
+		if _, ok := secrets[secret]; ok {
+			continue
+		}
+		delete(refs, gatewayKey)
+		if len(refs) == 0 {
+			delete(p.secretRefs, secret)
+		}
+	}
+	for secret := range secrets {
+		refs, ok := p.secretRefs[secret]
+		if !ok {
+			refs = make(map[string]struct{})
+			p.secretRefs[secret] = refs
+		}
+		refs[gatewayKey] = struct{}{}
+	}
+}
@@ -0,0 +1,64 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/apache/apisix-ingress-controller/pkg/providers/utils"
+)
+
+func TestStoreSecretReferences(t *testing.T) {
+	p := &Provider{
+		secretRefs: make(map[string]map[string]struct{}),
+	}
+	newGateway := func(refs ...gatewayv1beta1.SecretObjectReference) *gatewayv1beta1.Gateway {
+		return &gatewayv1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gateway",
+				Namespace: "test",
+			},
+			Spec: gatewayv1beta1.GatewaySpec{
+				Listeners: []gatewayv1beta1.Listener{
+					{
+						Name: "https",
+						TLS: &gatewayv1beta1.GatewayTLSConfig{
+							CertificateRefs: refs,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	p.storeSecretReferences("test/gateway", newGateway(
+		gatewayv1beta1.SecretObjectReference{Name: "cert"},
+		gatewayv1beta1.SecretObjectReference{Name: "cert", Namespace: utils.PtrOf(gatewayv1beta1.Namespace("certs"))},
+	))
+	assert.Contains(t, p.secretRefs["test/cert"], "test/gateway")
+	assert.Contains(t, p.secretRefs["certs/cert"], "test/gateway")
+
+	p.storeSecretReferences("test/gateway", newGateway(gatewayv1beta1.SecretObjectReference{Name: "cert"}))
+	assert.Contains(t, p.secretRefs["test/cert"], "test/gateway")
+	assert.NotContains(t, p.secretRefs, "certs/cert")
+
+	p.storeSecretReferences("test/gateway", nil)
+	assert.Len(t, p.secretRefs, 0)
+}
@@ -18,12 +18,16 @@ package translation
 
 import (
 	"errors"
+	"fmt"
 
 	"go.uber.org/zap"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
+	"github.com/apache/apisix-ingress-controller/pkg/id"
 	"github.com/apache/apisix-ingress-controller/pkg/log"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/gateway/types"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/translation"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
 )
 
 func (t *translator) TranslateGatewayV1beta1(gateway *gatewayv1beta1.Gateway) (map[string]*types.ListenerConf, error) {
@@ -44,6 +48,9 @@ func (t *translator) TranslateGatewayV1beta1(gateway *gatewayv1beta1.Gateway) (m
 		}
 
 		err = validateListenerConfigurations(gateway, i, allowedKinds, listener)
+		if err == nil {
+			err = t.validateListenerCertificateRefs(gateway, listener)
+		}
 		if err != nil {
 			// TODO: Update CRD status
 			log.Warnw("invalid listener conf",
@@ -76,12 +83,84 @@ func (t *translator) TranslateGatewayV1beta1(gateway *gatewayv1beta1.Gateway) (m
 			}
 		}
 
+		if isTLSTerminate(listener) {
+			conf.SSL, err = t.translateListenerSSL(gateway, listener)
+			if err != nil {
+				log.Warnw("invalid listener certificate",
+					zap.Error(err),
+					zap.String("gateway", gateway.Name),
+					zap.String("namespace", gateway.Namespace),
+					zap.Int("listener_index", i),
+				)
+				continue
+			}
+		}
+
 		listeners[conf.SectionName] = conf
 	}
 
 	return listeners, nil
 }
 
+func isTLSTerminate(listener gatewayv1beta1.Listener) bool {
+	if listener.TLS == nil {
+		return false
+	}
+	// Terminate is the default mode.
+	return listener.TLS.Mode == nil || *listener.TLS.Mode == gatewayv1beta1.TLSModeTerminate
+}
+
+// translateListenerSSL translates the first certificate of the listener to an
+// APISIX SSL, whose SNI is the hostname of the listener. The other
+// certificates are reported by the ResolvedRefs condition of the listener.
+func (t *translator) translateListenerSSL(gateway *gatewayv1beta1.Gateway, listener gatewayv1beta1.Listener) (*apisixv1.Ssl, error) {
+	if len(listener.TLS.CertificateRefs) == 0 {
+		return nil, errors.New("TLS mode Terminate requires CertificateRefs")
+	}
+	ref := listener.TLS.CertificateRefs[0]
+	if (ref.Group != nil && *ref.Group != "") || (ref.Kind != nil && *ref.Kind != kindSecret) {
+		return nil, fmt.Errorf("certificate reference %s is not a Secret", ref.Name)
+	}
+	if listener.Hostname == nil || *listener.Hostname == "" {
+		// APISIX selects the SSL by SNI, so a certificate without SNI never takes effect.
+		log.Warnw("ignore certificate of listener without hostname",
+			zap.String("gateway", gateway.Name),
+			zap.String("namespace", gateway.Namespace),
+			zap.String("listener", string(listener.Name)),
+		)
+		return nil, nil
+	}
+	if t.SecretLister == nil {
+		return nil, errors.New("no Secret lister")
+	}
+
+	ns := gateway.Namespace
+	if ref.Namespace != nil {
+		ns = string(*ref.Namespace)
+	}
+	secret, err := t.SecretLister.Secrets(ns).Get(string(ref.Name))
+	if err != nil {
+		return nil, err
+	}
+	cert, key, err := translation.ExtractKeyPair(secret, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apisixv1.Ssl{
+		ID:     id.GenID(gateway.Namespace + "_" + gateway.Name + "_" + string(listener.Name)),
+		Snis:   []string{string(*listener.Hostname)},
+		Cert:   string(cert),
+		Key:    string(key),
+		Status: 1,
+		Labels: map[string]string{
+			translation.MetaSecretNamespace: ns,
+			translation.MetaSecretName:      string(ref.Name),
+			"managed-by":                    "apisix-ingress-controller",
+		},
+	}, nil
+}
+
 func validateListenerConfigurations(gateway *gatewayv1beta1.Gateway, idx int, allowedKinds []gatewayv1beta1.RouteGroupKind,
 	listener gatewayv1beta1.Listener) error {
 	// Check protocols and allowedKinds
@@ -92,8 +171,8 @@ func validateListenerConfigurations(gateway *gatewayv1beta1.Gateway, idx int, al
 			return errors.New("non-empty TLS conf for protocol " + string(protocol))
 		}
 		if protocol == gatewayv1beta1.HTTPProtocolType {
-			if len(allowedKinds) != 1 || allowedKinds[0].Kind != types.KindHTTPRoute {
-				return errors.New("HTTP protocol must allow route type HTTPRoute")
+			if !isHTTPRouteKinds(allowedKinds) {
+				return errors.New("HTTP protocol only support route type HTTPRoute and GRPCRoute")
 			}
 		} else if protocol == gatewayv1beta1.TCPProtocolType {
 			if len(allowedKinds) != 1 || allowedKinds[0].Kind != types.KindTCPRoute {
@@ -137,8 +216,8 @@ func validateListenerConfigurations(gateway *gatewayv1beta1.Gateway, idx int, al
 			if *listener.TLS.Mode != gatewayv1beta1.TLSModeTerminate {
 				return errors.New("TLS mode for HTTPS protocol must be Terminate")
 			}
-			if len(allowedKinds) != 1 || allowedKinds[0].Kind != types.KindHTTPRoute {
-				return errors.New("HTTP protocol must allow route type HTTPRoute")
+			if !isHTTPRouteKinds(allowedKinds) {
+				return errors.New("HTTPS protocol only support route type HTTPRoute and GRPCRoute")
 			}
 		} else if protocol == gatewayv1beta1.TLSProtocolType {
 			for _, kind := range allowedKinds {
@@ -152,20 +231,45 @@ func validateListenerConfigurations(gateway *gatewayv1beta1.Gateway, idx int, al
 	return nil
 }
 
+// isHTTPRouteKinds reports whether the kinds are routes served over HTTP.
+func isHTTPRouteKinds(kinds []gatewayv1beta1.RouteGroupKind) bool {
+	if len(kinds) == 0 {
+		return false
+	}
+	for _, kind := range kinds {
+		if kind.Kind != types.KindHTTPRoute && kind.Kind != types.KindGRPCRoute {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *translator) validateListenerCertificateRefs(gateway *gatewayv1beta1.Gateway, listener gatewayv1beta1.Listener) error {
+	if listener.TLS == nil {
+		return nil
+	}
+	for _, ref := range listener.TLS.CertificateRefs {
+		if !IsSecretRefPermitted(t.ReferenceGrantLister, gateway.Namespace, ref) {
+			return fmt.Errorf("reference to certificate %s/%s is not permitted by any ReferenceGrant", *ref.Namespace, ref.Name)
+		}
+	}
+	return nil
+}
+
 func getAllowedKinds(listener gatewayv1beta1.Listener) ([]gatewayv1beta1.RouteGroupKind, error) {
 	var expectedKinds []gatewayv1beta1.RouteGroupKind
 	group := gatewayv1beta1.Group(gatewayv1beta1.GroupName)
 
-	var kind gatewayv1beta1.Kind
+	var kinds []gatewayv1beta1.Kind
 	switch listener.Protocol {
 	case gatewayv1beta1.HTTPProtocolType, gatewayv1beta1.HTTPSProtocolType:
-		kind = types.KindHTTPRoute
+		kinds = []gatewayv1beta1.Kind{types.KindHTTPRoute, types.KindGRPCRoute}
 	case gatewayv1beta1.TLSProtocolType:
-		kind = types.KindTLSRoute
+		kinds = []gatewayv1beta1.Kind{types.KindTLSRoute}
 	case gatewayv1beta1.TCPProtocolType:
-		kind = types.KindTCPRoute
+		kinds = []gatewayv1beta1.Kind{types.KindTCPRoute}
 	case gatewayv1beta1.UDPProtocolType:
-		kind = types.KindUDPRoute
+		kinds = []gatewayv1beta1.Kind{types.KindUDPRoute}
 	default:
 		// TODO: If an implementation does not support or recognize this resource type,
 		// it MUST set the “ResolvedRefs” condition to False for this Listener with
@@ -173,11 +277,11 @@ func getAllowedKinds(listener gatewayv1beta1.Listener) ([]gatewayv1beta1.RouteGr
 		return nil, errors.New("unknown protocol " + string(listener.Protocol))
 	}
 
-	expectedKinds = []gatewayv1beta1.RouteGroupKind{
-		{
+	for _, kind := range kinds {
+		expectedKinds = append(expectedKinds, gatewayv1beta1.RouteGroupKind{
 			Group: &group,
 			Kind:  kind,
-		},
+		})
 	}
 
 	if listener.AllowedRoutes == nil || len(listener.AllowedRoutes.Kinds) == 0 {
@@ -0,0 +1,292 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package translation
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/apache/apisix-ingress-controller/pkg/id"
+	"github.com/apache/apisix-ingress-controller/pkg/log"
+	gatewaytypes "github.com/apache/apisix-ingress-controller/pkg/providers/gateway/types"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/translation"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/utils"
+	"github.com/apache/apisix-ingress-controller/pkg/types"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+func (t *translator) generatePluginsFromGRPCRouteFilter(namespace string, plugins apisixv1.Plugins, filters []gatewayv1alpha2.GRPCRouteFilter) {
+	for _, filter := range filters {
+		switch filter.Type {
+		case gatewayv1alpha2.GRPCRouteFilterRequestHeaderModifier:
+			t.generatePluginFromHTTPRequestHeaderFilter(plugins, filter.RequestHeaderModifier)
+		case gatewayv1alpha2.GRPCRouteFilterResponseHeaderModifier:
+			t.generatePluginFromHTTPResponseHeaderFilter(plugins, filter.ResponseHeaderModifier)
+		case gatewayv1alpha2.GRPCRouteFilterRequestMirror:
+			t.generatePluginFromRequestMirrorFilter(gatewaytypes.KindGRPCRoute, namespace, apisixv1.SchemeGRPC, plugins, filter.RequestMirror)
+		}
+	}
+}
+
+func (t *translator) TranslateGatewayGRPCRouteV1Alpha2(grpcRoute *gatewayv1alpha2.GRPCRoute, listeners []*gatewaytypes.ListenerConf) (*translation.TranslateContext, error) {
+	ctx := translation.DefaultEmptyTranslateContext()
+
+	hosts, ok := gatewaytypes.IntersectListenersHostnames(listeners, grpcRoute.Spec.Hostnames)
+	if !ok {
+		return nil, errors.New("no hostname of the GRPCRoute intersects with the listeners")
+	}
+
+	rules := grpcRoute.Spec.Rules
+
+	for i, rule := range rules {
+		backends := rule.BackendRefs
+		if len(backends) == 0 {
+			continue
+		}
+
+		var ruleUpstreams []*apisixv1.Upstream
+		var weightedUpstreams []apisixv1.TrafficSplitConfigRuleWeightedUpstream
+		var ruleBackends []gatewayv1alpha2.GRPCBackendRef
+
+		for j, backend := range backends {
+			var kind string
+			if backend.Kind == nil {
+				kind = "service"
+			} else {
+				kind = strings.ToLower(string(*backend.Kind))
+			}
+			if kind != "service" {
+				log.Warnw(fmt.Sprintf("ignore non-service kind at Rules[%v].BackendRefs[%v]", i, j),
+					zap.String("kind", kind),
+				)
+				continue
+			}
+
+			var ns string
+			if backend.Namespace == nil {
+				ns = grpcRoute.Namespace
+			} else {
+				ns = string(*backend.Namespace)
+			}
+			if !IsBackendRefPermitted(t.ReferenceGrantLister, gatewaytypes.KindGRPCRoute, grpcRoute.Namespace, backend.BackendObjectReference) {
+				log.Warnw(fmt.Sprintf("ignore not permitted cross-namespace reference at Rules[%v].BackendRefs[%v]", i, j),
+					zap.String("namespace", ns),
+				)
+				continue
+			}
+
+			if backend.Port == nil {
+				log.Warnw(fmt.Sprintf("ignore nil port at Rules[%v].BackendRefs[%v]", i, j),
+					zap.String("kind", kind),
+				)
+				continue
+			}
+
+			ups, err := t.KubeTranslator.TranslateService(ns, string(backend.Name), "", int32(*backend.Port))
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to translate Rules[%v].BackendRefs[%v]", i, j))
+			}
+			// Keep the standard upstream name, so that the nodes are kept up to
+			// date by the endpoint controller, the same as other upstreams. The
+			// upstream is shared with the other routes to the Service port, so
+			// the scheme, i.e. grpc or grpcs, is set by its ApisixUpstream.
+			ups.Name = apisixv1.ComposeUpstreamName(ns, string(backend.Name), "", int32(*backend.Port), types.ResolveGranularity.Endpoint)
+
+			ups.Labels["meta_namespace"] = utils.TruncateString(ns, 64)
+			ups.Labels["meta_backend"] = utils.TruncateString(string(backend.Name), 64)
+			ups.Labels["meta_port"] = fmt.Sprintf("%v", int32(*backend.Port))
+
+			ups.ID = id.GenID(ups.Name)
+			log.Debugw("translated GRPCRoute upstream",
+				zap.Int("backendRefs_index", j),
+				zap.String("backendRefs_name", string(backend.Name)),
+				zap.String("name", ups.Name),
+			)
+			ctx.AddUpstream(ups)
+			ruleUpstreams = append(ruleUpstreams, ups)
+			ruleBackends = append(ruleBackends, backend)
+
+			weight := 1 // 1 is default value of BackendRef
+			if backend.Weight != nil {
+				weight = int(*backend.Weight)
+			}
+			weightedUpstreams = append(weightedUpstreams, apisixv1.TrafficSplitConfigRuleWeightedUpstream{
+				UpstreamID: ups.ID,
+				Weight:     weight,
+			})
+		}
+		if len(ruleUpstreams) == 0 {
+			log.Warnw(fmt.Sprintf("ignore all-failed backend refs at Rules[%v]", i),
+				zap.Any("BackendRefs", rule.BackendRefs),
+			)
+			continue
+		}
+
+		matches := rule.Matches
+		if len(matches) == 0 {
+			// An empty match matches all the gRPC requests.
+			matches = []gatewayv1alpha2.GRPCRouteMatch{{}}
+		}
+		// Backend filters which can't be applied are reported by
+		// the ResolvedRefs condition, see Validator.ValidateBackendFilters.
+		backendFilters, _ := CommonGRPCBackendFilters(ruleBackends)
+
+		for j, match := range matches {
+			route, err := t.translateGatewayGRPCRouteMatch(&match)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to translate Rules[%v].Matches[%v]", i, j))
+			}
+
+			name := apisixv1.ComposeRouteName(grpcRoute.Namespace, grpcRoute.Name, fmt.Sprintf("%d-%d", i, j))
+			route.ID = id.GenID(name)
+			route.Hosts = hosts
+			route.Labels["meta_namespace"] = utils.TruncateString(grpcRoute.Namespace, 64)
+			route.Labels["meta_grpcroute"] = utils.TruncateString(grpcRoute.Name, 64)
+			route.Plugins = apisixv1.Plugins{}
+			t.generatePluginsFromGRPCRouteFilter(grpcRoute.Namespace, route.Plugins, rule.Filters)
+			t.generatePluginsFromGRPCRouteFilter(grpcRoute.Namespace, route.Plugins, backendFilters)
+
+			if len(ruleUpstreams) == 1 {
+				route.UpstreamId = ruleUpstreams[0].ID
+			} else {
+				route.Plugins["traffic-split"] = &apisixv1.TrafficSplitConfig{
+					Rules: []apisixv1.TrafficSplitConfigRule{
+						{
+							WeightedUpstreams: weightedUpstreams,
+						},
+					},
+				}
+			}
+
+			ctx.AddRoute(route)
+		}
+	}
+
+	return ctx, nil
+}
+
+// CommonGRPCBackendFilters returns the filters shared by all backends of a
+// rule, see CommonHTTPBackendFilters.
+func CommonGRPCBackendFilters(backends []gatewayv1alpha2.GRPCBackendRef) ([]gatewayv1alpha2.GRPCRouteFilter, bool) {
+	if len(backends) == 0 {
+		return nil, true
+	}
+	filters := backends[0].Filters
+	for _, backend := range backends[1:] {
+		if len(backend.Filters) == 0 && len(filters) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(backend.Filters, filters) {
+			return nil, false
+		}
+	}
+	return filters, true
+}
+
+// translateGatewayGRPCRouteMatch translates the match to a route. The path of
+// a gRPC request is "/<service>/<method>", so method matches are translated to
+// URIs, or to a regex on the uri variable when they can't be expressed as one.
+func (t *translator) translateGatewayGRPCRouteMatch(match *gatewayv1alpha2.GRPCRouteMatch) (*apisixv1.Route, error) {
+	route := apisixv1.NewDefaultRoute()
+	route.Uri = "/*"
+
+	if match.Method != nil {
+		matchType := gatewayv1alpha2.GRPCMethodMatchExact
+		if match.Method.Type != nil {
+			matchType = *match.Method.Type
+		}
+		var service, method string
+		if match.Method.Service != nil {
+			service = *match.Method.Service
+		}
+		if match.Method.Method != nil {
+			method = *match.Method.Method
+		}
+
+		switch matchType {
+		case gatewayv1alpha2.GRPCMethodMatchExact:
+			switch {
+			case service != "" && method != "":
+				route.Uri = "/" + service + "/" + method
+			case service != "":
+				route.Uri = "/" + service + "/*"
+			case method != "":
+				route.Vars = append(route.Vars, uriRegexVar("^/[^/]+/"+regexp.QuoteMeta(method)+"$"))
+			}
+		case gatewayv1alpha2.GRPCMethodMatchRegularExpression:
+			if service == "" {
+				service = "[^/]+"
+			}
+			if method == "" {
+				method = "[^/]+"
+			}
+			route.Vars = append(route.Vars, uriRegexVar("^/(?:"+service+")/(?:"+method+")$"))
+		default:
+			return nil, errors.New("unknown method match type " + string(matchType))
+		}
+	}
+
+	for _, header := range match.Headers {
+		name := strings.ToLower(string(header.Name))
+		name = strings.ReplaceAll(name, "-", "_")
+
+		var this []apisixv1.StringOrSlice
+		this = append(this, apisixv1.StringOrSlice{
+			StrVal: "http_" + name,
+		})
+
+		matchType := gatewayv1beta1.HeaderMatchExact
+		if header.Type != nil {
+			matchType = *header.Type
+		}
+		switch matchType {
+		case gatewayv1beta1.HeaderMatchExact:
+			this = append(this, apisixv1.StringOrSlice{
+				StrVal: "==",
+			})
+		case gatewayv1beta1.HeaderMatchRegularExpression:
+			this = append(this, apisixv1.StringOrSlice{
+				StrVal: "~~",
+			})
+		default:
+			return nil, errors.New("unknown header match type " + string(matchType))
+		}
+
+		this = append(this, apisixv1.StringOrSlice{
+			StrVal: header.Value,
+		})
+
+		route.Vars = append(route.Vars, this)
+	}
+
+	return route, nil
+}
+
+func uriRegexVar(regex string) []apisixv1.StringOrSlice {
+	return []apisixv1.StringOrSlice{
+		{StrVal: "uri"},
+		{StrVal: "~~"},
+		{StrVal: regex},
+	}
+}
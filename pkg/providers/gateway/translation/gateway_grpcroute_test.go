@@ -0,0 +1,250 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	configv2 "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/apis/config/v2"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/utils"
+	"github.com/apache/apisix-ingress-controller/pkg/types"
+	v1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+func TestTranslateGatewayGRPCRoute(t *testing.T) {
+	tr, processCh := mockHTTPRouteTranslator(t)
+	<-processCh
+	<-processCh
+
+	grpcRoute := &gatewayv1alpha2.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "grpc_route",
+			Namespace: "test",
+		},
+		Spec: gatewayv1alpha2.GRPCRouteSpec{
+			Hostnames: []gatewayv1alpha2.Hostname{
+				"example.com",
+			},
+			Rules: []gatewayv1alpha2.GRPCRouteRule{
+				{
+					Matches: []gatewayv1alpha2.GRPCRouteMatch{
+						{
+							Method: &gatewayv1alpha2.GRPCMethodMatch{
+								Service: utils.PtrOf("helloworld.Greeter"),
+								Method:  utils.PtrOf("SayHello"),
+							},
+							Headers: []gatewayv1alpha2.GRPCHeaderMatch{
+								{
+									Name:  "X-Version",
+									Value: "v1",
+								},
+							},
+						},
+						{
+							Method: &gatewayv1alpha2.GRPCMethodMatch{
+								Service: utils.PtrOf("helloworld.Greeter"),
+							},
+						},
+						{
+							Method: &gatewayv1alpha2.GRPCMethodMatch{
+								Type:   utils.PtrOf(gatewayv1alpha2.GRPCMethodMatchRegularExpression),
+								Method: utils.PtrOf("Say.*"),
+							},
+						},
+					},
+					Filters: []gatewayv1alpha2.GRPCRouteFilter{
+						{
+							Type: gatewayv1alpha2.GRPCRouteFilterRequestHeaderModifier,
+							RequestHeaderModifier: &gatewayv1beta1.HTTPHeaderFilter{
+								Set: []gatewayv1beta1.HTTPHeader{{Name: "X-Gateway", Value: "apisix"}},
+							},
+						},
+					},
+					BackendRefs: []gatewayv1alpha2.GRPCBackendRef{
+						{
+							BackendRef: gatewayv1alpha2.BackendRef{
+								BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+									Kind:      refKind("Service"),
+									Name:      "svc",
+									Namespace: refNamespace("test"),
+									Port:      refPortNumber(80),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tctx, err := tr.TranslateGatewayGRPCRouteV1Alpha2(grpcRoute, nil)
+	assert.Nil(t, err)
+
+	assert.Len(t, tctx.Routes, 3)
+	assert.Len(t, tctx.Upstreams, 1)
+
+	u := tctx.Upstreams[0]
+	// The upstream is shared with the HTTPRoutes to the same Service port.
+	assert.Equal(t, v1.SchemeHTTP, u.Scheme)
+	// The endpoint controller keeps the nodes of the standard upstream name up to date.
+	assert.Equal(t, v1.ComposeUpstreamName("test", "svc", "", 80, types.ResolveGranularity.Endpoint), u.Name)
+	assert.Equal(t, 2, len(u.Nodes))
+
+	r := tctx.Routes[0]
+	assert.Equal(t, u.ID, r.UpstreamId)
+	assert.Equal(t, []string{"example.com"}, r.Hosts)
+	assert.Equal(t, "/helloworld.Greeter/SayHello", r.Uri)
+	assert.Equal(t, v1.Vars{{{StrVal: "http_x_version"}, {StrVal: "=="}, {StrVal: "v1"}}}, r.Vars)
+	assert.Equal(t, &v1.RewriteConfig{Headers: v1.Headers{"X-Gateway": "apisix"}}, r.Plugins["proxy-rewrite"])
+
+	r = tctx.Routes[1]
+	assert.Equal(t, "/helloworld.Greeter/*", r.Uri)
+	assert.Len(t, r.Vars, 0)
+
+	r = tctx.Routes[2]
+	assert.Equal(t, "/*", r.Uri)
+	assert.Equal(t, v1.Vars{{{StrVal: "uri"}, {StrVal: "~~"}, {StrVal: "^/(?:[^/]+)/(?:Say.*)$"}}}, r.Vars)
+}
+
+func TestTranslateGatewayGRPCRouteMultipleBackendRefs(t *testing.T) {
+	tr, processCh := mockHTTPRouteTranslator(t)
+	<-processCh
+	<-processCh
+
+	grpcRoute := &gatewayv1alpha2.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "grpc_route",
+			Namespace: "test",
+		},
+		Spec: gatewayv1alpha2.GRPCRouteSpec{
+			Rules: []gatewayv1alpha2.GRPCRouteRule{
+				{
+					BackendRefs: []gatewayv1alpha2.GRPCBackendRef{
+						{
+							BackendRef: gatewayv1alpha2.BackendRef{
+								BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+									Name: "svc",
+									Port: refPortNumber(80),
+								},
+								Weight: refInt32(10),
+							},
+						},
+						{
+							BackendRef: gatewayv1alpha2.BackendRef{
+								BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+									Name: "svc2",
+									Port: refPortNumber(81),
+								},
+								Weight: refInt32(20),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tctx, err := tr.TranslateGatewayGRPCRouteV1Alpha2(grpcRoute, nil)
+	assert.Nil(t, err)
+	assert.Len(t, tctx.Routes, 1)
+	assert.Len(t, tctx.Upstreams, 2)
+
+	r := tctx.Routes[0]
+	assert.Equal(t, "/*", r.Uri)
+	assert.Equal(t, "", r.UpstreamId)
+	ts := r.Plugins["traffic-split"].(*v1.TrafficSplitConfig)
+	assert.Equal(t, []v1.TrafficSplitConfigRuleWeightedUpstream{
+		{UpstreamID: tctx.Upstreams[0].ID, Weight: 10},
+		{UpstreamID: tctx.Upstreams[1].ID, Weight: 20},
+	}, ts.Rules[0].WeightedUpstreams)
+}
+
+func TestTranslateGatewayGRPCRouteApisixUpstreamScheme(t *testing.T) {
+	tr, processCh := mockHTTPRouteTranslator(t, &configv2.ApisixUpstream{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "svc",
+			Namespace: "test",
+		},
+		Spec: &configv2.ApisixUpstreamSpec{
+			ApisixUpstreamConfig: configv2.ApisixUpstreamConfig{
+				Scheme: v1.SchemeGRPC,
+			},
+		},
+	})
+	<-processCh
+	<-processCh
+
+	grpcRoute := &gatewayv1alpha2.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "grpc_route",
+			Namespace: "test",
+		},
+		Spec: gatewayv1alpha2.GRPCRouteSpec{
+			Rules: []gatewayv1alpha2.GRPCRouteRule{
+				{
+					BackendRefs: []gatewayv1alpha2.GRPCBackendRef{
+						{
+							BackendRef: gatewayv1alpha2.BackendRef{
+								BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+									Name: "svc",
+									Port: refPortNumber(80),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	httpRoute := &gatewayv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "http_route",
+			Namespace: "test",
+		},
+		Spec: gatewayv1beta1.HTTPRouteSpec{
+			Rules: []gatewayv1beta1.HTTPRouteRule{
+				{
+					BackendRefs: []gatewayv1beta1.HTTPBackendRef{
+						{
+							BackendRef: gatewayv1beta1.BackendRef{
+								BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+									Name: "svc",
+									Port: refPortNumber(80),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	grpcCtx, err := tr.TranslateGatewayGRPCRouteV1Alpha2(grpcRoute, nil)
+	assert.Nil(t, err)
+	httpCtx, err := tr.TranslateGatewayHTTPRouteV1beta1(httpRoute, nil)
+	assert.Nil(t, err)
+
+	// Both routes translate the same upstream of the Service port.
+	assert.Len(t, grpcCtx.Upstreams, 1)
+	assert.Len(t, httpCtx.Upstreams, 1)
+	assert.Equal(t, v1.SchemeGRPC, grpcCtx.Upstreams[0].Scheme)
+	assert.Equal(t, httpCtx.Upstreams[0], grpcCtx.Upstreams[0])
+}
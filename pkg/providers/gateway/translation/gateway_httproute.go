@@ -18,6 +18,8 @@ package translation
 
 import (
 	"fmt"
+	"reflect"
+	"regexp"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -26,14 +28,14 @@ import (
 
 	"github.com/apache/apisix-ingress-controller/pkg/id"
 	"github.com/apache/apisix-ingress-controller/pkg/log"
+	gatewaytypes "github.com/apache/apisix-ingress-controller/pkg/providers/gateway/types"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/translation"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/utils"
 	"github.com/apache/apisix-ingress-controller/pkg/types"
 	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
 )
 
-func (t *translator) generatePluginsFromHTTPRouteFilter(namespace string, filters []gatewayv1beta1.HTTPRouteFilter) apisixv1.Plugins {
-	plugins := apisixv1.Plugins{}
+func (t *translator) generatePluginsFromHTTPRouteFilter(namespace string, plugins apisixv1.Plugins, filters []gatewayv1beta1.HTTPRouteFilter, match *gatewayv1beta1.HTTPRouteMatch) {
 	for _, filter := range filters {
 		switch filter.Type {
 		case gatewayv1beta1.HTTPRouteFilterRequestHeaderModifier:
@@ -41,38 +43,124 @@ func (t *translator) generatePluginsFromHTTPRouteFilter(namespace string, filter
 		case gatewayv1beta1.HTTPRouteFilterRequestRedirect:
 			t.generatePluginFromHTTPRequestRedirectFilter(plugins, filter.RequestRedirect)
 		case gatewayv1beta1.HTTPRouteFilterRequestMirror:
-			t.generatePluginFromHTTPRequestMirrorFilter(namespace, plugins, filter.RequestMirror)
+			t.generatePluginFromRequestMirrorFilter(gatewaytypes.KindHTTPRoute, namespace, "http", plugins, filter.RequestMirror)
 		case gatewayv1beta1.HTTPRouteFilterURLRewrite:
-			// TODO: Supported, to be implemented
+			t.generatePluginFromHTTPURLRewriteFilter(plugins, filter.URLRewrite, match)
 		case gatewayv1beta1.HTTPRouteFilterResponseHeaderModifier:
-			// TODO: Supported, to be implemented
+			t.generatePluginFromHTTPResponseHeaderFilter(plugins, filter.ResponseHeaderModifier)
 		}
 	}
-	return plugins
+}
+
+// proxyRewriteConfig returns the proxy-rewrite config in plugins, creating it
+// if absent, so that header modifiers and URL rewrites share one plugin.
+func proxyRewriteConfig(plugins apisixv1.Plugins) *apisixv1.RewriteConfig {
+	if cfg, ok := plugins["proxy-rewrite"].(*apisixv1.RewriteConfig); ok {
+		return cfg
+	}
+	cfg := &apisixv1.RewriteConfig{}
+	plugins["proxy-rewrite"] = cfg
+	return cfg
 }
 
 func (t *translator) generatePluginFromHTTPRequestHeaderFilter(plugins apisixv1.Plugins, reqHeaderModifier *gatewayv1beta1.HTTPHeaderFilter) {
 	if reqHeaderModifier == nil {
 		return
 	}
-	headers := map[string]any{}
+	cfg := proxyRewriteConfig(plugins)
+	if cfg.Headers == nil {
+		cfg.Headers = apisixv1.Headers{}
+	}
 	// TODO: The current apisix plugin does not conform to the specification.
 	for _, header := range reqHeaderModifier.Add {
-		headers[string(header.Name)] = header.Value
+		cfg.Headers[string(header.Name)] = header.Value
 	}
 	for _, header := range reqHeaderModifier.Set {
-		headers[string(header.Name)] = header.Value
+		cfg.Headers[string(header.Name)] = header.Value
 	}
 	for _, header := range reqHeaderModifier.Remove {
-		headers[header] = ""
+		cfg.Headers[header] = ""
+	}
+}
+
+func (t *translator) generatePluginFromHTTPURLRewriteFilter(plugins apisixv1.Plugins, urlRewrite *gatewayv1beta1.HTTPURLRewriteFilter, match *gatewayv1beta1.HTTPRouteMatch) {
+	if urlRewrite == nil {
+		return
+	}
+	cfg := proxyRewriteConfig(plugins)
+	if urlRewrite.Hostname != nil {
+		cfg.Host = string(*urlRewrite.Hostname)
+	}
+	if urlRewrite.Path == nil {
+		return
+	}
+	switch urlRewrite.Path.Type {
+	case gatewayv1beta1.FullPathHTTPPathModifier:
+		if urlRewrite.Path.ReplaceFullPath != nil {
+			cfg.RewriteTarget = *urlRewrite.Path.ReplaceFullPath
+		}
+	case gatewayv1beta1.PrefixMatchHTTPPathModifier:
+		if urlRewrite.Path.ReplacePrefixMatch == nil {
+			return
+		}
+		// ReplacePrefixMatch is only compatible with a PathPrefix match.
+		if match == nil || match.Path == nil || match.Path.Type == nil || match.Path.Value == nil ||
+			*match.Path.Type != gatewayv1beta1.PathMatchPathPrefix {
+			log.Warnw("ignore ReplacePrefixMatch rewrite on a non-PathPrefix match",
+				zap.Any("match", match),
+			)
+			return
+		}
+		cfg.RewriteTargetRegex = replacePrefixMatchRegex(*match.Path.Value, *urlRewrite.Path.ReplacePrefixMatch)
+	}
+}
+
+// replacePrefixMatchRegex builds the proxy-rewrite regex_uri which replaces
+// the matched path prefix with replacement, keeping the rest of the path.
+// The prefix only matches whole path segments, so "/foo" doesn't rewrite
+// "/foobar", which the "/foo*" route URI matches as well.
+func replacePrefixMatchRegex(prefix, replacement string) []string {
+	prefix = strings.TrimSuffix(prefix, "/")
+	replacement = strings.TrimSuffix(replacement, "/")
+	if replacement == "" {
+		return []string{"^" + regexp.QuoteMeta(prefix) + "(?:/(.*))?$", "/$1"}
+	}
+	return []string{"^" + regexp.QuoteMeta(prefix) + "(/.*)?$", replacement + "$1"}
+}
+
+func (t *translator) generatePluginFromHTTPResponseHeaderFilter(plugins apisixv1.Plugins, respHeaderModifier *gatewayv1beta1.HTTPHeaderFilter) {
+	if respHeaderModifier == nil {
+		return
+	}
+	headers := apisixv1.Headers{}
+	if len(respHeaderModifier.Add) > 0 {
+		add := make([]string, 0, len(respHeaderModifier.Add))
+		for _, header := range respHeaderModifier.Add {
+			add = append(add, fmt.Sprintf("%s: %s", header.Name, header.Value))
+		}
+		headers["add"] = add
+	}
+	if len(respHeaderModifier.Set) > 0 {
+		set := make(map[string]string, len(respHeaderModifier.Set))
+		for _, header := range respHeaderModifier.Set {
+			set[string(header.Name)] = header.Value
+		}
+		headers["set"] = set
+	}
+	if len(respHeaderModifier.Remove) > 0 {
+		headers["remove"] = respHeaderModifier.Remove
 	}
 
-	plugins["proxy-rewrite"] = apisixv1.RewriteConfig{
+	plugins["response-rewrite"] = &apisixv1.ResponseRewriteConfig{
 		Headers: headers,
 	}
 }
 
-func (t *translator) generatePluginFromHTTPRequestMirrorFilter(namespace string, plugins apisixv1.Plugins, reqMirror *gatewayv1beta1.HTTPRequestMirrorFilter) {
+// generatePluginFromRequestMirrorFilter generates the proxy-mirror plugin of a
+// RequestMirror filter of a route of routeKind, scheme is the protocol used to
+// talk to the mirror backend.
+func (t *translator) generatePluginFromRequestMirrorFilter(routeKind gatewayv1beta1.Kind, namespace, scheme string, plugins apisixv1.Plugins,
+	reqMirror *gatewayv1beta1.HTTPRequestMirrorFilter) {
 	if reqMirror == nil {
 		return
 	}
@@ -87,9 +175,15 @@ func (t *translator) generatePluginFromHTTPRequestMirrorFilter(namespace string,
 	if reqMirror.BackendRef.Namespace != nil {
 		ns = string(*reqMirror.BackendRef.Namespace)
 	}
+	if !IsBackendRefPermitted(t.ReferenceGrantLister, routeKind, namespace, reqMirror.BackendRef) {
+		log.Warnw("ignore not permitted cross-namespace reference of RequestMirror filter",
+			zap.String("namespace", ns),
+		)
+		return
+	}
 	// TODO 1: Need to support https.
 	// TODO 2: https://github.com/apache/apisix/issues/8351 APISIX 3.0 support {service.namespace} and {service.namespace.svc}, but APISIX <= 2.15 version is not supported.
-	host := fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", reqMirror.BackendRef.Name, ns, port)
+	host := fmt.Sprintf("%s://%s.%s.svc.cluster.local:%d", scheme, reqMirror.BackendRef.Name, ns, port)
 
 	plugins["proxy-mirror"] = apisixv1.RequestMirror{
 		Host: host,
@@ -130,20 +224,14 @@ func (t *translator) generatePluginFromHTTPRequestRedirectFilter(plugins apisixv
 	}
 }
 
-func (t *translator) TranslateGatewayHTTPRouteV1beta1(httpRoute *gatewayv1beta1.HTTPRoute) (*translation.TranslateContext, error) {
+func (t *translator) TranslateGatewayHTTPRouteV1beta1(httpRoute *gatewayv1beta1.HTTPRoute, listeners []*gatewaytypes.ListenerConf) (*translation.TranslateContext, error) {
 	ctx := translation.DefaultEmptyTranslateContext()
 
-	var hosts []string
-	for _, hostname := range httpRoute.Spec.Hostnames {
-		hosts = append(hosts, string(hostname))
-
-		// TODO: See the document of gatewayv1beta1.Listener.Hostname
-		_ = gatewayv1beta1.Listener{}.Hostname
-		// For HTTPRoute and TLSRoute resources, there is an interaction with the
-		// `spec.hostnames` array. When both listener and route specify hostnames,
-		// there MUST be an intersection between the values for a Route to be
-		// accepted. For more information, refer to the Route specific Hostnames
-		// documentation.
+	// When both listener and route specify hostnames, there MUST be an
+	// intersection between the values for a Route to be accepted.
+	hosts, ok := gatewaytypes.IntersectListenersHostnames(listeners, httpRoute.Spec.Hostnames)
+	if !ok {
+		return nil, errors.New("no hostname of the HTTPRoute intersects with the listeners")
 	}
 
 	rules := httpRoute.Spec.Rules
@@ -156,10 +244,9 @@ func (t *translator) TranslateGatewayHTTPRouteV1beta1(httpRoute *gatewayv1beta1.
 
 		var ruleUpstreams []*apisixv1.Upstream
 		var weightedUpstreams []apisixv1.TrafficSplitConfigRuleWeightedUpstream
+		var ruleBackends []gatewayv1beta1.HTTPBackendRef
 
 		for j, backend := range backends {
-			// TODO: Support filters
-			// filters := backend.Filters
 			var kind string
 			if backend.Kind == nil {
 				kind = "service"
@@ -179,9 +266,12 @@ func (t *translator) TranslateGatewayHTTPRouteV1beta1(httpRoute *gatewayv1beta1.
 			} else {
 				ns = string(*backend.Namespace)
 			}
-			//if ns != httpRoute.Namespace {
-			// TODO: check gatewayv1beta1.ReferencePolicy
-			//}
+			if !IsBackendRefPermitted(t.ReferenceGrantLister, gatewaytypes.KindHTTPRoute, httpRoute.Namespace, backend.BackendObjectReference) {
+				log.Warnw(fmt.Sprintf("ignore not permitted cross-namespace reference at Rules[%v].BackendRefs[%v]", i, j),
+					zap.String("namespace", ns),
+				)
+				continue
+			}
 
 			if backend.Port == nil {
 				log.Warnw(fmt.Sprintf("ignore nil port at Rules[%v].BackendRefs[%v]", i, j),
@@ -210,6 +300,7 @@ func (t *translator) TranslateGatewayHTTPRouteV1beta1(httpRoute *gatewayv1beta1.
 			)
 			ctx.AddUpstream(ups)
 			ruleUpstreams = append(ruleUpstreams, ups)
+			ruleBackends = append(ruleBackends, backend)
 
 			if backend.Weight == nil {
 				weightedUpstreams = append(weightedUpstreams, apisixv1.TrafficSplitConfigRuleWeightedUpstream{
@@ -243,7 +334,9 @@ func (t *translator) TranslateGatewayHTTPRouteV1beta1(httpRoute *gatewayv1beta1.
 				},
 			}
 		}
-		plugins := t.generatePluginsFromHTTPRouteFilter(httpRoute.Namespace, rule.Filters)
+		// Backend filters which can't be applied are reported by
+		// the ResolvedRefs condition, see Validator.ValidateBackendFilters.
+		backendFilters, _ := CommonHTTPBackendFilters(ruleBackends)
 
 		for j, match := range matches {
 			route, err := t.translateGatewayHTTPRouteMatch(&match)
@@ -254,7 +347,11 @@ func (t *translator) TranslateGatewayHTTPRouteV1beta1(httpRoute *gatewayv1beta1.
 			name := apisixv1.ComposeRouteName(httpRoute.Namespace, httpRoute.Name, fmt.Sprintf("%d-%d", i, j))
 			route.ID = id.GenID(name)
 			route.Hosts = hosts
-			route.Plugins = plugins
+			route.Plugins = apisixv1.Plugins{}
+			t.generatePluginsFromHTTPRouteFilter(httpRoute.Namespace, route.Plugins, rule.Filters, &match)
+			// Backend filters are applied after the rule filters, so that they
+			// take precedence for the traffic sent to these backends.
+			t.generatePluginsFromHTTPRouteFilter(httpRoute.Namespace, route.Plugins, backendFilters, &match)
 
 			// Bind Upstream
 			if len(ruleUpstreams) == 1 {
@@ -271,14 +368,31 @@ func (t *translator) TranslateGatewayHTTPRouteV1beta1(httpRoute *gatewayv1beta1.
 
 			ctx.AddRoute(route)
 		}
-
-		// TODO: Support filters
-		// filters := rule.Filters
 	}
 
 	return ctx, nil
 }
 
+// CommonHTTPBackendFilters returns the filters shared by all backends of a rule.
+// APISIX traffic-split only selects an upstream and can't run plugins per
+// weighted upstream, so backend filters are applied on the route when every
+// backend carries the same filters. It returns false if the backends disagree.
+func CommonHTTPBackendFilters(backends []gatewayv1beta1.HTTPBackendRef) ([]gatewayv1beta1.HTTPRouteFilter, bool) {
+	if len(backends) == 0 {
+		return nil, true
+	}
+	filters := backends[0].Filters
+	for _, backend := range backends[1:] {
+		if len(backend.Filters) == 0 && len(filters) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(backend.Filters, filters) {
+			return nil, false
+		}
+	}
+	return filters, true
+}
+
 func (t *translator) translateGatewayHTTPRouteMatch(match *gatewayv1beta1.HTTPRouteMatch) (*apisixv1.Route, error) {
 	route := apisixv1.NewDefaultRoute()
 
@@ -17,6 +17,7 @@ package translation
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -31,8 +32,10 @@ import (
 
 	"github.com/apache/apisix-ingress-controller/pkg/config"
 	"github.com/apache/apisix-ingress-controller/pkg/kube"
+	configv2 "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/apis/config/v2"
 	fakeapisix "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/client/clientset/versioned/fake"
 	apisixinformers "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/client/informers/externalversions"
+	gatewaytypes "github.com/apache/apisix-ingress-controller/pkg/providers/gateway/types"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/translation"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/utils"
 	v1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
@@ -107,7 +110,7 @@ func newServiceAndEndpoints(t *testing.T, client kubernetes.Interface, ns, svcNa
 	return svc, endpoints
 }
 
-func mockHTTPRouteTranslator(t *testing.T) (*translator, <-chan struct{}) {
+func mockHTTPRouteTranslator(t *testing.T, aus ...*configv2.ApisixUpstream) (*translator, <-chan struct{}) {
 
 	client := fake.NewSimpleClientset()
 	informersFactory := informers.NewSharedInformerFactory(client, 0)
@@ -116,6 +119,11 @@ func mockHTTPRouteTranslator(t *testing.T) (*translator, <-chan struct{}) {
 	epLister, epInformer := kube.NewEndpointListerAndInformer(informersFactory, false)
 	apisixClient := fakeapisix.NewSimpleClientset()
 	apisixInformersFactory := apisixinformers.NewSharedInformerFactory(apisixClient, 0)
+	auInformer := apisixInformersFactory.Apisix().V2().ApisixUpstreams().Informer()
+	for _, au := range aus {
+		_, err := apisixClient.ApisixV2().ApisixUpstreams(au.Namespace).Create(context.Background(), au, metav1.CreateOptions{})
+		assert.Nil(t, err)
+	}
 
 	newServiceAndEndpoints(t, client, "test", "svc", []int32{80, 443}, []int32{9080, 9443}, []string{"192.168.1.1", "192.168.1.2"})
 	newServiceAndEndpoints(t, client, "test", "svc2", []int32{81, 444}, []int32{9081, 9444}, []string{"192.168.1.3", "192.168.1.4"})
@@ -128,7 +136,8 @@ func mockHTTPRouteTranslator(t *testing.T) (*translator, <-chan struct{}) {
 				ApisixUpstreamLister: kube.NewApisixUpstreamLister(
 					apisixInformersFactory.Apisix().V2().ApisixUpstreams().Lister(),
 				),
-				APIVersion: config.DefaultAPIVersion,
+				APIVersion:       config.DefaultAPIVersion,
+				IngressClassName: config.IngressClassApisixAndAll,
 			}),
 		},
 	}
@@ -149,7 +158,8 @@ func mockHTTPRouteTranslator(t *testing.T) (*translator, <-chan struct{}) {
 	defer close(stopCh)
 	go svcInformer.Run(stopCh)
 	go epInformer.Run(stopCh)
-	cache.WaitForCacheSync(stopCh, svcInformer.HasSynced)
+	go auInformer.Run(stopCh)
+	cache.WaitForCacheSync(stopCh, svcInformer.HasSynced, auInformer.HasSynced)
 
 	return tr, processCh
 }
@@ -222,7 +232,7 @@ func TestTranslateGatewayHTTPRouteExactMatch(t *testing.T) {
 		},
 	}
 
-	tctx, err := tr.TranslateGatewayHTTPRouteV1beta1(httpRoute)
+	tctx, err := tr.TranslateGatewayHTTPRouteV1beta1(httpRoute, nil)
 	assert.Nil(t, err)
 
 	assert.Equal(t, 1, len(tctx.Routes))
@@ -331,7 +341,7 @@ func TestTranslateGatewayHTTPRouteRegexMatch(t *testing.T) {
 		},
 	}
 
-	tctx, err := tr.TranslateGatewayHTTPRouteV1beta1(httpRoute)
+	tctx, err := tr.TranslateGatewayHTTPRouteV1beta1(httpRoute, nil)
 	assert.Nil(t, err)
 
 	assert.Equal(t, 1, len(tctx.Routes))
@@ -451,7 +461,7 @@ func TestTranslateGatewayHTTPRouteMultipleBackendRefs(t *testing.T) {
 		},
 	}
 
-	tctx, err := tr.TranslateGatewayHTTPRouteV1beta1(httpRoute)
+	tctx, err := tr.TranslateGatewayHTTPRouteV1beta1(httpRoute, nil)
 	assert.Nil(t, err)
 
 	assert.Equal(t, 2, len(tctx.Routes))
@@ -505,3 +515,238 @@ func TestTranslateGatewayHTTPRouteMultipleBackendRefs(t *testing.T) {
 	assert.Equal(t, "192.168.1.4", u.Nodes[1].Host)
 	assert.Equal(t, 9081, u.Nodes[1].Port)
 }
+
+func TestTranslateGatewayHTTPRouteFilters(t *testing.T) {
+	tr, processCh := mockHTTPRouteTranslator(t)
+	<-processCh
+	<-processCh
+
+	httpRoute := &gatewayv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "http_route",
+			Namespace: "test",
+		},
+		Spec: gatewayv1beta1.HTTPRouteSpec{
+			Rules: []gatewayv1beta1.HTTPRouteRule{
+				{
+					Matches: []gatewayv1beta1.HTTPRouteMatch{
+						{
+							Path: &gatewayv1beta1.HTTPPathMatch{
+								Type:  utils.PtrOf(gatewayv1beta1.PathMatchPathPrefix),
+								Value: utils.PtrOf("/foo/"),
+							},
+						},
+						{
+							Path: &gatewayv1beta1.HTTPPathMatch{
+								Type:  utils.PtrOf(gatewayv1beta1.PathMatchExact),
+								Value: utils.PtrOf("/bar"),
+							},
+						},
+					},
+					Filters: []gatewayv1beta1.HTTPRouteFilter{
+						{
+							Type: gatewayv1beta1.HTTPRouteFilterRequestHeaderModifier,
+							RequestHeaderModifier: &gatewayv1beta1.HTTPHeaderFilter{
+								Set: []gatewayv1beta1.HTTPHeader{{Name: "X-Req", Value: "v"}},
+							},
+						},
+						{
+							Type: gatewayv1beta1.HTTPRouteFilterURLRewrite,
+							URLRewrite: &gatewayv1beta1.HTTPURLRewriteFilter{
+								Hostname: utils.PtrOf(gatewayv1beta1.PreciseHostname("internal.example.com")),
+								Path: &gatewayv1beta1.HTTPPathModifier{
+									Type:               gatewayv1beta1.PrefixMatchHTTPPathModifier,
+									ReplacePrefixMatch: utils.PtrOf("/baz"),
+								},
+							},
+						},
+					},
+					BackendRefs: []gatewayv1beta1.HTTPBackendRef{
+						{
+							BackendRef: gatewayv1beta1.BackendRef{
+								BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+									Name: "svc",
+									Port: refPortNumber(80),
+								},
+							},
+							Filters: []gatewayv1beta1.HTTPRouteFilter{
+								{
+									Type: gatewayv1beta1.HTTPRouteFilterResponseHeaderModifier,
+									ResponseHeaderModifier: &gatewayv1beta1.HTTPHeaderFilter{
+										Add:    []gatewayv1beta1.HTTPHeader{{Name: "X-Add", Value: "a"}},
+										Set:    []gatewayv1beta1.HTTPHeader{{Name: "X-Set", Value: "s"}},
+										Remove: []string{"X-Remove"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tctx, err := tr.TranslateGatewayHTTPRouteV1beta1(httpRoute, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(tctx.Routes))
+
+	// === route 1: prefix match ===
+	r := tctx.Routes[0]
+	rewrite, ok := r.Plugins["proxy-rewrite"].(*v1.RewriteConfig)
+	assert.True(t, ok)
+	assert.Equal(t, "internal.example.com", rewrite.Host)
+	assert.Equal(t, []string{"^/foo(/.*)?$", "/baz$1"}, rewrite.RewriteTargetRegex)
+	assert.Equal(t, "v", rewrite.Headers["X-Req"])
+
+	respRewrite, ok := r.Plugins["response-rewrite"].(*v1.ResponseRewriteConfig)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"X-Add: a"}, respRewrite.Headers["add"])
+	assert.Equal(t, map[string]string{"X-Set": "s"}, respRewrite.Headers["set"])
+	assert.Equal(t, []string{"X-Remove"}, respRewrite.Headers["remove"])
+
+	// === route 2: ReplacePrefixMatch is ignored on an exact match ===
+	r = tctx.Routes[1]
+	rewrite, ok = r.Plugins["proxy-rewrite"].(*v1.RewriteConfig)
+	assert.True(t, ok)
+	assert.Equal(t, "internal.example.com", rewrite.Host)
+	assert.Nil(t, rewrite.RewriteTargetRegex)
+	assert.Contains(t, r.Plugins, "response-rewrite")
+}
+
+func TestTranslateGatewayHTTPRouteTrafficSplitBackendFilters(t *testing.T) {
+	tr, processCh := mockHTTPRouteTranslator(t)
+	<-processCh
+	<-processCh
+
+	fullPathRewrite := []gatewayv1beta1.HTTPRouteFilter{
+		{
+			Type: gatewayv1beta1.HTTPRouteFilterURLRewrite,
+			URLRewrite: &gatewayv1beta1.HTTPURLRewriteFilter{
+				Path: &gatewayv1beta1.HTTPPathModifier{
+					Type:            gatewayv1beta1.FullPathHTTPPathModifier,
+					ReplaceFullPath: utils.PtrOf("/full"),
+				},
+			},
+		},
+	}
+	newRoute := func(filters1, filters2 []gatewayv1beta1.HTTPRouteFilter) *gatewayv1beta1.HTTPRoute {
+		return &gatewayv1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "http_route",
+				Namespace: "test",
+			},
+			Spec: gatewayv1beta1.HTTPRouteSpec{
+				Rules: []gatewayv1beta1.HTTPRouteRule{
+					{
+						BackendRefs: []gatewayv1beta1.HTTPBackendRef{
+							{
+								BackendRef: gatewayv1beta1.BackendRef{
+									BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+										Name: "svc",
+										Port: refPortNumber(80),
+									},
+								},
+								Filters: filters1,
+							},
+							{
+								BackendRef: gatewayv1beta1.BackendRef{
+									BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+										Name: "svc2",
+										Port: refPortNumber(81),
+									},
+								},
+								Filters: filters2,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	// Backends sharing the same filters have them applied on the route.
+	tctx, err := tr.TranslateGatewayHTTPRouteV1beta1(newRoute(fullPathRewrite, fullPathRewrite), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(tctx.Routes))
+	r := tctx.Routes[0]
+	assert.Contains(t, r.Plugins, "traffic-split")
+	rewrite, ok := r.Plugins["proxy-rewrite"].(*v1.RewriteConfig)
+	assert.True(t, ok)
+	assert.Equal(t, "/full", rewrite.RewriteTarget)
+
+	// Backends with different filters can't be expressed by traffic-split.
+	tctx, err = tr.TranslateGatewayHTTPRouteV1beta1(newRoute(fullPathRewrite, nil), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(tctx.Routes))
+	r = tctx.Routes[0]
+	assert.Contains(t, r.Plugins, "traffic-split")
+	assert.NotContains(t, r.Plugins, "proxy-rewrite")
+}
+
+func TestTranslateGatewayHTTPRouteHostnameIntersection(t *testing.T) {
+	tr, processCh := mockHTTPRouteTranslator(t)
+	<-processCh
+	<-processCh
+
+	newRoute := func(hostnames ...gatewayv1beta1.Hostname) *gatewayv1beta1.HTTPRoute {
+		return &gatewayv1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "http_route",
+				Namespace: "test",
+			},
+			Spec: gatewayv1beta1.HTTPRouteSpec{
+				Hostnames: hostnames,
+				Rules: []gatewayv1beta1.HTTPRouteRule{
+					{
+						BackendRefs: []gatewayv1beta1.HTTPBackendRef{
+							{
+								BackendRef: gatewayv1beta1.BackendRef{
+									BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+										Name: "svc",
+										Port: refPortNumber(80),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	wildcard := []*gatewaytypes.ListenerConf{{Hostname: utils.PtrOf(gatewayv1beta1.Hostname("*.example.com"))}}
+
+	// A route without hostnames only matches the hostname of the listener.
+	tctx, err := tr.TranslateGatewayHTTPRouteV1beta1(newRoute(), wildcard)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"*.example.com"}, tctx.Routes[0].Hosts)
+
+	// Only the hostnames intersecting with the listener are effective.
+	tctx, err = tr.TranslateGatewayHTTPRouteV1beta1(newRoute("foo.example.com", "foo.apache.org", "*.bar.example.com"), wildcard)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"foo.example.com", "*.bar.example.com"}, tctx.Routes[0].Hosts)
+
+	// A listener without hostname doesn't restrict the route.
+	listeners := append(wildcard, &gatewaytypes.ListenerConf{})
+	tctx, err = tr.TranslateGatewayHTTPRouteV1beta1(newRoute(), listeners)
+	assert.Nil(t, err)
+	assert.Nil(t, tctx.Routes[0].Hosts)
+
+	_, err = tr.TranslateGatewayHTTPRouteV1beta1(newRoute("foo.apache.org"), wildcard)
+	assert.NotNil(t, err)
+}
+
+func TestReplacePrefixMatchRegex(t *testing.T) {
+	rewrite := func(regex []string, uri string) string {
+		return regexp.MustCompile(regex[0]).ReplaceAllString(uri, regexp.MustCompile(`\$(\d)`).ReplaceAllString(regex[1], "$${$1}"))
+	}
+
+	regex := replacePrefixMatchRegex("/foo", "/baz")
+	assert.Equal(t, "/baz", rewrite(regex, "/foo"))
+	assert.Equal(t, "/baz/bar", rewrite(regex, "/foo/bar"))
+	assert.Equal(t, "/foobar", rewrite(regex, "/foobar"))
+
+	regex = replacePrefixMatchRegex("/foo/", "/")
+	assert.Equal(t, "/", rewrite(regex, "/foo"))
+	assert.Equal(t, "/bar", rewrite(regex, "/foo/bar"))
+	assert.Equal(t, "/foobar", rewrite(regex, "/foobar"))
+}
@@ -0,0 +1,112 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listerscorev1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewaylistersv1beta1 "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1beta1"
+
+	"github.com/apache/apisix-ingress-controller/pkg/providers/utils"
+)
+
+func TestTranslateGatewayListenerSSL(t *testing.T) {
+	secretIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	grantIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	tr := &translator{
+		TranslatorOptions: &TranslatorOptions{
+			SecretLister:         listerscorev1.NewSecretLister(secretIndexer),
+			ReferenceGrantLister: gatewaylistersv1beta1.NewReferenceGrantLister(grantIndexer),
+		},
+	}
+	for _, ns := range []string{"test", "certs"} {
+		err := secretIndexer.Add(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cert",
+				Namespace: ns,
+			},
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       []byte("cert"),
+				corev1.TLSPrivateKeyKey: []byte("key"),
+			},
+		})
+		assert.Nil(t, err)
+	}
+
+	newListener := func(name, hostname string, ref gatewayv1beta1.SecretObjectReference) gatewayv1beta1.Listener {
+		return gatewayv1beta1.Listener{
+			Name:     gatewayv1beta1.SectionName(name),
+			Hostname: utils.PtrOf(gatewayv1beta1.Hostname(hostname)),
+			Port:     443,
+			Protocol: gatewayv1beta1.HTTPSProtocolType,
+			TLS: &gatewayv1beta1.GatewayTLSConfig{
+				Mode:            utils.PtrOf(gatewayv1beta1.TLSModeTerminate),
+				CertificateRefs: []gatewayv1beta1.SecretObjectReference{ref},
+			},
+			AllowedRoutes: &gatewayv1beta1.AllowedRoutes{},
+		}
+	}
+	gateway := &gatewayv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gateway",
+			Namespace: "test",
+		},
+		Spec: gatewayv1beta1.GatewaySpec{
+			Listeners: []gatewayv1beta1.Listener{
+				newListener("https", "api.example.com", gatewayv1beta1.SecretObjectReference{Name: "cert"}),
+				newListener("missing", "missing.example.com", gatewayv1beta1.SecretObjectReference{Name: "missing"}),
+				newListener("cross", "cross.example.com", gatewayv1beta1.SecretObjectReference{Name: "cert", Namespace: refNamespace("certs")}),
+			},
+		},
+	}
+
+	listeners, err := tr.TranslateGatewayV1beta1(gateway)
+	assert.Nil(t, err)
+	// Listeners referencing missing or not permitted Secrets are invalid.
+	assert.Len(t, listeners, 1)
+	ssl := listeners["https"].SSL
+	assert.NotNil(t, ssl)
+	assert.Equal(t, []string{"api.example.com"}, ssl.Snis)
+	assert.Equal(t, "cert", ssl.Cert)
+	assert.Equal(t, "key", ssl.Key)
+	assert.Equal(t, "test", ssl.Labels["meta_secret_namespace"])
+
+	err = grantIndexer.Add(&gatewayv1beta1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "grant",
+			Namespace: "certs",
+		},
+		Spec: gatewayv1beta1.ReferenceGrantSpec{
+			From: []gatewayv1beta1.ReferenceGrantFrom{{Group: gatewayv1beta1.GroupName, Kind: "Gateway", Namespace: "test"}},
+			To:   []gatewayv1beta1.ReferenceGrantTo{{Kind: "Secret"}},
+		},
+	})
+	assert.Nil(t, err)
+
+	listeners, err = tr.TranslateGatewayV1beta1(gateway)
+	assert.Nil(t, err)
+	assert.Len(t, listeners, 2)
+	ssl = listeners["cross"].SSL
+	assert.Equal(t, []string{"cross.example.com"}, ssl.Snis)
+	assert.Equal(t, "certs", ssl.Labels["meta_secret_namespace"])
+	assert.NotEqual(t, listeners["https"].SSL.ID, ssl.ID)
+}
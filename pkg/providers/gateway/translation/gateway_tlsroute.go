@@ -26,6 +26,7 @@ import (
 
 	"github.com/apache/apisix-ingress-controller/pkg/id"
 	"github.com/apache/apisix-ingress-controller/pkg/log"
+	gatewaytypes "github.com/apache/apisix-ingress-controller/pkg/providers/gateway/types"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/translation"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/utils"
 	"github.com/apache/apisix-ingress-controller/pkg/types"
@@ -33,6 +34,17 @@ import (
 )
 
 func (t *translator) TranslateGatewayTLSRouteV1Alpha2(tlsRoute *gatewayv1alpha2.TLSRoute) (*translation.TranslateContext, error) {
+	return t.translateGatewayTLSRouteV1Alpha2(tlsRoute, false)
+}
+
+func (t *translator) GenerateGatewayTLSRouteV1Alpha2DeleteMark(tlsRoute *gatewayv1alpha2.TLSRoute) (*translation.TranslateContext, error) {
+	return t.translateGatewayTLSRouteV1Alpha2(tlsRoute, true)
+}
+
+// translateGatewayTLSRouteV1Alpha2 translates the TLSRoute, the upstreams only
+// carry the ID and name when deleteMark is true, so that the deletion won't be
+// blocked by missing Services.
+func (t *translator) translateGatewayTLSRouteV1Alpha2(tlsRoute *gatewayv1alpha2.TLSRoute, deleteMark bool) (*translation.TranslateContext, error) {
 	ctx := translation.DefaultEmptyTranslateContext()
 
 	// TODO: Handle ParentRefs
@@ -75,9 +87,12 @@ func (t *translator) TranslateGatewayTLSRouteV1Alpha2(tlsRoute *gatewayv1alpha2.
 			} else {
 				ns = string(*backend.Namespace)
 			}
-			//if ns != tlsRoute.Namespace {
-			// TODO: check gatewayv1alpha2.ReferencePolicy
-			//}
+			if !IsBackendRefPermitted(t.ReferenceGrantLister, gatewaytypes.KindTLSRoute, tlsRoute.Namespace, backend.BackendObjectReference) {
+				log.Warnw(fmt.Sprintf("ignore not permitted cross-namespace reference at Rules[%v].BackendRefs[%v]", i, j),
+					zap.String("namespace", ns),
+				)
+				continue
+			}
 
 			if backend.Port == nil {
 				log.Warnw(fmt.Sprintf("ignore nil port at Rules[%v].BackendRefs[%v]", i, j),
@@ -86,9 +101,13 @@ func (t *translator) TranslateGatewayTLSRouteV1Alpha2(tlsRoute *gatewayv1alpha2.
 				continue
 			}
 
-			ups, err := t.KubeTranslator.TranslateService(ns, string(backend.Name), "", int32(*backend.Port))
-			if err != nil {
-				return nil, errors.Wrap(err, fmt.Sprintf("failed to translate Rules[%v].BackendRefs[%v]", i, j))
+			ups := apisixv1.NewDefaultUpstream()
+			if !deleteMark {
+				var err error
+				ups, err = t.KubeTranslator.TranslateService(ns, string(backend.Name), "", int32(*backend.Port))
+				if err != nil {
+					return nil, errors.Wrap(err, fmt.Sprintf("failed to translate Rules[%v].BackendRefs[%v]", i, j))
+				}
 			}
 			ups.Name = apisixv1.ComposeUpstreamName(ns, string(backend.Name), "", int32(*backend.Port), types.ResolveGranularity.Endpoint)
 
@@ -26,12 +26,24 @@ import (
 
 	"github.com/apache/apisix-ingress-controller/pkg/id"
 	"github.com/apache/apisix-ingress-controller/pkg/log"
+	gatewaytypes "github.com/apache/apisix-ingress-controller/pkg/providers/gateway/types"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/translation"
 	"github.com/apache/apisix-ingress-controller/pkg/types"
 	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
 )
 
 func (t *translator) TranslateGatewayUDPRouteV1Alpha2(udpRoute *gatewayv1alpha2.UDPRoute) (*translation.TranslateContext, error) {
+	return t.translateGatewayUDPRouteV1Alpha2(udpRoute, false)
+}
+
+func (t *translator) GenerateGatewayUDPRouteV1Alpha2DeleteMark(udpRoute *gatewayv1alpha2.UDPRoute) (*translation.TranslateContext, error) {
+	return t.translateGatewayUDPRouteV1Alpha2(udpRoute, true)
+}
+
+// translateGatewayUDPRouteV1Alpha2 translates the UDPRoute, the upstreams only
+// carry the ID and name when deleteMark is true, so that the deletion won't be
+// blocked by missing Services.
+func (t *translator) translateGatewayUDPRouteV1Alpha2(udpRoute *gatewayv1alpha2.UDPRoute, deleteMark bool) (*translation.TranslateContext, error) {
 	ctx := translation.DefaultEmptyTranslateContext()
 
 	// TODO: handle UDPRoute.Spec.ParentRef
@@ -58,9 +70,12 @@ func (t *translator) TranslateGatewayUDPRouteV1Alpha2(udpRoute *gatewayv1alpha2.
 			} else {
 				ns = string(*backend.Namespace)
 			}
-			//if ns != httpRoute.Namespace {
-			// TODO: check gatewayv1alpha2.ReferencePolicy
-			//}
+			if !IsBackendRefPermitted(t.ReferenceGrantLister, gatewaytypes.KindUDPRoute, udpRoute.Namespace, backend.BackendObjectReference) {
+				log.Warnw(fmt.Sprintf("ignore not permitted cross-namespace reference at Rules[%v].BackendRefs[%v]", i, j),
+					zap.String("namespace", ns),
+				)
+				continue
+			}
 
 			if backend.Port == nil {
 				log.Warnw(fmt.Sprintf("ignore nil port at Rules[%v].BackendRefs[%v]", i, j),
@@ -74,9 +89,13 @@ func (t *translator) TranslateGatewayUDPRouteV1Alpha2(udpRoute *gatewayv1alpha2.
 			name := apisixv1.ComposeStreamRouteName(ns, udpRoute.Name, fmt.Sprintf("%d-%d", i, j))
 			sr.ID = id.GenID(name)
 
-			ups, err := t.KubeTranslator.TranslateService(ns, string(backend.Name), "", int32(*backend.Port))
-			if err != nil {
-				return nil, errors.Wrap(err, fmt.Sprintf("failed to translate Rules[%v].BackendRefs[%v]", i, j))
+			ups := apisixv1.NewDefaultUpstream()
+			if !deleteMark {
+				var err error
+				ups, err = t.KubeTranslator.TranslateService(ns, string(backend.Name), "", int32(*backend.Port))
+				if err != nil {
+					return nil, errors.Wrap(err, fmt.Sprintf("failed to translate Rules[%v].BackendRefs[%v]", i, j))
+				}
 			}
 			ups.Scheme = apisixv1.SchemeUDP
 			ups.Name = apisixv1.ComposeUpstreamName(ns, string(backend.Name), "", int32(*backend.Port), types.ResolveGranularity.Endpoint)
@@ -0,0 +1,75 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func newUDPRoute(svcName string) *gatewayv1alpha2.UDPRoute {
+	return &gatewayv1alpha2.UDPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "udp_route",
+			Namespace: "test",
+		},
+		Spec: gatewayv1alpha2.UDPRouteSpec{
+			Rules: []gatewayv1alpha2.UDPRouteRule{
+				{
+					BackendRefs: []gatewayv1alpha2.BackendRef{
+						{
+							BackendObjectReference: gatewayv1alpha2.BackendObjectReference{
+								Name: gatewayv1alpha2.ObjectName(svcName),
+								Port: refPortNumber(80),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGenerateGatewayUDPRouteDeleteMark(t *testing.T) {
+	tr, processCh := mockHTTPRouteTranslator(t)
+	<-processCh
+	<-processCh
+
+	udpRoute := newUDPRoute("svc")
+	tctx, err := tr.TranslateGatewayUDPRouteV1Alpha2(udpRoute)
+	assert.Nil(t, err)
+	mark, err := tr.GenerateGatewayUDPRouteV1Alpha2DeleteMark(udpRoute)
+	assert.Nil(t, err)
+
+	// The delete mark refers to the same resources.
+	assert.Len(t, mark.StreamRoutes, 1)
+	assert.Len(t, mark.Upstreams, 1)
+	assert.Equal(t, tctx.StreamRoutes[0].ID, mark.StreamRoutes[0].ID)
+	assert.Equal(t, tctx.Upstreams[0].ID, mark.Upstreams[0].ID)
+	assert.Equal(t, tctx.Upstreams[0].Name, mark.Upstreams[0].Name)
+
+	// Missing Services don't block the deletion.
+	udpRoute = newUDPRoute("deleted")
+	_, err = tr.TranslateGatewayUDPRouteV1Alpha2(udpRoute)
+	assert.NotNil(t, err)
+	mark, err = tr.GenerateGatewayUDPRouteV1Alpha2DeleteMark(udpRoute)
+	assert.Nil(t, err)
+	assert.Len(t, mark.StreamRoutes, 1)
+	assert.Len(t, mark.Upstreams, 1)
+}
@@ -0,0 +1,129 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package translation
+
+import (
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/labels"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewaylistersv1beta1 "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1beta1"
+
+	"github.com/apache/apisix-ingress-controller/pkg/log"
+)
+
+const (
+	kindService gatewayv1beta1.Kind = "Service"
+	kindSecret  gatewayv1beta1.Kind = "Secret"
+	kindGateway gatewayv1beta1.Kind = "Gateway"
+)
+
+// IsBackendRefPermitted reports whether a route of routeKind in routeNamespace
+// is allowed to reference the backend. References to another namespace must
+// be permitted by a ReferenceGrant in the namespace of the backend.
+func IsBackendRefPermitted(lister gatewaylistersv1beta1.ReferenceGrantLister, routeKind gatewayv1beta1.Kind,
+	routeNamespace string, ref gatewayv1beta1.BackendObjectReference) bool {
+	if ref.Namespace == nil || string(*ref.Namespace) == routeNamespace {
+		return true
+	}
+	to := gatewayv1beta1.ReferenceGrantTo{
+		Kind: kindService,
+		Name: &ref.Name,
+	}
+	if ref.Group != nil {
+		to.Group = *ref.Group
+	}
+	if ref.Kind != nil {
+		to.Kind = *ref.Kind
+	}
+	from := gatewayv1beta1.ReferenceGrantFrom{
+		Group:     gatewayv1beta1.GroupName,
+		Kind:      routeKind,
+		Namespace: gatewayv1beta1.Namespace(routeNamespace),
+	}
+	return isReferencePermitted(lister, from, string(*ref.Namespace), to)
+}
+
+// IsSecretRefPermitted reports whether a Gateway in gatewayNamespace is
+// allowed to reference the certificate. References to another namespace must
+// be permitted by a ReferenceGrant in the namespace of the certificate.
+func IsSecretRefPermitted(lister gatewaylistersv1beta1.ReferenceGrantLister, gatewayNamespace string,
+	ref gatewayv1beta1.SecretObjectReference) bool {
+	if ref.Namespace == nil || string(*ref.Namespace) == gatewayNamespace {
+		return true
+	}
+	to := gatewayv1beta1.ReferenceGrantTo{
+		Kind: kindSecret,
+		Name: &ref.Name,
+	}
+	if ref.Group != nil {
+		to.Group = *ref.Group
+	}
+	if ref.Kind != nil {
+		to.Kind = *ref.Kind
+	}
+	from := gatewayv1beta1.ReferenceGrantFrom{
+		Group:     gatewayv1beta1.GroupName,
+		Kind:      kindGateway,
+		Namespace: gatewayv1beta1.Namespace(gatewayNamespace),
+	}
+	return isReferencePermitted(lister, from, string(*ref.Namespace), to)
+}
+
+func isReferencePermitted(lister gatewaylistersv1beta1.ReferenceGrantLister, from gatewayv1beta1.ReferenceGrantFrom,
+	toNamespace string, to gatewayv1beta1.ReferenceGrantTo) bool {
+	if lister == nil {
+		return false
+	}
+	grants, err := lister.ReferenceGrants(toNamespace).List(labels.Everything())
+	if err != nil {
+		log.Errorw("failed to list ReferenceGrants",
+			zap.Error(err),
+			zap.String("namespace", toNamespace),
+		)
+		return false
+	}
+	for _, grant := range grants {
+		if referenceGrantAllows(grant, from, to) {
+			return true
+		}
+	}
+	return false
+}
+
+func referenceGrantAllows(grant *gatewayv1beta1.ReferenceGrant, from gatewayv1beta1.ReferenceGrantFrom,
+	to gatewayv1beta1.ReferenceGrantTo) bool {
+	fromAllowed := false
+	for _, f := range grant.Spec.From {
+		if f == from {
+			fromAllowed = true
+			break
+		}
+	}
+	if !fromAllowed {
+		return false
+	}
+	for _, t := range grant.Spec.To {
+		if t.Group != to.Group || t.Kind != to.Kind {
+			continue
+		}
+		// An empty name allows all resources of the kind in the namespace.
+		if t.Name == nil || *t.Name == "" || *t.Name == *to.Name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewaylistersv1beta1 "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1beta1"
+
+	"github.com/apache/apisix-ingress-controller/pkg/providers/gateway/types"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/utils"
+)
+
+func TestIsBackendRefPermitted(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	lister := gatewaylistersv1beta1.NewReferenceGrantLister(indexer)
+
+	ref := gatewayv1beta1.BackendObjectReference{
+		Name:      "svc",
+		Namespace: refNamespace("backend"),
+		Port:      refPortNumber(80),
+	}
+
+	// Same namespace references don't need a grant.
+	assert.True(t, IsBackendRefPermitted(lister, types.KindHTTPRoute, "backend", ref))
+	// Cross namespace references without a grant are refused.
+	assert.False(t, IsBackendRefPermitted(lister, types.KindHTTPRoute, "frontend", ref))
+	assert.False(t, IsBackendRefPermitted(nil, types.KindHTTPRoute, "frontend", ref))
+
+	err := indexer.Add(&gatewayv1beta1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "grant",
+			Namespace: "backend",
+		},
+		Spec: gatewayv1beta1.ReferenceGrantSpec{
+			From: []gatewayv1beta1.ReferenceGrantFrom{
+				{
+					Group:     gatewayv1beta1.GroupName,
+					Kind:      types.KindHTTPRoute,
+					Namespace: "frontend",
+				},
+			},
+			To: []gatewayv1beta1.ReferenceGrantTo{
+				{
+					Kind: "Service",
+					Name: utils.PtrOf(gatewayv1beta1.ObjectName("svc")),
+				},
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	assert.True(t, IsBackendRefPermitted(lister, types.KindHTTPRoute, "frontend", ref))
+	// The grant doesn't cover other kinds, namespaces or services.
+	assert.False(t, IsBackendRefPermitted(lister, types.KindTCPRoute, "frontend", ref))
+	assert.False(t, IsBackendRefPermitted(lister, types.KindHTTPRoute, "other", ref))
+	ref.Name = "svc2"
+	assert.False(t, IsBackendRefPermitted(lister, types.KindHTTPRoute, "frontend", ref))
+}
+
+func TestIsSecretRefPermitted(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	lister := gatewaylistersv1beta1.NewReferenceGrantLister(indexer)
+
+	ref := gatewayv1beta1.SecretObjectReference{
+		Name:      "cert",
+		Namespace: refNamespace("certs"),
+	}
+	assert.False(t, IsSecretRefPermitted(lister, "gateway", ref))
+
+	err := indexer.Add(&gatewayv1beta1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "grant",
+			Namespace: "certs",
+		},
+		Spec: gatewayv1beta1.ReferenceGrantSpec{
+			From: []gatewayv1beta1.ReferenceGrantFrom{
+				{
+					Group:     gatewayv1beta1.GroupName,
+					Kind:      "Gateway",
+					Namespace: "gateway",
+				},
+			},
+			// An empty name permits all the Secrets in the namespace.
+			To: []gatewayv1beta1.ReferenceGrantTo{
+				{
+					Kind: "Secret",
+				},
+			},
+		},
+	})
+	assert.Nil(t, err)
+	assert.True(t, IsSecretRefPermitted(lister, "gateway", ref))
+}
@@ -17,8 +17,10 @@
 package translation
 
 import (
+	listerscorev1 "k8s.io/client-go/listers/core/v1"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewaylistersv1beta1 "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1beta1"
 
 	"github.com/apache/apisix-ingress-controller/pkg/providers/gateway/types"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/translation"
@@ -26,6 +28,11 @@ import (
 
 type TranslatorOptions struct {
 	KubeTranslator translation.Translator
+	// ReferenceGrantLister is used to check cross-namespace references,
+	// which are refused when it's nil.
+	ReferenceGrantLister gatewaylistersv1beta1.ReferenceGrantLister
+	// SecretLister is used to get the certificates of listeners terminating TLS.
+	SecretLister listerscorev1.SecretLister
 }
 
 type translator struct {
@@ -33,16 +40,28 @@ type translator struct {
 }
 
 type Translator interface {
-	// TranslateGatewayV1beta1 translates Gateway to internal configurations
+	// TranslateGatewayV1beta1 translates Gateway to internal configurations, including
+	// the SSL of listeners terminating TLS.
 	TranslateGatewayV1beta1(gateway *gatewayv1beta1.Gateway) (map[string]*types.ListenerConf, error)
-	// TranslateGatewayHTTPRouteV1beta1 translates Gateway API HTTPRoute to APISIX resources
-	TranslateGatewayHTTPRouteV1beta1(httpRoute *gatewayv1beta1.HTTPRoute) (*translation.TranslateContext, error)
+	// TranslateGatewayHTTPRouteV1beta1 translates Gateway API HTTPRoute to APISIX resources,
+	// the hosts of the routes are the intersection of the hostnames of the HTTPRoute and the
+	// listeners it attaches to.
+	TranslateGatewayHTTPRouteV1beta1(httpRoute *gatewayv1beta1.HTTPRoute, listeners []*types.ListenerConf) (*translation.TranslateContext, error)
+	// TranslateGatewayGRPCRouteV1Alpha2 translates Gateway API GRPCRoute to APISIX resources,
+	// the hosts are computed in the same way as HTTPRoute.
+	TranslateGatewayGRPCRouteV1Alpha2(grpcRoute *gatewayv1alpha2.GRPCRoute, listeners []*types.ListenerConf) (*translation.TranslateContext, error)
 	// TranslateGatewayTLSRouteV1Alpha2 translates Gateway API TLSRoute to APISIX resources
 	TranslateGatewayTLSRouteV1Alpha2(tlsRoute *gatewayv1alpha2.TLSRoute) (*translation.TranslateContext, error)
+	// GenerateGatewayTLSRouteV1Alpha2DeleteMark translates Gateway API TLSRoute to APISIX resources
+	// not strictly, only used for delete event.
+	GenerateGatewayTLSRouteV1Alpha2DeleteMark(tlsRoute *gatewayv1alpha2.TLSRoute) (*translation.TranslateContext, error)
 	// TranslateGatewayTCPRouteV1Alpha2 translates Gateway API TCPRoute to APISIX resources
 	TranslateGatewayTCPRouteV1Alpha2(*gatewayv1alpha2.TCPRoute) (*translation.TranslateContext, error)
 	// TranslateGatewayUDPRouteV1Alpha2 translates Gateway API UDPRoute to APISIX resources
 	TranslateGatewayUDPRouteV1Alpha2(udpRoute *gatewayv1alpha2.UDPRoute) (*translation.TranslateContext, error)
+	// GenerateGatewayUDPRouteV1Alpha2DeleteMark translates Gateway API UDPRoute to APISIX resources
+	// not strictly, only used for delete event.
+	GenerateGatewayUDPRouteV1Alpha2DeleteMark(udpRoute *gatewayv1alpha2.UDPRoute) (*translation.TranslateContext, error)
 }
 
 // NewTranslator initializes a APISIX CRD resources Translator.
@@ -20,6 +20,7 @@ import (
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/apache/apisix-ingress-controller/pkg/providers/utils"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
 )
 
 const (
@@ -27,6 +28,7 @@ const (
 	KindTLSRoute  gatewayv1beta1.Kind = "TLSRoute"
 	KindHTTPRoute gatewayv1beta1.Kind = "HTTPRoute"
 	KindUDPRoute  gatewayv1beta1.Kind = "UDPRoute"
+	KindGRPCRoute gatewayv1beta1.Kind = "GRPCRoute"
 )
 
 type ListenerConf struct {
@@ -43,6 +45,9 @@ type ListenerConf struct {
 	// namespace selector of AllowedRoutes
 	RouteNamespace *gatewayv1beta1.RouteNamespaces
 	AllowedKinds   []gatewayv1beta1.RouteGroupKind
+
+	// SSL is the certificate of a listener terminating TLS, nil otherwise.
+	SSL *apisixv1.Ssl
 }
 
 func (c *ListenerConf) IsAllowedKind(r gatewayv1beta1.RouteGroupKind) bool {
@@ -58,16 +63,70 @@ func (c *ListenerConf) IsAllowedKind(r gatewayv1beta1.RouteGroupKind) bool {
 	return false
 }
 
+// IsHostnameMatch reports whether at least one of the route hostnames
+// intersects with the listener hostname.
 func (c *ListenerConf) IsHostnameMatch(hostnames []gatewayv1beta1.Hostname) bool {
-	if c.Hostname == nil || len(hostnames) == 0 {
-		return true
+	_, ok := c.IntersectHostnames(hostnames)
+	return ok
+}
+
+// IntersectHostnames returns the hostnames of a route attached to the listener
+// which are effective, a nil slice means any hostname. It reports false if no
+// route hostname intersects with the listener hostname.
+func (c *ListenerConf) IntersectHostnames(hostnames []gatewayv1beta1.Hostname) ([]string, bool) {
+	if !c.HasHostname() {
+		if len(hostnames) == 0 {
+			return nil, true
+		}
+		hosts := make([]string, 0, len(hostnames))
+		for _, h := range hostnames {
+			hosts = append(hosts, string(h))
+		}
+		return hosts, true
 	}
+	if len(hostnames) == 0 {
+		return []string{string(*c.Hostname)}, true
+	}
+	var hosts []string
 	for _, h := range hostnames {
-		if !utils.IsHostnameMatch(string(*c.Hostname), string(h)) {
-			return false
+		if host, ok := utils.IntersectHostname(string(*c.Hostname), string(h)); ok {
+			hosts = append(hosts, host)
 		}
 	}
-	return true
+	return hosts, len(hosts) > 0
+}
+
+// IntersectListenersHostnames returns the effective hostnames of a route
+// attached to all the listeners, a nil slice means any hostname. Without
+// listeners, the route hostnames are effective. It reports false if the
+// route hostnames don't intersect with any listener.
+func IntersectListenersHostnames(listeners []*ListenerConf, hostnames []gatewayv1beta1.Hostname) ([]string, bool) {
+	if len(listeners) == 0 {
+		return (&ListenerConf{}).IntersectHostnames(hostnames)
+	}
+	var (
+		hosts   []string
+		matched bool
+	)
+	seen := make(map[string]struct{})
+	for _, listener := range listeners {
+		listenerHosts, ok := listener.IntersectHostnames(hostnames)
+		if !ok {
+			continue
+		}
+		if listenerHosts == nil {
+			// One of the listeners accepts any hostname.
+			return nil, true
+		}
+		matched = true
+		for _, host := range listenerHosts {
+			if _, ok := seen[host]; !ok {
+				seen[host] = struct{}{}
+				hosts = append(hosts, host)
+			}
+		}
+	}
+	return hosts, matched
 }
 
 func (c *ListenerConf) HasHostname() bool {
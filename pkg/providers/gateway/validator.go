@@ -28,6 +28,7 @@ import (
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/apache/apisix-ingress-controller/pkg/log"
+	gatewaytranslation "github.com/apache/apisix-ingress-controller/pkg/providers/gateway/translation"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/gateway/types"
 )
 
@@ -47,6 +48,7 @@ type commonRoute struct {
 	routeProtocol  gatewayv1beta1.ProtocolType
 	routeHostnames []gatewayv1beta1.Hostname
 	routeGroupKind gatewayv1beta1.RouteGroupKind
+	backendRefs    []gatewayv1beta1.BackendObjectReference
 }
 
 func (r *commonRoute) hasParentRefs() bool {
@@ -84,6 +86,34 @@ func ConvertHostnamesToV1beta1(hostnames []gatewayv1alpha2.Hostname) []gatewayv1
 	return v1beta1Hostnames
 }
 
+func backendObjectRefs(backends []gatewayv1beta1.BackendRef) []gatewayv1beta1.BackendObjectReference {
+	refs := make([]gatewayv1beta1.BackendObjectReference, 0, len(backends))
+	for _, backend := range backends {
+		refs = append(refs, backend.BackendObjectReference)
+	}
+	return refs
+}
+
+func mirrorBackendRefs(filters []gatewayv1beta1.HTTPRouteFilter) []gatewayv1beta1.BackendObjectReference {
+	var refs []gatewayv1beta1.BackendObjectReference
+	for _, filter := range filters {
+		if filter.RequestMirror != nil {
+			refs = append(refs, filter.RequestMirror.BackendRef)
+		}
+	}
+	return refs
+}
+
+func grpcMirrorBackendRefs(filters []gatewayv1alpha2.GRPCRouteFilter) []gatewayv1beta1.BackendObjectReference {
+	var refs []gatewayv1beta1.BackendObjectReference
+	for _, filter := range filters {
+		if filter.RequestMirror != nil {
+			refs = append(refs, filter.RequestMirror.BackendRef)
+		}
+	}
+	return refs
+}
+
 func parseToCommentRoute(route any) (*commonRoute, error) {
 	r := new(commonRoute)
 	group := gatewayv1beta1.Group(gatewayv1beta1.GroupName)
@@ -97,6 +127,29 @@ func parseToCommentRoute(route any) (*commonRoute, error) {
 			Group: &group,
 			Kind:  types.KindHTTPRoute,
 		}
+		for _, rule := range route.Spec.Rules {
+			r.backendRefs = append(r.backendRefs, mirrorBackendRefs(rule.Filters)...)
+			for _, backend := range rule.BackendRefs {
+				r.backendRefs = append(r.backendRefs, backend.BackendObjectReference)
+				r.backendRefs = append(r.backendRefs, mirrorBackendRefs(backend.Filters)...)
+			}
+		}
+	case *gatewayv1alpha2.GRPCRoute:
+		r.routeNamespace = route.Namespace
+		r.parentRefs = ConvertParentRefsToV1beta1(route.Spec.ParentRefs)
+		r.routeProtocol = gatewayv1beta1.HTTPProtocolType
+		r.routeHostnames = ConvertHostnamesToV1beta1(route.Spec.Hostnames)
+		r.routeGroupKind = gatewayv1beta1.RouteGroupKind{
+			Group: &group,
+			Kind:  types.KindGRPCRoute,
+		}
+		for _, rule := range route.Spec.Rules {
+			r.backendRefs = append(r.backendRefs, grpcMirrorBackendRefs(rule.Filters)...)
+			for _, backend := range rule.BackendRefs {
+				r.backendRefs = append(r.backendRefs, backend.BackendObjectReference)
+				r.backendRefs = append(r.backendRefs, grpcMirrorBackendRefs(backend.Filters)...)
+			}
+		}
 	case *gatewayv1alpha2.TLSRoute:
 		r.routeNamespace = route.Namespace
 		r.parentRefs = ConvertParentRefsToV1beta1(route.Spec.ParentRefs)
@@ -106,6 +159,9 @@ func parseToCommentRoute(route any) (*commonRoute, error) {
 			Group: &group,
 			Kind:  types.KindTLSRoute,
 		}
+		for _, rule := range route.Spec.Rules {
+			r.backendRefs = append(r.backendRefs, backendObjectRefs(rule.BackendRefs)...)
+		}
 	case *gatewayv1alpha2.TCPRoute:
 		r.routeNamespace = route.Namespace
 		r.parentRefs = ConvertParentRefsToV1beta1(route.Spec.ParentRefs)
@@ -114,6 +170,9 @@ func parseToCommentRoute(route any) (*commonRoute, error) {
 			Group: &group,
 			Kind:  types.KindTCPRoute,
 		}
+		for _, rule := range route.Spec.Rules {
+			r.backendRefs = append(r.backendRefs, backendObjectRefs(rule.BackendRefs)...)
+		}
 	case *gatewayv1alpha2.UDPRoute:
 		r.routeNamespace = route.Namespace
 		r.parentRefs = ConvertParentRefsToV1beta1(route.Spec.ParentRefs)
@@ -122,6 +181,9 @@ func parseToCommentRoute(route any) (*commonRoute, error) {
 			Group: &group,
 			Kind:  types.KindUDPRoute,
 		}
+		for _, rule := range route.Spec.Rules {
+			r.backendRefs = append(r.backendRefs, backendObjectRefs(rule.BackendRefs)...)
+		}
 	default:
 		return nil, fmt.Errorf("validator unsupported Route %T", r)
 	}
@@ -158,7 +220,9 @@ func (v *Validator) getListenersConf(r *commonRoute, parentRef gatewayv1beta1.Pa
 		if err != nil {
 			return nil, err
 		}
-		listeners = append(listeners, listenerConf)
+		if listenerConf != nil {
+			listeners = append(listeners, listenerConf)
+		}
 	} else {
 		_listeners, err := v.provider.QueryListeners(namespace, name)
 		if err != nil {
@@ -177,98 +241,225 @@ func (v *Validator) getListenersConf(r *commonRoute, parentRef gatewayv1beta1.Pa
 	return listeners, nil
 }
 
-func (v *Validator) validateParentRef(r *commonRoute) ([]*types.ListenerConf, error) {
-	var matchedListeners []*types.ListenerConf
-	for _, parentRef := range r.parentRefs {
-		listeners, err := v.getListenersConf(r, parentRef)
-		if err != nil {
-			return nil, err
-		}
+// ParentRefResult is the result of attaching a route to one of its ParentRefs.
+type ParentRefResult struct {
+	ParentRef gatewayv1beta1.ParentReference
+	// Listeners the route attaches to, the route is accepted by the parent
+	// when it's not empty.
+	Listeners []*types.ListenerConf
+	// Reason and Message explain why the route is not accepted.
+	Reason  gatewayv1beta1.RouteConditionReason
+	Message string
+}
 
-		// filter listener by ParentRef
-		for _, listenerConf := range listeners {
+// Accepted reports whether the route attaches to any listener of the parent.
+func (r *ParentRefResult) Accepted() bool {
+	return len(r.Listeners) > 0
+}
+
+func (v *Validator) validateParentRef(r *commonRoute, parentRef gatewayv1beta1.ParentReference) (*ParentRefResult, error) {
+	result := &ParentRefResult{
+		ParentRef: parentRef,
+	}
+	listeners, err := v.getListenersConf(r, parentRef)
+	if err != nil {
+		result.Reason = gatewayv1beta1.RouteReasonNoMatchingParent
+		result.Message = err.Error()
+		return result, nil
+	}
 
-			if listenerConf.Protocol != r.routeProtocol {
+	hostnameMismatched := false
+	// filter listener by ParentRef
+	for _, listenerConf := range listeners {
+		if !listenerConf.IsAllowedKind(r.routeGroupKind) {
+			// TODO: set the “ResolvedRefs” condition to False for this Listener with the “InvalidRouteKinds” reason.
+			continue
+		}
+
+		// match listener by AllowRoute.Namespaces
+		switch *listenerConf.RouteNamespace.From {
+		case gatewayv1beta1.NamespacesFromSame:
+			if r.routeNamespace != listenerConf.Namespace {
 				continue
 			}
-			if !listenerConf.IsAllowedKind(r.routeGroupKind) {
-				// TODO: set the “ResolvedRefs” condition to False for this Listener with the “InvalidRouteKinds” reason.
-				continue
+		case gatewayv1beta1.NamespacesFromSelector:
+			// get listener namespace with selector labeled namespace
+			selector, err := metav1.LabelSelectorAsSelector(listenerConf.RouteNamespace.Selector)
+			if err != nil {
+				log.Errorw("convert Selector failed",
+					zap.Error(err),
+					zap.Any("Object", listenerConf.RouteNamespace.Selector),
+				)
+				return nil, err
 			}
-			if r.isHTTPProtocol() || r.isHTTPSProtocol() {
-				if listenerConf.HasHostname() && len(r.routeHostnames) != 0 {
-					if !listenerConf.IsHostnameMatch(r.routeHostnames) {
-						continue
-					}
+			allowedNamespaces := &corev1.NamespaceList{}
+			err = v.provider.runtimeClient.List(
+				context.TODO(), allowedNamespaces,
+				&runtimeclient.ListOptions{LabelSelector: selector},
+			)
+			if err != nil {
+				log.Errorw("list parent namespace failed",
+					zap.Error(err),
+					zap.Any("Object", *listenerConf.RouteNamespace.From),
+				)
+				return nil, err
+			}
+			namespaceMatched := false
+			for _, allowedNamespace := range allowedNamespaces.Items {
+				if string(allowedNamespace.Name) == r.routeNamespace {
+					namespaceMatched = true
+					break
 				}
 			}
+			if !namespaceMatched {
+				continue
+			}
+		}
 
-			// match listener by AllowRoute.Namespaces
-			switch *listenerConf.RouteNamespace.From {
-			case gatewayv1beta1.NamespacesFromSame:
-				if r.routeNamespace != listenerConf.Namespace {
-					continue
-				}
-			case gatewayv1beta1.NamespacesFromSelector:
-				// get listener namespace with selector labeled namespace
-				selector, err := metav1.LabelSelectorAsSelector(listenerConf.RouteNamespace.Selector)
-				if err != nil {
-					log.Errorw("convert Selector failed",
-						zap.Error(err),
-						zap.Any("Object", listenerConf.RouteNamespace.Selector),
-					)
-					return nil, err
-				}
-				allowedNamespaces := &corev1.NamespaceList{}
-				err = v.provider.runtimeClient.List(
-					context.TODO(), allowedNamespaces,
-					&runtimeclient.ListOptions{LabelSelector: selector},
-				)
-				if err != nil {
-					log.Errorw("list parent namespace failed",
-						zap.Error(err),
-						zap.Any("Object", *listenerConf.RouteNamespace.From),
-					)
-					return nil, err
-				}
-				namespaceMatched := false
-				for _, allowedNamespace := range allowedNamespaces.Items {
-					if string(allowedNamespace.Name) == r.routeNamespace {
-						namespaceMatched = true
-						break
-					}
-				}
-				if !namespaceMatched {
+		if r.isHTTPProtocol() || r.isHTTPSProtocol() {
+			if listenerConf.HasHostname() && len(r.routeHostnames) != 0 {
+				if !listenerConf.IsHostnameMatch(r.routeHostnames) {
+					hostnameMismatched = true
 					continue
 				}
 			}
+		}
 
-			matchedListeners = append(matchedListeners, listenerConf)
+		result.Listeners = append(result.Listeners, listenerConf)
+	}
+
+	if !result.Accepted() {
+		if hostnameMismatched {
+			result.Reason = gatewayv1beta1.RouteReasonNoMatchingListenerHostname
+			result.Message = "no listener hostname matches the route hostnames"
+		} else {
+			result.Reason = gatewayv1beta1.RouteReasonNotAllowedByListeners
+			result.Message = "no listener allows the route to attach"
 		}
 	}
-	if len(matchedListeners) == 0 {
-		log.Errorw("no listeners referenced by ParentRefs",
-			zap.Any("listeners", v.provider.listeners),
-			zap.Any("route", r),
-		)
-		return nil, fmt.Errorf("no listeners referenced by ParentRefs")
+	return result, nil
+}
+
+// ValidateParentRefs attaches the route to each of its ParentRefs.
+// route argument support HTTPRoute GRPCRoute TLSRoute TCPRoute UDPRoute for now.
+func (v *Validator) ValidateParentRefs(route any) ([]*ParentRefResult, error) {
+	r, err := parseToCommentRoute(route)
+	if err != nil {
+		return nil, err
 	}
-	return matchedListeners, nil
+
+	results := make([]*ParentRefResult, 0, len(r.parentRefs))
+	for _, parentRef := range r.parentRefs {
+		result, err := v.validateParentRef(r, parentRef)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
 }
 
 // ValidateCommonRoute only checks CommonRoute and ParentRef.
-// route argument support HTTPRoute TLSRoute UDPRoute TLSRoute for now.
+// route argument support HTTPRoute GRPCRoute TLSRoute TCPRoute UDPRoute for now.
 func (v *Validator) ValidateCommonRoute(route any) error {
+	results, err := v.ValidateParentRefs(route)
+	if err != nil {
+		return err
+	}
+	return acceptedError(results)
+}
+
+// acceptedError returns an error if the route has ParentRefs but none of them
+// accepts it.
+func acceptedError(results []*ParentRefResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+	for _, result := range results {
+		if result.Accepted() {
+			return nil
+		}
+	}
+	log.Errorw("no listeners referenced by ParentRefs",
+		zap.Any("results", results),
+	)
+	return fmt.Errorf("no listeners referenced by ParentRefs")
+}
+
+// acceptedListeners returns all the listeners the route attaches to.
+func acceptedListeners(results []*ParentRefResult) []*types.ListenerConf {
+	var listeners []*types.ListenerConf
+	for _, result := range results {
+		listeners = append(listeners, result.Listeners...)
+	}
+	return listeners
+}
+
+// RefError is a reference of a route which can't be resolved.
+type RefError struct {
+	Reason  gatewayv1beta1.RouteConditionReason
+	Message string
+}
+
+func (e *RefError) Error() string {
+	return e.Message
+}
+
+// ValidateBackendRefs checks that every reference of the route is a Service,
+// and cross-namespace references are permitted by a ReferenceGrant. The
+// returned error is a *RefError.
+// route argument support HTTPRoute GRPCRoute TLSRoute TCPRoute UDPRoute for now.
+func (v *Validator) ValidateBackendRefs(route any) error {
 	r, err := parseToCommentRoute(route)
 	if err != nil {
 		return err
 	}
 
-	if r.hasParentRefs() {
-		_, err = v.validateParentRef(r)
-		if err != nil {
-			return err
+	for _, ref := range r.backendRefs {
+		if (ref.Group != nil && *ref.Group != "") || (ref.Kind != nil && *ref.Kind != "Service") {
+			return &RefError{
+				Reason:  gatewayv1beta1.RouteReasonInvalidKind,
+				Message: fmt.Sprintf("reference to %s is not a Service", ref.Name),
+			}
+		}
+		if !gatewaytranslation.IsBackendRefPermitted(v.provider.referenceGrantLister, r.routeGroupKind.Kind, r.routeNamespace, ref) {
+			return &RefError{
+				Reason:  gatewayv1beta1.RouteReasonRefNotPermitted,
+				Message: fmt.Sprintf("reference to %s/%s is not permitted by any ReferenceGrant", *ref.Namespace, ref.Name),
+			}
 		}
 	}
 	return nil
 }
+
+// ValidateBackendFilters checks that the backends of every rule carry the same
+// filters, otherwise the backend filters of the rule are ignored, since APISIX
+// traffic-split can't run plugins per weighted upstream. The returned error is
+// a *RefError.
+func (v *Validator) ValidateBackendFilters(route any) error {
+	var rules []int
+	switch route := route.(type) {
+	case *gatewayv1beta1.HTTPRoute:
+		for i, rule := range route.Spec.Rules {
+			if _, ok := gatewaytranslation.CommonHTTPBackendFilters(rule.BackendRefs); !ok {
+				rules = append(rules, i)
+			}
+		}
+	case *gatewayv1alpha2.GRPCRoute:
+		for i, rule := range route.Spec.Rules {
+			if _, ok := gatewaytranslation.CommonGRPCBackendFilters(rule.BackendRefs); !ok {
+				rules = append(rules, i)
+			}
+		}
+	default:
+		return nil
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	return &RefError{
+		Reason: gatewayv1beta1.RouteReasonIncompatibleFilters,
+		Message: fmt.Sprintf("backend filters of Rules%v are ignored, backends of a traffic-split must share the same filters",
+			rules),
+	}
+}
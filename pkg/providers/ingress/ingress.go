@@ -16,6 +16,7 @@ package ingress
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -37,9 +38,11 @@ import (
 	"github.com/apache/apisix-ingress-controller/pkg/config"
 	"github.com/apache/apisix-ingress-controller/pkg/kube"
 	"github.com/apache/apisix-ingress-controller/pkg/log"
+	ingresstranslation "github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/translation"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/utils"
 	"github.com/apache/apisix-ingress-controller/pkg/types"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
 )
 
 const (
@@ -60,6 +63,18 @@ type ingressController struct {
 	// Secret key is kube-style meta key: `namespace/name`
 	// Ingress Version Key is: `namespace/name_groupVersion`
 	secretRefMap *sync.Map
+
+	// syncedIngresses stores what was last synced to APISIX for the Ingresses,
+	// so that it can be cleaned up even after the IngressClass parameters,
+	// which the bound clusters and defaults come from, changed.
+	// type: Map<IngressKey, *syncedIngress>
+	syncedIngresses *sync.Map
+}
+
+// syncedIngress is the manifest of an Ingress and the clusters it was synced to.
+type syncedIngress struct {
+	clusters []string
+	manifest *utils.Manifest
 }
 
 func newIngressController(common *ingressCommon) *ingressController {
@@ -70,7 +85,8 @@ func newIngressController(common *ingressCommon) *ingressController {
 		workers:   1,
 		pool:      pool.NewLimited(2),
 
-		secretRefMap: new(sync.Map),
+		secretRefMap:    new(sync.Map),
+		syncedIngresses: new(sync.Map),
 	}
 
 	c.IngressInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -78,6 +94,17 @@ func newIngressController(common *ingressCommon) *ingressController {
 		UpdateFunc: c.onUpdate,
 		DeleteFunc: c.OnDelete,
 	})
+	if c.IngressClassInformer != nil {
+		c.IngressClassInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.onIngressClassAdd,
+			UpdateFunc: c.onIngressClassUpdate,
+		})
+	}
+	if c.ApisixClusterConfigInformer != nil {
+		c.ApisixClusterConfigInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: c.onApisixClusterConfigUpdate,
+		})
+	}
 	return c
 }
 
@@ -151,6 +178,10 @@ func (c *ingressController) sync(ctx context.Context, ev *types.Event) error {
 		ing = ev.Tombstone.(kube.Ingress)
 	}
 
+	if ingresstranslation.IsCanaryIngress(ing) || (ingEv.OldObject != nil && ingresstranslation.IsCanaryIngress(ingEv.OldObject)) {
+		c.syncCanaryPrimaries(ing, ingEv.OldObject)
+	}
+
 	var secrets []string
 	switch ingEv.GroupVersion {
 	case kube.IngressV1:
@@ -173,13 +204,24 @@ func (c *ingressController) sync(ctx context.Context, ev *types.Event) error {
 		if ev.Type == types.EventDelete {
 			tctx, err = c.translator.TranslateIngressDeleteEvent(ing)
 		} else {
+			if annoErr := c.translator.ValidateAnnotations(ing); annoErr != nil {
+				// Malformed annotations are skipped in translation, let users
+				// know about them.
+				c.RecordEventS(ingressObject(ing), corev1.EventTypeWarning, utils.AnnotationsInvalid, annoErr.Error())
+			}
+			start := time.Now()
 			tctx, err = c.translator.TranslateIngress(ing)
+			c.MetricsCollector.RecordTranslation(time.Since(start), "ingress", err)
 		}
 		if err != nil {
 			log.Errorw("failed to translate ingress",
 				zap.Error(err),
 				zap.Any("ingress", ing),
 			)
+			var certErr *translation.CertificateError
+			if errors.As(err, &certErr) {
+				c.RecordEvent(ingressObject(ing), corev1.EventTypeWarning, utils.ResourceSyncAborted, err)
+			}
 			goto updateStatus
 		}
 
@@ -190,6 +232,9 @@ func (c *ingressController) sync(ctx context.Context, ev *types.Event) error {
 			zap.Any("ssl", tctx.SSL),
 			zap.Any("pluginConfigs", tctx.PluginConfigs),
 		)
+		for _, ssl := range tctx.SSL {
+			c.MonitorCertificate(ingressObject(ing), sslSecret(ssl), ssl, ev.Type)
+		}
 	}
 	{
 		m := &utils.Manifest{
@@ -200,31 +245,44 @@ func (c *ingressController) sync(ctx context.Context, ev *types.Event) error {
 		}
 
 		var (
-			added   *utils.Manifest
-			updated *utils.Manifest
-			deleted *utils.Manifest
+			clusters    = c.translator.BoundClusters(ing)
+			om          *utils.Manifest
+			oldClusters []string
 		)
-
-		if ev.Type == types.EventDelete {
-			deleted = m
-		} else if ev.Type.IsAddEvent() {
-			added = m
-		} else {
+		if obj, ok := c.syncedIngresses.Load(ingEv.Key); ok {
+			// The bound clusters and defaults may have been changed by the
+			// IngressClass parameters since the last sync, translating the
+			// old object again doesn't tell what is in APISIX.
+			last := obj.(*syncedIngress)
+			if ev.Type == types.EventDelete {
+				m, clusters = last.manifest, last.clusters
+			} else {
+				om, oldClusters = last.manifest, last.clusters
+			}
+		} else if ev.Type == types.EventUpdate {
 			oldCtx, _ := c.translator.TranslateOldIngress(ingEv.OldObject)
-			om := &utils.Manifest{
+			om = &utils.Manifest{
 				Routes:        oldCtx.Routes,
 				Upstreams:     oldCtx.Upstreams,
 				SSLs:          oldCtx.SSL,
 				PluginConfigs: oldCtx.PluginConfigs,
 			}
-			added, updated, deleted = m.Diff(om)
+			oldClusters = c.translator.BoundClusters(ingEv.OldObject)
 		}
-		if err = c.SyncManifests(ctx, added, updated, deleted, ev.Type.IsSyncEvent()); err != nil {
+		if om != nil {
+			c.DeleteStaleCertificateExpiry(certificateSNIs(om.SSLs), certificateSNIs(m.SSLs))
+		}
+		if err = c.SyncBoundManifests(ctx, ev.Type, clusters, oldClusters, m, om); err != nil {
 			log.Errorw("failed to sync Ingress to apisix",
 				zap.Error(err),
 			)
 			goto updateStatus
 		}
+		if ev.Type == types.EventDelete {
+			c.syncedIngresses.Delete(ingEv.Key)
+		} else {
+			c.syncedIngresses.Store(ingEv.Key, &syncedIngress{clusters: clusters, manifest: m})
+		}
 	}
 updateStatus:
 	c.pool.Queue(func(wu pool.WorkUnit) (interface{}, error) {
@@ -237,6 +295,117 @@ updateStatus:
 	return err
 }
 
+// sslSecret returns the key (namespace/name) of the Secret of the SSL object.
+func sslSecret(ssl *apisixv1.Ssl) string {
+	return ssl.Labels[translation.MetaSecretNamespace] + "/" + ssl.Labels[translation.MetaSecretName]
+}
+
+// certificateSNIs maps the Secrets of the SSL objects to their SNIs.
+func certificateSNIs(ssls []*apisixv1.Ssl) map[string][]string {
+	snis := make(map[string][]string, len(ssls))
+	for _, ssl := range ssls {
+		secret := sslSecret(ssl)
+		snis[secret] = append(snis[secret], ssl.Snis...)
+	}
+	return snis
+}
+
+// syncCanaryPrimaries resyncs the Ingresses which share hosts and paths with
+// the canary Ingresses, so that their routes follow the canary changes.
+func (c *ingressController) syncCanaryPrimaries(canaries ...kube.Ingress) {
+	var (
+		namespace    string
+		groupVersion string
+		hostPaths    = make(map[string]struct{})
+	)
+	for _, ing := range canaries {
+		if ing == nil || !ingresstranslation.IsCanaryIngress(ing) {
+			continue
+		}
+		namespace = ing.GetNamespace()
+		groupVersion = ing.GroupVersion()
+		for _, hp := range ingressHostPaths(ing) {
+			hostPaths[hp] = struct{}{}
+		}
+	}
+	if len(hostPaths) == 0 {
+		return
+	}
+
+	var (
+		list []kube.Ingress
+		err  error
+	)
+	if groupVersion == kube.IngressV1beta1 {
+		list, err = c.IngressLister.ListV1beta1(namespace)
+	} else {
+		list, err = c.IngressLister.ListV1(namespace)
+	}
+	if err != nil {
+		log.Errorw("failed to list ingresses for canary",
+			zap.Error(err),
+			zap.String("namespace", namespace),
+		)
+		return
+	}
+	for _, ing := range list {
+		if ingresstranslation.IsCanaryIngress(ing) || !c.isIngressEffective(ing) {
+			continue
+		}
+		for _, hp := range ingressHostPaths(ing) {
+			if _, ok := hostPaths[hp]; !ok {
+				continue
+			}
+			// The old object is the Ingress itself, its routes are read
+			// from the cache, so that the upstreams of the removed
+			// canary are deleted.
+			c.workqueue.Add(&types.Event{
+				Type: types.EventUpdate,
+				Object: kube.IngressEvent{
+					Key:          ing.GetNamespace() + "/" + ing.GetName(),
+					GroupVersion: ing.GroupVersion(),
+					OldObject:    ing,
+				},
+			})
+			break
+		}
+	}
+}
+
+// ingressHostPaths returns the host and path pairs of the Ingress rules.
+func ingressHostPaths(ing kube.Ingress) []string {
+	var hostPaths []string
+	switch ing.GroupVersion() {
+	case kube.IngressV1:
+		for _, rule := range ing.V1().Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			for _, pathRule := range rule.HTTP.Paths {
+				hostPaths = append(hostPaths, rule.Host+pathRule.Path)
+			}
+		}
+	case kube.IngressV1beta1:
+		for _, rule := range ing.V1beta1().Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			for _, pathRule := range rule.HTTP.Paths {
+				hostPaths = append(hostPaths, rule.Host+pathRule.Path)
+			}
+		}
+	}
+	return hostPaths
+}
+
+// ingressObject returns the underlying object of the Ingress.
+func ingressObject(ing kube.Ingress) runtime.Object {
+	if ing.GroupVersion() == kube.IngressV1beta1 {
+		return ing.V1beta1()
+	}
+	return ing.V1()
+}
+
 func (c *ingressController) handleSyncErr(obj interface{}, err error) {
 	if err == nil {
 		c.workqueue.Forget(obj)
@@ -457,7 +626,84 @@ func (c *ingressController) isIngressEffective(ing kube.Ingress) bool {
 	if ic != nil {
 		return *ic == configIngressClass
 	}
-	return false
+	// Ingresses without class belong to the default IngressClass.
+	return c.isDefaultIngressClass(configIngressClass)
+}
+
+// isDefaultIngressClass reports whether the IngressClass is marked as the
+// default one of the cluster.
+func (c *ingressController) isDefaultIngressClass(name string) bool {
+	if c.IngressClassLister == nil {
+		return false
+	}
+	ic, err := c.IngressClassLister.Get(name)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			log.Errorw("failed to get IngressClass",
+				zap.Error(err),
+				zap.String("name", name),
+			)
+		}
+		return false
+	}
+	return ic.Annotations[networkingv1.AnnotationIsDefaultIngressClass] == "true"
+}
+
+func (c *ingressController) onIngressClassAdd(obj interface{}) {
+	c.onIngressClassChange(nil, obj.(*networkingv1.IngressClass))
+}
+
+func (c *ingressController) onIngressClassUpdate(oldObj, newObj interface{}) {
+	c.onIngressClassChange(oldObj.(*networkingv1.IngressClass), newObj.(*networkingv1.IngressClass))
+}
+
+// onIngressClassChange resyncs the Ingresses once the IngressClass of the
+// controller becomes the default one, so that the Ingresses without class
+// are adopted. Ingresses which are abandoned when the marker is removed are
+// left as they are, like the ones whose class is changed. The Ingresses are
+// resynced as well when the parameters of the IngressClass change.
+func (c *ingressController) onIngressClassChange(prev, curr *networkingv1.IngressClass) {
+	configIngressClass := c.Kubernetes.IngressClass
+	if configIngressClass == config.IngressClassApisixAndAll {
+		configIngressClass = config.IngressClass
+	}
+	if curr.Name != configIngressClass {
+		return
+	}
+	isDefault := func(ic *networkingv1.IngressClass) bool {
+		return ic != nil && ic.Annotations[networkingv1.AnnotationIsDefaultIngressClass] == "true"
+	}
+	if isDefault(curr) && !isDefault(prev) {
+		log.Infow("IngressClass is marked as default, adopt Ingresses without class",
+			zap.String("ingress_class", curr.Name),
+		)
+		c.ResourceSync("")
+		return
+	}
+	if prev != nil && !reflect.DeepEqual(prev.Spec.Parameters, curr.Spec.Parameters) {
+		log.Infow("parameters of IngressClass changed, resync Ingresses",
+			zap.String("ingress_class", curr.Name),
+		)
+		c.ResourceSync("")
+	}
+}
+
+// onApisixClusterConfigUpdate resyncs the Ingresses when the Ingress defaults
+// of the ApisixClusterConfig referred by the IngressClass change.
+func (c *ingressController) onApisixClusterConfigUpdate(oldObj, newObj interface{}) {
+	prev := kube.MustNewApisixClusterConfig(oldObj)
+	curr := kube.MustNewApisixClusterConfig(newObj)
+	if reflect.DeepEqual(prev.V2().Spec.IngressDefaults, curr.V2().Spec.IngressDefaults) {
+		return
+	}
+	acc := c.translator.IngressClassParameters()
+	if acc == nil || acc.Name != curr.V2().Name {
+		return
+	}
+	log.Infow("ingress defaults of ApisixClusterConfig changed, resync Ingresses",
+		zap.String("apisix_cluster_config", acc.Name),
+	)
+	c.ResourceSync("")
 }
 
 // ResourceSync syncs Ingress resources within namespace to workqueue.
@@ -486,7 +732,7 @@ func (c *ingressController) ResourceSync(namespace string) {
 		}
 		ing := kube.MustNewIngress(obj)
 		if !c.isIngressEffective(ing) {
-			return
+			continue
 		}
 		log.Debugw("ingress add event arrived",
 			zap.Any("object", obj),
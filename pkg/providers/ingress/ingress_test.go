@@ -21,6 +21,8 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/apache/apisix-ingress-controller/pkg/config"
 	"github.com/apache/apisix-ingress-controller/pkg/kube"
@@ -132,3 +134,49 @@ func TestIsIngressEffective(t *testing.T) {
 	// Spec.IngressClassName takes the precedence.
 	assert.Equal(t, false, c.isIngressEffective(ing))
 }
+
+func TestIsIngressEffectiveWithDefaultIngressClass(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	informersFactory := informers.NewSharedInformerFactory(client, 0)
+	ingressClassInformer := informersFactory.Networking().V1().IngressClasses().Informer()
+
+	c := &ingressController{
+		ingressCommon: &ingressCommon{
+			Common: &providertypes.Common{
+				Config: config.NewDefaultConfig(),
+				ListerInformer: &providertypes.ListerInformer{
+					IngressClassLister: informersFactory.Networking().V1().IngressClasses().Lister(),
+				},
+			},
+		},
+	}
+	ing, err := kube.NewIngress(&networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "v1-ing",
+		},
+	})
+	assert.Nil(t, err)
+	// No IngressClass.
+	assert.Equal(t, false, c.isIngressEffective(ing))
+
+	ic := &networkingv1.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "apisix",
+		},
+	}
+	assert.Nil(t, ingressClassInformer.GetIndexer().Add(ic))
+	assert.Equal(t, false, c.isIngressEffective(ing))
+
+	ic = ic.DeepCopy()
+	ic.Annotations = map[string]string{
+		networkingv1.AnnotationIsDefaultIngressClass: "true",
+	}
+	assert.Nil(t, ingressClassInformer.GetIndexer().Update(ic))
+	assert.Equal(t, true, c.isIngressEffective(ing))
+
+	// Ingresses with a class are not affected.
+	cn := "nginx"
+	ing.V1().Spec.IngressClassName = &cn
+	assert.Equal(t, false, c.isIngressEffective(ing))
+}
@@ -21,6 +21,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 
+	"github.com/apache/apisix-ingress-controller/pkg/api/validation"
 	apisixtranslation "github.com/apache/apisix-ingress-controller/pkg/providers/apisix/translation"
 	ingresstranslation "github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/k8s/namespace"
@@ -70,9 +71,19 @@ func NewProvider(common *providertypes.Common, namespaceProvider namespace.Watch
 			Apisix:        common.APISIX,
 			ClusterName:   common.Config.APISIX.DefaultClusterName,
 			ServiceLister: common.SvcLister,
+			IngressLister: common.IngressLister,
+
+			IngressClassName:          common.Config.Kubernetes.IngressClass,
+			IngressClassLister:        common.IngressClassLister,
+			ApisixClusterConfigLister: common.ApisixClusterConfigLister,
+			ApisixUpstreamLister:      common.ApisixUpstreamLister,
 		}, translator, apisixTranslator),
 	}
 
+	if common.Config.Kubernetes.EnableAdmission {
+		validation.SetIngressTranslator(c.translator)
+	}
+
 	p.ingressController = newIngressController(c)
 
 	return p, nil
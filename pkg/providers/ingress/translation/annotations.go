@@ -15,11 +15,14 @@
 package translation
 
 import (
+	"github.com/hashicorp/go-multierror"
 	"github.com/imdario/mergo"
 	"go.uber.org/zap"
 
 	"github.com/apache/apisix-ingress-controller/pkg/log"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations/canary"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations/genericplugins"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations/pluginconfig"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations/plugins"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations/regex"
@@ -37,6 +40,8 @@ type Ingress struct {
 	PluginConfigName string
 	ServiceNamespace string
 	Upstream         upstream.Upstream
+	Canary           canary.Canary
+	GenericPlugins   genericplugins.GenericPlugins
 }
 
 var (
@@ -47,20 +52,23 @@ var (
 		"PluginConfigName": pluginconfig.NewParser(),
 		"ServiceNamespace": servicenamespace.NewParser(),
 		"Upstream":         upstream.NewParser(),
+		"Canary":           canary.NewParser(),
+		"GenericPlugins":   genericplugins.NewParser(),
 	}
 )
 
-func (t *translator) TranslateAnnotations(anno map[string]string) *Ingress {
+// TranslateAnnotations parses the annotations of an Ingress. Annotations
+// which are failed to parse are skipped and reported by the returned error,
+// the Ingress is still filled by the rest of them.
+func (t *translator) TranslateAnnotations(anno map[string]string) (*Ingress, error) {
+	var merr *multierror.Error
 	ing := &Ingress{}
 	extractor := annotations.NewExtractor(anno)
 	data := make(map[string]interface{})
 	for name, parser := range _parsers {
 		out, err := parser.Parse(extractor)
 		if err != nil {
-			log.Warnw("failed to parse annotations",
-				zap.Error(err),
-			)
-			continue
+			merr = multierror.Append(merr, err)
 		}
 		if out != nil {
 			data[name] = out
@@ -70,5 +78,5 @@ func (t *translator) TranslateAnnotations(anno map[string]string) *Ingress {
 	if err != nil {
 		log.Errorw("unexpected error merging extracted annotations", zap.Error(err))
 	}
-	return ing
+	return ing, merr.ErrorOrNil()
 }
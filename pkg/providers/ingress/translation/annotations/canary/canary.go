@@ -0,0 +1,71 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package canary
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations"
+)
+
+func NewParser() annotations.IngressAnnotationsParser {
+	return &Canary{}
+}
+
+// Canary is the canary release setting of an Ingress. A canary Ingress
+// doesn't own any routes, its backend is merged into the route of the
+// primary Ingress which has the same host and path.
+type Canary struct {
+	Enable bool
+	// Weight is the percentage of requests which are sent to the canary
+	// backend when neither the header nor the cookie decides it.
+	Weight int
+	// Header is the request header to route by, the request is sent to the
+	// canary backend if the header equals to HeaderValue, or "always" when
+	// HeaderValue is empty. "never" keeps the request away from the canary.
+	Header      string
+	HeaderValue string
+	// Cookie is the cookie to route by, "always" and "never" take the same
+	// effect as Header.
+	Cookie string
+}
+
+// Parse returns a new Canary rather than filling c, since the parser is shared
+// by all the Ingresses.
+func (c *Canary) Parse(e annotations.Extractor) (interface{}, error) {
+	if !e.GetBoolAnnotation(annotations.AnnotationsCanary) {
+		return nil, nil
+	}
+	canary := Canary{
+		Enable:      true,
+		Header:      e.GetStringAnnotation(annotations.AnnotationsCanaryByHeader),
+		HeaderValue: e.GetStringAnnotation(annotations.AnnotationsCanaryHeaderValue),
+		Cookie:      e.GetStringAnnotation(annotations.AnnotationsCanaryByCookie),
+	}
+
+	weight := e.GetStringAnnotation(annotations.AnnotationsCanaryWeight)
+	if weight != "" {
+		w, err := strconv.Atoi(weight)
+		if err != nil {
+			return canary, fmt.Errorf("could not parse canary weight as an integer: %s", err.Error())
+		}
+		if w < 0 || w > 100 {
+			return canary, fmt.Errorf("canary weight %d is out of range [0, 100]", w)
+		}
+		canary.Weight = w
+	}
+	return canary, nil
+}
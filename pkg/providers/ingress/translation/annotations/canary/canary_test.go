@@ -0,0 +1,78 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package canary_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations/canary"
+)
+
+func TestCanaryParsing(t *testing.T) {
+	anno := map[string]string{
+		annotations.AnnotationsCanaryWeight: "20",
+	}
+	p := canary.NewParser()
+	out, err := p.Parse(annotations.NewExtractor(anno))
+	assert.Nil(t, err, "checking given error")
+	assert.Nil(t, out, "canary is not enabled")
+
+	anno[annotations.AnnotationsCanary] = "true"
+	anno[annotations.AnnotationsCanaryByHeader] = "X-Canary"
+	anno[annotations.AnnotationsCanaryHeaderValue] = "yes"
+	anno[annotations.AnnotationsCanaryByCookie] = "canary"
+	out, err = p.Parse(annotations.NewExtractor(anno))
+	assert.Nil(t, err, "checking given error")
+	c, ok := out.(canary.Canary)
+	if !ok {
+		t.Fatalf("could not parse canary")
+	}
+	assert.True(t, c.Enable)
+	assert.Equal(t, 20, c.Weight)
+	assert.Equal(t, "X-Canary", c.Header)
+	assert.Equal(t, "yes", c.HeaderValue)
+	assert.Equal(t, "canary", c.Cookie)
+
+	anno[annotations.AnnotationsCanaryWeight] = "abc"
+	_, err = canary.NewParser().Parse(annotations.NewExtractor(anno))
+	assert.NotNil(t, err, "checking given error")
+
+	anno[annotations.AnnotationsCanaryWeight] = "101"
+	_, err = canary.NewParser().Parse(annotations.NewExtractor(anno))
+	assert.NotNil(t, err, "checking given error")
+}
+
+func TestCanaryParsingIsolation(t *testing.T) {
+	p := canary.NewParser()
+	out, err := p.Parse(annotations.NewExtractor(map[string]string{
+		annotations.AnnotationsCanary:       "true",
+		annotations.AnnotationsCanaryWeight: "30",
+	}))
+	assert.Nil(t, err, "checking given error")
+	assert.Equal(t, 30, out.(canary.Canary).Weight)
+
+	// The weight of the previous Ingress doesn't leak into the next one.
+	out, err = p.Parse(annotations.NewExtractor(map[string]string{
+		annotations.AnnotationsCanary:         "true",
+		annotations.AnnotationsCanaryByHeader: "X-Canary",
+	}))
+	assert.Nil(t, err, "checking given error")
+	c := out.(canary.Canary)
+	assert.Equal(t, 0, c.Weight)
+	assert.Equal(t, "X-Canary", c.Header)
+}
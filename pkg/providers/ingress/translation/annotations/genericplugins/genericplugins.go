@@ -0,0 +1,124 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package genericplugins
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"sigs.k8s.io/yaml"
+
+	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+func NewParser() annotations.IngressAnnotationsParser {
+	return &GenericPlugins{}
+}
+
+// GenericPlugins are the APISIX plugins configured by the
+// "plugin.<name>" and "plugin.<name>.enable" annotations.
+type GenericPlugins struct {
+	// Plugins are the enabled plugins and their configs.
+	Plugins apisixv1.Plugins
+	// Disabled are the plugins turned off explicitly, they are removed
+	// even if other annotations enable them.
+	Disabled []string
+}
+
+func (g *GenericPlugins) Parse(e annotations.Extractor) (interface{}, error) {
+	anno := e.GetAnnotationsWithPrefix(annotations.AnnotationsPluginPrefix)
+	if len(anno) == 0 {
+		return nil, nil
+	}
+	keys := make([]string, 0, len(anno))
+	for key := range anno {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var merr *multierror.Error
+	configs := make(map[string]interface{})
+	enables := make(map[string]bool)
+	for _, key := range keys {
+		value := anno[key]
+		if name := strings.TrimSuffix(key, annotations.AnnotationsPluginEnableSuffix); name != key {
+			switch value {
+			case "true":
+				enables[name] = true
+			case "false":
+				enables[name] = false
+			default:
+				merr = multierror.Append(merr, fmt.Errorf("invalid %s%s %q, should be true or false",
+					annotations.AnnotationsPluginPrefix, key, value))
+			}
+			continue
+		}
+		if key == "" {
+			merr = multierror.Append(merr, fmt.Errorf("missing plugin name in annotation %s", annotations.AnnotationsPluginPrefix))
+			continue
+		}
+		config, err := decodeConfig(value)
+		if err != nil {
+			merr = multierror.Append(merr, fmt.Errorf("invalid %s%s: %s", annotations.AnnotationsPluginPrefix, key, err))
+			continue
+		}
+		configs[key] = config
+	}
+
+	// The parser is shared by all the Ingresses, the result must not be kept in it.
+	plugins := GenericPlugins{
+		Plugins: make(apisixv1.Plugins),
+	}
+	for name, config := range configs {
+		if enable, ok := enables[name]; ok && !enable {
+			continue
+		}
+		plugins.Plugins[name] = config
+	}
+	for _, key := range keys {
+		name := strings.TrimSuffix(key, annotations.AnnotationsPluginEnableSuffix)
+		enable, ok := enables[name]
+		if name == key || !ok {
+			continue
+		}
+		if !enable {
+			plugins.Disabled = append(plugins.Disabled, name)
+		} else if _, ok := plugins.Plugins[name]; !ok {
+			plugins.Plugins[name] = make(map[string]interface{})
+		}
+	}
+	return plugins, merr.ErrorOrNil()
+}
+
+// decodeConfig decodes the plugin config in JSON or YAML, an empty value
+// is an empty config.
+func decodeConfig(value string) (map[string]interface{}, error) {
+	config := make(map[string]interface{})
+	if strings.TrimSpace(value) == "" {
+		return config, nil
+	}
+	data, err := yaml.YAMLToJSON([]byte(value))
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("should be a JSON or YAML object")
+	}
+	return config, nil
+}
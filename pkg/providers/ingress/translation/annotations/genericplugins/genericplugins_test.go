@@ -0,0 +1,85 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package genericplugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+func TestParse(t *testing.T) {
+	anno := map[string]string{
+		annotations.AnnotationsPluginPrefix + "limit-count":                                            `{"count": 10, "time_window": 60}`,
+		annotations.AnnotationsPluginPrefix + "echo":                                                   "before_body: hello\nafter_body: world",
+		annotations.AnnotationsPluginPrefix + "prometheus" + annotations.AnnotationsPluginEnableSuffix: "true",
+		annotations.AnnotationsPluginPrefix + "gzip":                                                   "comp_level: 6",
+		annotations.AnnotationsPluginPrefix + "gzip" + annotations.AnnotationsPluginEnableSuffix:       "false",
+		annotations.AnnotationsPluginPrefix + "cors" + annotations.AnnotationsPluginEnableSuffix:       "false",
+		annotations.AnnotationsEnableWebSocket:                                                         "true",
+	}
+	out, err := NewParser().Parse(annotations.NewExtractor(anno))
+	assert.Nil(t, err)
+	plugins := out.(GenericPlugins)
+	assert.Equal(t, apisixv1.Plugins{
+		"limit-count": map[string]interface{}{"count": float64(10), "time_window": float64(60)},
+		"echo":        map[string]interface{}{"before_body": "hello", "after_body": "world"},
+		"prometheus":  map[string]interface{}{},
+	}, plugins.Plugins)
+	assert.Equal(t, []string{"cors", "gzip"}, plugins.Disabled)
+
+	out, err = NewParser().Parse(annotations.NewExtractor(nil))
+	assert.Nil(t, err)
+	assert.Nil(t, out)
+}
+
+func TestParseInvalid(t *testing.T) {
+	anno := map[string]string{
+		annotations.AnnotationsPluginPrefix + "echo":                                                   "[1, 2]",
+		annotations.AnnotationsPluginPrefix + "limit-count":                                            `{"count": 10`,
+		annotations.AnnotationsPluginPrefix + "prometheus" + annotations.AnnotationsPluginEnableSuffix: "yes",
+		annotations.AnnotationsPluginPrefix + "gzip":                                                   "comp_level: 6",
+	}
+	out, err := NewParser().Parse(annotations.NewExtractor(anno))
+	assert.Contains(t, err.Error(), annotations.AnnotationsPluginPrefix+"echo")
+	assert.Contains(t, err.Error(), annotations.AnnotationsPluginPrefix+"limit-count")
+	assert.Contains(t, err.Error(), annotations.AnnotationsPluginPrefix+"prometheus.enable")
+	assert.Equal(t, apisixv1.Plugins{
+		"gzip": map[string]interface{}{"comp_level": float64(6)},
+	}, out.(GenericPlugins).Plugins)
+}
+
+func TestParseIsolation(t *testing.T) {
+	p := NewParser()
+	out, err := p.Parse(annotations.NewExtractor(map[string]string{
+		annotations.AnnotationsPluginPrefix + "echo":                                             "before_body: hello",
+		annotations.AnnotationsPluginPrefix + "cors" + annotations.AnnotationsPluginEnableSuffix: "false",
+	}))
+	assert.Nil(t, err)
+	plugins := out.(GenericPlugins)
+	assert.Equal(t, []string{"cors"}, plugins.Disabled)
+
+	// Nothing is carried over from the previous Ingress.
+	out, err = p.Parse(annotations.NewExtractor(map[string]string{
+		annotations.AnnotationsPluginPrefix + "gzip" + annotations.AnnotationsPluginEnableSuffix: "false",
+	}))
+	assert.Nil(t, err)
+	plugins = out.(GenericPlugins)
+	assert.Equal(t, apisixv1.Plugins{}, plugins.Plugins)
+	assert.Equal(t, []string{"gzip"}, plugins.Disabled)
+}
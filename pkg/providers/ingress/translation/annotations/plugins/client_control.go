@@ -0,0 +1,80 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package plugins
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+type clientControl struct{}
+
+// NewClientControlHandler creates a handler to convert annotations about
+// the client request body size to APISIX client-control plugin.
+func NewClientControlHandler() PluginAnnotationsHandler {
+	return &clientControl{}
+}
+
+func (c *clientControl) PluginName() string {
+	return "client-control"
+}
+
+func (c *clientControl) Handle(e annotations.Extractor) (interface{}, error) {
+	value := e.GetStringAnnotation(annotations.AnnotationsProxyBodySize)
+	if value == "" {
+		return nil, nil
+	}
+	size, err := parseSize(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q, should be a size like 1024, 8k, 8m or 1g",
+			annotations.AnnotationsProxyBodySize, value)
+	}
+	return &apisixv1.ClientControlConfig{
+		MaxBodySize: size,
+	}, nil
+}
+
+// parseSize parses the size in the same format as nginx, which is a
+// number of bytes, optionally followed by k, m or g (case-insensitive).
+func parseSize(value string) (int64, error) {
+	unit := int64(1)
+	switch strings.ToLower(value[len(value)-1:]) {
+	case "k":
+		unit = 1 << 10
+	case "m":
+		unit = 1 << 20
+	case "g":
+		unit = 1 << 30
+	}
+	if unit != 1 {
+		value = value[:len(value)-1]
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("negative size")
+	}
+	if n > math.MaxInt64/unit {
+		return 0, fmt.Errorf("size overflows")
+	}
+	return n * unit, nil
+}
@@ -0,0 +1,58 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package plugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+func TestClientControlHandler(t *testing.T) {
+	p := NewClientControlHandler()
+	assert.Equal(t, "client-control", p.PluginName())
+
+	sizes := map[string]int64{
+		"0":    0,
+		"1024": 1024,
+		"8k":   8 << 10,
+		"8M":   8 << 20,
+		"1g":   1 << 30,
+		// The largest size which doesn't overflow.
+		"8589934591g": 8589934591 << 30,
+	}
+	for value, size := range sizes {
+		out, err := p.Handle(annotations.NewExtractor(map[string]string{
+			annotations.AnnotationsProxyBodySize: value,
+		}))
+		assert.Nil(t, err, value)
+		assert.Equal(t, size, out.(*apisixv1.ClientControlConfig).MaxBodySize, value)
+	}
+
+	for _, value := range []string{"m", "-1k", "8mb", "8589934592g", "9223372036854775808"} {
+		out, err := p.Handle(annotations.NewExtractor(map[string]string{
+			annotations.AnnotationsProxyBodySize: value,
+		}))
+		assert.Nil(t, out, value)
+		assert.Contains(t, err.Error(), annotations.AnnotationsProxyBodySize, value)
+	}
+
+	out, err := p.Handle(annotations.NewExtractor(nil))
+	assert.Nil(t, err)
+	assert.Nil(t, out)
+}
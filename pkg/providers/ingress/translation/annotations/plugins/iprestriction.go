@@ -15,6 +15,9 @@
 package plugins
 
 import (
+	"fmt"
+	"net"
+
 	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations"
 	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
 )
@@ -35,6 +38,12 @@ func (i *ipRestriction) Handle(e annotations.Extractor) (interface{}, error) {
 	var plugin apisixv1.IPRestrictConfig
 	allowlist := e.GetStringsAnnotation(annotations.AnnotationsAllowlistSourceRange)
 	blocklist := e.GetStringsAnnotation(annotations.AnnotationsBlocklistSourceRange)
+	if err := validateSourceRanges(annotations.AnnotationsAllowlistSourceRange, allowlist); err != nil {
+		return nil, err
+	}
+	if err := validateSourceRanges(annotations.AnnotationsBlocklistSourceRange, blocklist); err != nil {
+		return nil, err
+	}
 	if allowlist != nil || blocklist != nil {
 		plugin.Allowlist = allowlist
 		plugin.Blocklist = blocklist
@@ -42,3 +51,17 @@ func (i *ipRestriction) Handle(e annotations.Extractor) (interface{}, error) {
 	}
 	return nil, nil
 }
+
+// validateSourceRanges checks that every item is either an IP address or
+// a CIDR, which is what APISIX ip-restriction plugin accepts.
+func validateSourceRanges(name string, ranges []string) error {
+	for _, r := range ranges {
+		if net.ParseIP(r) != nil {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(r); err != nil {
+			return fmt.Errorf("invalid %s item %q, should be an IP or a CIDR", name, r)
+		}
+	}
+	return nil
+}
@@ -53,3 +53,20 @@ func TestIPRestrictionHandler(t *testing.T) {
 	assert.Nil(t, err, "checking given error")
 	assert.Nil(t, out, "checking the given ip-restrction plugin config")
 }
+
+func TestIPRestrictionHandlerInvalidRange(t *testing.T) {
+	anno := map[string]string{
+		annotations.AnnotationsAllowlistSourceRange: "10.2.2.2,192.168.0.0/33",
+	}
+	p := NewIPRestrictionHandler()
+	out, err := p.Handle(annotations.NewExtractor(anno))
+	assert.Nil(t, out)
+	assert.Contains(t, err.Error(), "192.168.0.0/33")
+
+	anno = map[string]string{
+		annotations.AnnotationsBlocklistSourceRange: "localhost",
+	}
+	out, err = p.Handle(annotations.NewExtractor(anno))
+	assert.Nil(t, out)
+	assert.Contains(t, err.Error(), "localhost")
+}
@@ -0,0 +1,163 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package plugins
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+const (
+	// _limitKey limits the requests by the client address.
+	_limitKey     = "remote_addr"
+	_limitKeyType = "var"
+	// _defaultLimitBurstMultiplier is the same as the default of ingress-nginx.
+	_defaultLimitBurstMultiplier = 5
+	// _defaultLimitConnDelay is the delay in seconds of the requests
+	// which are over the connection limit but within the burst.
+	_defaultLimitConnDelay = 0.1
+)
+
+type limitReq struct{}
+
+// NewLimitReqHandler creates a handler to convert annotations about
+// the requests per second limit to APISIX limit-req plugin.
+func NewLimitReqHandler() PluginAnnotationsHandler {
+	return &limitReq{}
+}
+
+func (l *limitReq) PluginName() string {
+	return "limit-req"
+}
+
+func (l *limitReq) Handle(e annotations.Extractor) (interface{}, error) {
+	rate, err := parsePositiveInt(e, annotations.AnnotationsLimitRPS)
+	if err != nil || rate == 0 {
+		return nil, err
+	}
+	multiplier, err := parsePositiveInt(e, annotations.AnnotationsLimitBurstMultiplier)
+	if err != nil {
+		return nil, err
+	}
+	if multiplier == 0 {
+		multiplier = _defaultLimitBurstMultiplier
+	}
+	code, err := parseRejectedCode(e)
+	if err != nil {
+		return nil, err
+	}
+	return &apisixv1.LimitReqConfig{
+		Rate:         rate,
+		Burst:        rate * multiplier,
+		Key:          _limitKey,
+		KeyType:      _limitKeyType,
+		RejectedCode: code,
+		NoDelay:      true,
+	}, nil
+}
+
+type limitCount struct{}
+
+// NewLimitCountHandler creates a handler to convert annotations about
+// the requests per minute limit to APISIX limit-count plugin.
+func NewLimitCountHandler() PluginAnnotationsHandler {
+	return &limitCount{}
+}
+
+func (l *limitCount) PluginName() string {
+	return "limit-count"
+}
+
+func (l *limitCount) Handle(e annotations.Extractor) (interface{}, error) {
+	count, err := parsePositiveInt(e, annotations.AnnotationsLimitRPM)
+	if err != nil || count == 0 {
+		return nil, err
+	}
+	code, err := parseRejectedCode(e)
+	if err != nil {
+		return nil, err
+	}
+	return &apisixv1.LimitCountConfig{
+		Count:        count,
+		TimeWindow:   60,
+		Key:          _limitKey,
+		KeyType:      _limitKeyType,
+		RejectedCode: code,
+	}, nil
+}
+
+type limitConn struct{}
+
+// NewLimitConnHandler creates a handler to convert annotations about
+// the concurrent connections limit to APISIX limit-conn plugin.
+func NewLimitConnHandler() PluginAnnotationsHandler {
+	return &limitConn{}
+}
+
+func (l *limitConn) PluginName() string {
+	return "limit-conn"
+}
+
+func (l *limitConn) Handle(e annotations.Extractor) (interface{}, error) {
+	conn, err := parsePositiveInt(e, annotations.AnnotationsLimitConnections)
+	if err != nil || conn == 0 {
+		return nil, err
+	}
+	code, err := parseRejectedCode(e)
+	if err != nil {
+		return nil, err
+	}
+	return &apisixv1.LimitConnConfig{
+		Conn:             conn,
+		Burst:            0,
+		DefaultConnDelay: _defaultLimitConnDelay,
+		Key:              _limitKey,
+		KeyType:          _limitKeyType,
+		RejectedCode:     code,
+	}, nil
+}
+
+// parsePositiveInt parses the annotation as a positive integer, zero is
+// returned when the annotation is missing.
+func parsePositiveInt(e annotations.Extractor, name string) (int, error) {
+	value := e.GetStringAnnotation(name)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid %s %q, should be a positive integer", name, value)
+	}
+	return n, nil
+}
+
+// parseRejectedCode parses the status code returned to the clients which
+// are over the limit, it's 503 by default, the same as ingress-nginx.
+func parseRejectedCode(e annotations.Extractor) (int, error) {
+	value := e.GetStringAnnotation(annotations.AnnotationsLimitRejectedCode)
+	if value == "" {
+		return http.StatusServiceUnavailable, nil
+	}
+	code, err := strconv.Atoi(value)
+	if err != nil || code < 200 || code > 599 {
+		return 0, fmt.Errorf("invalid %s %q, should be an integer between 200 and 599",
+			annotations.AnnotationsLimitRejectedCode, value)
+	}
+	return code, nil
+}
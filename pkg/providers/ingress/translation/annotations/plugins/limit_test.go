@@ -0,0 +1,100 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package plugins
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+func TestLimitReqHandler(t *testing.T) {
+	anno := map[string]string{
+		annotations.AnnotationsLimitRPS: "10",
+	}
+	p := NewLimitReqHandler()
+	out, err := p.Handle(annotations.NewExtractor(anno))
+	assert.Nil(t, err)
+	config := out.(*apisixv1.LimitReqConfig)
+	assert.Equal(t, 10, config.Rate)
+	assert.Equal(t, 50, config.Burst)
+	assert.Equal(t, "remote_addr", config.Key)
+	assert.Equal(t, http.StatusServiceUnavailable, config.RejectedCode)
+	assert.True(t, config.NoDelay)
+	assert.Equal(t, "limit-req", p.PluginName())
+
+	anno[annotations.AnnotationsLimitBurstMultiplier] = "2"
+	anno[annotations.AnnotationsLimitRejectedCode] = "429"
+	out, err = p.Handle(annotations.NewExtractor(anno))
+	assert.Nil(t, err)
+	config = out.(*apisixv1.LimitReqConfig)
+	assert.Equal(t, 20, config.Burst)
+	assert.Equal(t, http.StatusTooManyRequests, config.RejectedCode)
+
+	anno[annotations.AnnotationsLimitBurstMultiplier] = "-1"
+	out, err = p.Handle(annotations.NewExtractor(anno))
+	assert.Nil(t, out)
+	assert.Contains(t, err.Error(), annotations.AnnotationsLimitBurstMultiplier)
+
+	out, err = p.Handle(annotations.NewExtractor(nil))
+	assert.Nil(t, err)
+	assert.Nil(t, out)
+}
+
+func TestLimitCountHandler(t *testing.T) {
+	anno := map[string]string{
+		annotations.AnnotationsLimitRPM: "300",
+	}
+	p := NewLimitCountHandler()
+	out, err := p.Handle(annotations.NewExtractor(anno))
+	assert.Nil(t, err)
+	config := out.(*apisixv1.LimitCountConfig)
+	assert.Equal(t, 300, config.Count)
+	assert.Equal(t, 60, config.TimeWindow)
+	assert.Equal(t, "limit-count", p.PluginName())
+
+	anno[annotations.AnnotationsLimitRPM] = "abc"
+	out, err = p.Handle(annotations.NewExtractor(anno))
+	assert.Nil(t, out)
+	assert.Contains(t, err.Error(), annotations.AnnotationsLimitRPM)
+
+	anno[annotations.AnnotationsLimitRPM] = "300"
+	anno[annotations.AnnotationsLimitRejectedCode] = "600"
+	out, err = p.Handle(annotations.NewExtractor(anno))
+	assert.Nil(t, out)
+	assert.Contains(t, err.Error(), annotations.AnnotationsLimitRejectedCode)
+}
+
+func TestLimitConnHandler(t *testing.T) {
+	anno := map[string]string{
+		annotations.AnnotationsLimitConnections: "5",
+	}
+	p := NewLimitConnHandler()
+	out, err := p.Handle(annotations.NewExtractor(anno))
+	assert.Nil(t, err)
+	config := out.(*apisixv1.LimitConnConfig)
+	assert.Equal(t, 5, config.Conn)
+	assert.Equal(t, 0, config.Burst)
+	assert.Equal(t, "limit-conn", p.PluginName())
+
+	anno[annotations.AnnotationsLimitConnections] = "0"
+	out, err = p.Handle(annotations.NewExtractor(anno))
+	assert.Nil(t, out)
+	assert.Contains(t, err.Error(), annotations.AnnotationsLimitConnections)
+}
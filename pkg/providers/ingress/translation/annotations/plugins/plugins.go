@@ -15,9 +15,8 @@
 package plugins
 
 import (
-	"go.uber.org/zap"
+	"github.com/hashicorp/go-multierror"
 
-	"github.com/apache/apisix-ingress-controller/pkg/log"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations"
 	apisix "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
 )
@@ -45,6 +44,11 @@ var (
 		NewCSRFHandler(),
 		NewHttpMethodHandler(),
 		NewResponseRewriteHandler(),
+		NewLimitReqHandler(),
+		NewLimitCountHandler(),
+		NewLimitConnHandler(),
+		NewClientControlHandler(),
+		NewProxyControlHandler(),
 	}
 )
 
@@ -55,18 +59,19 @@ func NewParser() annotations.IngressAnnotationsParser {
 }
 
 func (p *plugins) Parse(e annotations.Extractor) (interface{}, error) {
+	var merr *multierror.Error
 	plugins := make(apisix.Plugins)
 	for _, handler := range _handlers {
 		out, err := handler.Handle(e)
 		if err != nil {
-			log.Warnw("failed to handle annotations",
-				zap.Error(err),
-			)
+			// Keep going so that a single malformed annotation doesn't
+			// drop the plugins converted from the others.
+			merr = multierror.Append(merr, err)
 			continue
 		}
 		if out != nil {
 			plugins[handler.PluginName()] = out
 		}
 	}
-	return plugins, nil
+	return plugins, merr.ErrorOrNil()
 }
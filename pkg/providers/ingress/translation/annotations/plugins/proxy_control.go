@@ -0,0 +1,49 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package plugins
+
+import (
+	"fmt"
+
+	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+type proxyControl struct{}
+
+// NewProxyControlHandler creates a handler to convert annotations about
+// the request buffering to APISIX proxy-control plugin.
+func NewProxyControlHandler() PluginAnnotationsHandler {
+	return &proxyControl{}
+}
+
+func (p *proxyControl) PluginName() string {
+	return "proxy-control"
+}
+
+func (p *proxyControl) Handle(e annotations.Extractor) (interface{}, error) {
+	value := e.GetStringAnnotation(annotations.AnnotationsProxyRequestBuffering)
+	switch value {
+	case "":
+		return nil, nil
+	case "on":
+		return &apisixv1.ProxyControlConfig{RequestBuffering: true}, nil
+	case "off":
+		return &apisixv1.ProxyControlConfig{RequestBuffering: false}, nil
+	default:
+		return nil, fmt.Errorf("invalid %s %q, should be on or off",
+			annotations.AnnotationsProxyRequestBuffering, value)
+	}
+}
@@ -15,6 +15,7 @@
 package plugins
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -38,15 +39,21 @@ func (r *redirect) Handle(e annotations.Extractor) (interface{}, error) {
 	var plugin apisixv1.RedirectConfig
 	plugin.HttpToHttps = e.GetBoolAnnotation(annotations.AnnotationsHttpToHttps)
 	plugin.URI = e.GetStringAnnotation(annotations.AnnotationsHttpRedirect)
-	// Transformation fail defaults to 0.
-	plugin.RetCode, _ = strconv.Atoi(e.GetStringAnnotation(annotations.AnnotationsHttpRedirectCode))
+	if code := e.GetStringAnnotation(annotations.AnnotationsHttpRedirectCode); code != "" {
+		retCode, err := strconv.Atoi(code)
+		if err != nil || retCode < http.StatusMovedPermanently || retCode > http.StatusPermanentRedirect {
+			return nil, fmt.Errorf("invalid %s %q, should be an integer between %d and %d",
+				annotations.AnnotationsHttpRedirectCode, code, http.StatusMovedPermanently, http.StatusPermanentRedirect)
+		}
+		plugin.RetCode = retCode
+	}
 	// To avoid empty redirect plugin config, adding the check about the redirect.
 	if plugin.HttpToHttps {
 		return &plugin, nil
 	}
 	if plugin.URI != "" {
-		// Default is http.StatusMovedPermanently, the allowed value is between http.StatusMultipleChoices and http.StatusPermanentRedirect.
-		if plugin.RetCode < http.StatusMovedPermanently || plugin.RetCode > http.StatusPermanentRedirect {
+		// Default is http.StatusMovedPermanently, the allowed value is between http.StatusMovedPermanently and http.StatusPermanentRedirect.
+		if plugin.RetCode == 0 {
 			plugin.RetCode = http.StatusMovedPermanently
 		}
 		return &plugin, nil
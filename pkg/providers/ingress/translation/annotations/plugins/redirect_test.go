@@ -0,0 +1,50 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package plugins
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+func TestRedirectHandler(t *testing.T) {
+	anno := map[string]string{
+		annotations.AnnotationsHttpRedirect: "/ip",
+	}
+	p := NewRedirectHandler()
+	out, err := p.Handle(annotations.NewExtractor(anno))
+	assert.Nil(t, err, "checking given error")
+	config := out.(*apisixv1.RedirectConfig)
+	assert.Equal(t, "/ip", config.URI)
+	assert.Equal(t, http.StatusMovedPermanently, config.RetCode)
+
+	anno[annotations.AnnotationsHttpRedirectCode] = "307"
+	out, err = p.Handle(annotations.NewExtractor(anno))
+	assert.Nil(t, err, "checking given error")
+	config = out.(*apisixv1.RedirectConfig)
+	assert.Equal(t, http.StatusTemporaryRedirect, config.RetCode)
+
+	for _, code := range []string{"200", "309", "abc"} {
+		anno[annotations.AnnotationsHttpRedirectCode] = code
+		out, err = p.Handle(annotations.NewExtractor(anno))
+		assert.Nil(t, out)
+		assert.Contains(t, err.Error(), code)
+	}
+}
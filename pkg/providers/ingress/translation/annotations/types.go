@@ -33,6 +33,13 @@ const (
 	AnnotationsUpstreamTimeoutConnect = AnnotationsPrefix + "upstream-connect-timeout"
 	AnnotationsUpstreamTimeoutRead    = AnnotationsPrefix + "upstream-read-timeout"
 	AnnotationsUpstreamTimeoutSend    = AnnotationsPrefix + "upstream-send-timeout"
+
+	// support canary releases by weight, header or cookie
+	AnnotationsCanary            = AnnotationsPrefix + "canary"
+	AnnotationsCanaryWeight      = AnnotationsPrefix + "canary-weight"
+	AnnotationsCanaryByHeader    = AnnotationsPrefix + "canary-by-header"
+	AnnotationsCanaryHeaderValue = AnnotationsPrefix + "canary-by-header-value"
+	AnnotationsCanaryByCookie    = AnnotationsPrefix + "canary-by-cookie"
 )
 
 const (
@@ -82,10 +89,28 @@ const (
 	AnnotationsHttpAllowMethods = AnnotationsPrefix + "http-allow-methods"
 	AnnotationsHttpBlockMethods = AnnotationsPrefix + "http-block-methods"
 
+	// limit-req, limit-count and limit-conn plugins
+	AnnotationsLimitRPS             = AnnotationsPrefix + "limit-rps"
+	AnnotationsLimitRPM             = AnnotationsPrefix + "limit-rpm"
+	AnnotationsLimitConnections     = AnnotationsPrefix + "limit-connections"
+	AnnotationsLimitBurstMultiplier = AnnotationsPrefix + "limit-burst-multiplier"
+	AnnotationsLimitRejectedCode    = AnnotationsPrefix + "limit-rejected-code"
+
+	// client-control plugin
+	AnnotationsProxyBodySize = AnnotationsPrefix + "proxy-body-size"
+
+	// proxy-control plugin
+	AnnotationsProxyRequestBuffering = AnnotationsPrefix + "proxy-request-buffering"
+
 	// key-auth plugin and basic-auth plugin
 	// auth-type: keyAuth | basicAuth
 	AnnotationsAuthType = AnnotationsPrefix + "auth-type"
 
+	// any APISIX plugin, "plugin.<name>" is the config in JSON or YAML,
+	// "plugin.<name>.enable" turns the plugin on or off.
+	AnnotationsPluginPrefix       = AnnotationsPrefix + "plugin."
+	AnnotationsPluginEnableSuffix = ".enable"
+
 	// support backend service cross namespace
 	AnnotationsSvcNamespace = AnnotationsPrefix + "svc-namespace"
 )
@@ -94,7 +119,8 @@ const (
 type IngressAnnotationsParser interface {
 	// Handle parses the target annotation and converts it to the type-agnostic structure.
 	// The return value might be nil since some features have an explicit switch, users should
-	// judge whether Handle is failed by the second error value.
+	// judge whether Handle is failed by the second error value. A parser may return a partial
+	// result along with the error.
 	Parse(Extractor) (interface{}, error)
 }
 
@@ -111,6 +137,9 @@ type Extractor interface {
 	// When value is "true", true will be given, other values will be treated as
 	// false.
 	GetBoolAnnotation(string) bool
+	// GetAnnotationsWithPrefix returns the annotations whose names start with
+	// the prefix, the prefix is trimmed from the names in the returned map.
+	GetAnnotationsWithPrefix(string) map[string]string
 }
 
 type extractor struct {
@@ -133,6 +162,20 @@ func (e *extractor) GetBoolAnnotation(name string) bool {
 	return e.annotations[name] == "true"
 }
 
+func (e *extractor) GetAnnotationsWithPrefix(prefix string) map[string]string {
+	var out map[string]string
+	for name, value := range e.annotations {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]string)
+		}
+		out[strings.TrimPrefix(name, prefix)] = value
+	}
+	return out
+}
+
 // NewExtractor creates an annotation extractor.
 func NewExtractor(annotations map[string]string) Extractor {
 	return &extractor{
@@ -28,7 +28,7 @@ func TestAnnotationsPlugins(t *testing.T) {
 		annotations.AnnotationsAuthType: "basicAuth",
 	}
 
-	ingress := (&translator{}).TranslateAnnotations(anno)
+	ingress, _ := (&translator{}).TranslateAnnotations(anno)
 	assert.Len(t, ingress.Plugins, 1)
 	assert.Equal(t, apisix.Plugins{
 		"basic-auth": &apisix.BasicAuthConfig{},
@@ -36,7 +36,7 @@ func TestAnnotationsPlugins(t *testing.T) {
 
 	anno[annotations.AnnotationsEnableCsrf] = "true"
 	anno[annotations.AnnotationsCsrfKey] = "csrf-key"
-	ingress = (&translator{}).TranslateAnnotations(anno)
+	ingress, _ = (&translator{}).TranslateAnnotations(anno)
 	assert.Len(t, ingress.Plugins, 2)
 	assert.Equal(t, apisix.Plugins{
 		"basic-auth": &apisix.BasicAuthConfig{},
@@ -51,7 +51,7 @@ func TestAnnotationsPluginConfigName(t *testing.T) {
 		annotations.AnnotationsPluginConfigName: "plugin-config-echo",
 	}
 
-	ingress := (&translator{}).TranslateAnnotations(anno)
+	ingress, _ := (&translator{}).TranslateAnnotations(anno)
 	assert.Equal(t, "plugin-config-echo", ingress.PluginConfigName)
 }
 
@@ -60,7 +60,7 @@ func TestAnnotationsEnableWebSocket(t *testing.T) {
 		annotations.AnnotationsEnableWebSocket: "true",
 	}
 
-	ingress := (&translator{}).TranslateAnnotations(anno)
+	ingress, _ := (&translator{}).TranslateAnnotations(anno)
 	assert.Equal(t, true, ingress.EnableWebSocket)
 }
 
@@ -69,7 +69,7 @@ func TestAnnotationsUseRegex(t *testing.T) {
 		annotations.AnnotationsUseRegex: "true",
 	}
 
-	ingress := (&translator{}).TranslateAnnotations(anno)
+	ingress, _ := (&translator{}).TranslateAnnotations(anno)
 	assert.Equal(t, true, ingress.UseRegex)
 }
 
@@ -78,6 +78,20 @@ func TestAnnotationsServiceNamespace(t *testing.T) {
 		annotations.AnnotationsSvcNamespace: "mynamespace",
 	}
 
-	ingress := (&translator{}).TranslateAnnotations(anno)
+	ingress, _ := (&translator{}).TranslateAnnotations(anno)
 	assert.Equal(t, "mynamespace", ingress.ServiceNamespace)
 }
+
+func TestAnnotationsLimits(t *testing.T) {
+	anno := map[string]string{
+		annotations.AnnotationsLimitRPS:         "10",
+		annotations.AnnotationsLimitConnections: "abc",
+		annotations.AnnotationsProxyBodySize:    "8m",
+	}
+
+	ingress, err := (&translator{}).TranslateAnnotations(anno)
+	assert.Contains(t, err.Error(), annotations.AnnotationsLimitConnections)
+	assert.Len(t, ingress.Plugins, 2)
+	assert.Equal(t, 10, ingress.Plugins["limit-req"].(*apisix.LimitReqConfig).Rate)
+	assert.Equal(t, &apisix.ClientControlConfig{MaxBodySize: 8 << 20}, ingress.Plugins["client-control"])
+}
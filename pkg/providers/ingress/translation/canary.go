@@ -0,0 +1,263 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package translation
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+
+	"github.com/apache/apisix-ingress-controller/pkg/kube"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations/canary"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/translation"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+const (
+	_trafficSplitPlugin = "traffic-split"
+
+	_canaryAlways = "always"
+	_canaryNever  = "never"
+)
+
+// listCanaryIngresses returns the canary Ingresses in the namespace, sorted by
+// name so that the same one wins when several of them share a host and path.
+func (t *translator) listCanaryIngresses(namespace, groupVersion string) ([]kube.Ingress, error) {
+	if t.TranslatorOptions == nil || t.IngressLister == nil {
+		return nil, nil
+	}
+	var (
+		list []kube.Ingress
+		err  error
+	)
+	if groupVersion == kube.IngressV1beta1 {
+		list, err = t.IngressLister.ListV1beta1(namespace)
+	} else {
+		list, err = t.IngressLister.ListV1(namespace)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var canaries []kube.Ingress
+	for _, ing := range list {
+		if IsCanaryIngress(ing) {
+			canaries = append(canaries, ing)
+		}
+	}
+	sort.Slice(canaries, func(i, j int) bool {
+		return canaries[i].GetName() < canaries[j].GetName()
+	})
+	return canaries, nil
+}
+
+// IsCanaryIngress reports whether the Ingress is a canary one, which is
+// merged into the Ingress sharing the host and path with it.
+func IsCanaryIngress(ing kube.Ingress) bool {
+	return ing.GetAnnotations()[annotations.AnnotationsCanary] == "true"
+}
+
+// translateCanaryV1 splits the traffic of the route to the backend of the
+// first canary Ingress with the same host and path.
+func (t *translator) translateCanaryV1(ctx *translation.TranslateContext, ing *networkingv1.Ingress, host, path string,
+	route *apisixv1.Route, skipVerify bool) error {
+	canaries, err := t.listCanaryIngresses(ing.Namespace, kube.IngressV1)
+	if err != nil {
+		return err
+	}
+	for _, c := range canaries {
+		for _, rule := range c.V1().Spec.Rules {
+			if rule.Host != host || rule.HTTP == nil {
+				continue
+			}
+			for _, pathRule := range rule.HTTP.Paths {
+				if pathRule.Path != path || pathRule.Backend.Service == nil {
+					continue
+				}
+				anno, _ := t.translateIngressAnnotations(c.GetAnnotations())
+				ns := c.GetNamespace()
+				if anno.ServiceNamespace != "" {
+					ns = anno.ServiceNamespace
+				}
+				var ups *apisixv1.Upstream
+				if skipVerify {
+					ups = t.translateDefaultUpstreamFromIngressV1(ns, pathRule.Backend.Service)
+				} else {
+					ups, err = t.translateUpstreamFromIngressV1(ns, pathRule.Backend.Service)
+					if err != nil {
+						return err
+					}
+				}
+				setUpstreamAnnotations(ups, anno.Upstream)
+				applyCanary(ctx, route, ups, anno.Canary)
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// translateCanaryV1beta1 splits the traffic of the route to the backend of
+// the first canary Ingress with the same host and path.
+func (t *translator) translateCanaryV1beta1(ctx *translation.TranslateContext, ing *networkingv1beta1.Ingress, host, path string,
+	route *apisixv1.Route, skipVerify bool) error {
+	canaries, err := t.listCanaryIngresses(ing.Namespace, kube.IngressV1beta1)
+	if err != nil {
+		return err
+	}
+	for _, c := range canaries {
+		for _, rule := range c.V1beta1().Spec.Rules {
+			if rule.Host != host || rule.HTTP == nil {
+				continue
+			}
+			for _, pathRule := range rule.HTTP.Paths {
+				if pathRule.Path != path || pathRule.Backend.ServiceName == "" {
+					continue
+				}
+				anno, _ := t.translateIngressAnnotations(c.GetAnnotations())
+				ns := c.GetNamespace()
+				if anno.ServiceNamespace != "" {
+					ns = anno.ServiceNamespace
+				}
+				var ups *apisixv1.Upstream
+				if skipVerify {
+					ups = t.translateDefaultUpstreamFromIngressV1beta1(ns, pathRule.Backend.ServiceName, pathRule.Backend.ServicePort)
+				} else {
+					ups, err = t.translateUpstreamFromIngressV1beta1(ns, pathRule.Backend.ServiceName, pathRule.Backend.ServicePort)
+					if err != nil {
+						return err
+					}
+				}
+				setUpstreamAnnotations(ups, anno.Upstream)
+				applyCanary(ctx, route, ups, anno.Canary)
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// applyCanary adds the traffic-split plugin to the route. Like ingress-nginx,
+// the header takes precedence over the cookie, and the cookie takes
+// precedence over the weight.
+func applyCanary(ctx *translation.TranslateContext, route *apisixv1.Route, ups *apisixv1.Upstream, c canary.Canary) {
+	if ups.ID == route.UpstreamId {
+		return
+	}
+	toCanary := []apisixv1.TrafficSplitConfigRuleWeightedUpstream{
+		{
+			UpstreamID: ups.ID,
+			Weight:     100,
+		},
+	}
+	// The weighted upstream without upstream_id stands for the upstream
+	// of the route.
+	toPrimary := []apisixv1.TrafficSplitConfigRuleWeightedUpstream{
+		{
+			Weight: 100,
+		},
+	}
+
+	var rules []apisixv1.TrafficSplitConfigRule
+	if c.Header != "" {
+		v := "http_" + strings.ReplaceAll(strings.ToLower(c.Header), "-", "_")
+		if c.HeaderValue != "" {
+			rules = append(rules, matchRule(v, c.HeaderValue, toCanary))
+		} else {
+			rules = append(rules,
+				matchRule(v, _canaryAlways, toCanary),
+				matchRule(v, _canaryNever, toPrimary),
+			)
+		}
+	}
+	if c.Cookie != "" {
+		v := "cookie_" + c.Cookie
+		rules = append(rules,
+			matchRule(v, _canaryAlways, toCanary),
+			matchRule(v, _canaryNever, toPrimary),
+		)
+	}
+	if c.Weight > 0 {
+		rules = append(rules, apisixv1.TrafficSplitConfigRule{
+			WeightedUpstreams: []apisixv1.TrafficSplitConfigRuleWeightedUpstream{
+				{
+					UpstreamID: ups.ID,
+					Weight:     c.Weight,
+				},
+				{
+					Weight: 100 - c.Weight,
+				},
+			},
+		})
+	}
+	if len(rules) == 0 {
+		return
+	}
+
+	ctx.AddUpstream(ups)
+	if route.Plugins == nil {
+		route.Plugins = make(apisixv1.Plugins)
+	}
+	route.Plugins[_trafficSplitPlugin] = &apisixv1.TrafficSplitConfig{
+		Rules: rules,
+	}
+}
+
+func matchRule(v, value string, ups []apisixv1.TrafficSplitConfigRuleWeightedUpstream) apisixv1.TrafficSplitConfigRule {
+	return apisixv1.TrafficSplitConfigRule{
+		Match: []apisixv1.TrafficSplitConfigRuleMatch{
+			{
+				Vars: apisixv1.Vars{
+					{
+						{StrVal: v},
+						{StrVal: "=="},
+						{StrVal: value},
+					},
+				},
+			},
+		},
+		WeightedUpstreams: ups,
+	}
+}
+
+// trafficSplitUpstreams returns the IDs of the upstreams referred by the
+// traffic-split plugin of the route.
+func trafficSplitUpstreams(r *apisixv1.Route) []string {
+	plugin, ok := r.Plugins[_trafficSplitPlugin]
+	if !ok {
+		return nil
+	}
+	// The plugin config from cache is a generic map, convert it back.
+	data, err := json.Marshal(plugin)
+	if err != nil {
+		return nil
+	}
+	var conf apisixv1.TrafficSplitConfig
+	if err := json.Unmarshal(data, &conf); err != nil {
+		return nil
+	}
+	var ids []string
+	for _, rule := range conf.Rules {
+		for _, wu := range rule.WeightedUpstreams {
+			if wu.UpstreamID != "" {
+				ids = append(ids, wu.UpstreamID)
+			}
+		}
+	}
+	return ids
+}
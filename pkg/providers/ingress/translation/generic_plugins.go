@@ -0,0 +1,91 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package translation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/apache/apisix-ingress-controller/pkg/providers/utils"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+// pluginSchemaError means the schema of a plugin can't be fetched from APISIX,
+// so it's unknown whether the plugin config is valid.
+type pluginSchemaError struct {
+	plugin string
+	err    error
+}
+
+func (e *pluginSchemaError) Error() string {
+	return fmt.Sprintf("failed to get the schema of plugin %s: %s", e.plugin, e.err)
+}
+
+func (e *pluginSchemaError) Unwrap() error {
+	return e.err
+}
+
+// mergeGenericPlugins merges the plugins of the "plugin.<name>" annotations
+// into the plugins of the Ingress, they take precedence over the plugins
+// converted from other annotations. Plugins with invalid configs are skipped,
+// a *pluginSchemaError is returned if the schema of a plugin can't be fetched.
+func (t *translator) mergeGenericPlugins(ingress *Ingress, anno map[string]string) error {
+	for _, name := range ingress.GenericPlugins.Disabled {
+		delete(ingress.Plugins, name)
+	}
+	if len(ingress.GenericPlugins.Plugins) == 0 {
+		return nil
+	}
+	var merr *multierror.Error
+	for name, config := range ingress.GenericPlugins.Plugins {
+		if err := t.validatePluginSchema(anno, name, config); err != nil {
+			merr = multierror.Append(merr, err)
+			continue
+		}
+		if ingress.Plugins == nil {
+			ingress.Plugins = make(apisixv1.Plugins)
+		}
+		ingress.Plugins[name] = config
+	}
+	return merr.ErrorOrNil()
+}
+
+// validatePluginSchema validates the plugin config against the plugin schema
+// of the APISIX cluster which the Ingress is synced to.
+func (t *translator) validatePluginSchema(anno map[string]string, name string, config interface{}) error {
+	if t.TranslatorOptions == nil || t.Apisix == nil {
+		return nil
+	}
+	cluster := utils.BoundClusters(anno, t.defaultCluster())[0]
+	schema, err := t.Apisix.Cluster(cluster).Schema().GetPluginSchema(context.TODO(), name)
+	if err != nil {
+		return &pluginSchemaError{plugin: name, err: err}
+	}
+	result, err := gojsonschema.Validate(gojsonschema.NewStringLoader(schema.Content), gojsonschema.NewGoLoader(config))
+	if err != nil {
+		return fmt.Errorf("failed to validate the config of plugin %s: %s", name, err)
+	}
+	if result.Valid() {
+		return nil
+	}
+	merr := multierror.Append(nil, fmt.Errorf("%s plugin's config is invalid", name))
+	for _, desc := range result.Errors() {
+		merr = multierror.Append(merr, fmt.Errorf("- %s", desc.String()))
+	}
+	return merr
+}
@@ -0,0 +1,144 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package translation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apache/apisix-ingress-controller/pkg/apisix"
+	"github.com/apache/apisix-ingress-controller/pkg/apisix/cache"
+	"github.com/apache/apisix-ingress-controller/pkg/kube"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+type fakeSchema struct {
+	apisix.Schema
+	plugins map[string]string
+	err     error
+}
+
+func (s *fakeSchema) GetPluginSchema(_ context.Context, name string) (*apisixv1.Schema, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	content, ok := s.plugins[name]
+	if !ok {
+		return nil, cache.ErrNotFound
+	}
+	return &apisixv1.Schema{Name: "plugins/" + name, Content: content}, nil
+}
+
+type fakeCluster struct {
+	apisix.Cluster
+	schema apisix.Schema
+}
+
+func (c *fakeCluster) Schema() apisix.Schema {
+	return c.schema
+}
+
+type fakeAPISIX struct {
+	apisix.APISIX
+	cluster apisix.Cluster
+}
+
+func (a *fakeAPISIX) Cluster(_ string) apisix.Cluster {
+	return a.cluster
+}
+
+func TestTranslateGenericPluginAnnotations(t *testing.T) {
+	schema := &fakeSchema{
+		plugins: map[string]string{
+			"limit-count": `{"type": "object", "properties": {"count": {"type": "integer", "exclusiveMinimum": 0}}, "required": ["count"]}`,
+			"prometheus":  `{"type": "object"}`,
+		},
+	}
+	tr := &translator{
+		TranslatorOptions: &TranslatorOptions{
+			Apisix:      &fakeAPISIX{cluster: &fakeCluster{schema: schema}},
+			ClusterName: "default",
+		},
+	}
+
+	anno := map[string]string{
+		annotations.AnnotationsEnableCors:                                                              "true",
+		annotations.AnnotationsAuthType:                                                                "keyAuth",
+		annotations.AnnotationsPluginPrefix + "limit-count":                                            "count: 10",
+		annotations.AnnotationsPluginPrefix + "prometheus" + annotations.AnnotationsPluginEnableSuffix: "true",
+		annotations.AnnotationsPluginPrefix + "cors" + annotations.AnnotationsPluginEnableSuffix:       "false",
+	}
+	ingress, err := tr.translateIngressAnnotations(anno)
+	assert.Nil(t, err)
+	assert.Equal(t, apisixv1.Plugins{
+		"key-auth":    &apisixv1.KeyAuthConfig{},
+		"limit-count": map[string]interface{}{"count": float64(10)},
+		"prometheus":  map[string]interface{}{},
+	}, ingress.Plugins)
+
+	// Configs violating the schema are skipped, unknown plugins are reported
+	// along with them.
+	anno[annotations.AnnotationsPluginPrefix+"limit-count"] = "count: 0"
+	anno[annotations.AnnotationsPluginPrefix+"unknown"] = "{}"
+	ingress, err = tr.translateIngressAnnotations(anno)
+	assert.Contains(t, err.Error(), "limit-count plugin's config is invalid")
+	assert.Contains(t, err.Error(), "failed to get the schema of plugin unknown")
+	assert.Equal(t, apisixv1.Plugins{
+		"key-auth":   &apisixv1.KeyAuthConfig{},
+		"prometheus": map[string]interface{}{},
+	}, ingress.Plugins)
+}
+
+func TestTranslateIngressPluginSchemaUnavailable(t *testing.T) {
+	schema := &fakeSchema{
+		plugins: map[string]string{
+			"key-auth": `{"type": "object"}`,
+		},
+		err: errors.New("connection refused"),
+	}
+	tr := &translator{
+		TranslatorOptions: &TranslatorOptions{
+			Apisix:      &fakeAPISIX{cluster: &fakeCluster{schema: schema}},
+			ClusterName: "default",
+		},
+	}
+	ing := kube.MustNewIngress(&networkingv1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Ingress",
+			APIVersion: "networking.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "foo",
+			Annotations: map[string]string{
+				annotations.AnnotationsPluginPrefix + "key-auth": "{}",
+			},
+		},
+	})
+
+	// The Ingress must not go live without the plugin.
+	_, err := tr.TranslateIngress(ing)
+	assert.Contains(t, err.Error(), "failed to get the schema of plugin key-auth: connection refused")
+
+	schema.err = nil
+	_, err = tr.TranslateIngress(ing)
+	assert.Nil(t, err)
+}
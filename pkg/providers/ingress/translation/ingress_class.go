@@ -0,0 +1,164 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package translation
+
+import (
+	"github.com/hashicorp/go-multierror"
+	"go.uber.org/zap"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/apache/apisix-ingress-controller/pkg/config"
+	"github.com/apache/apisix-ingress-controller/pkg/kube"
+	kubev2 "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/apis/config/v2"
+	"github.com/apache/apisix-ingress-controller/pkg/log"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/utils"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+// IngressClassParameters returns the ApisixClusterConfig which the
+// spec.parameters of the IngressClass refers to, nil is returned if
+// there is none.
+func (t *translator) IngressClassParameters() *kubev2.ApisixClusterConfig {
+	if t.TranslatorOptions == nil || t.IngressClassLister == nil || t.ApisixClusterConfigLister == nil {
+		return nil
+	}
+	name := t.IngressClassName
+	if name == config.IngressClassApisixAndAll {
+		name = config.IngressClass
+	}
+	ic, err := t.IngressClassLister.Get(name)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			log.Errorw("failed to get IngressClass",
+				zap.Error(err),
+				zap.String("name", name),
+			)
+		}
+		return nil
+	}
+	params := ic.Spec.Parameters
+	if params == nil || params.APIGroup == nil || *params.APIGroup != kubev2.GroupName || params.Kind != "ApisixClusterConfig" {
+		return nil
+	}
+	acc, err := t.ApisixClusterConfigLister.V2(params.Name)
+	if err != nil {
+		log.Warnw("failed to get ApisixClusterConfig referred by IngressClass",
+			zap.Error(err),
+			zap.String("ingress_class", name),
+			zap.String("apisix_cluster_config", params.Name),
+		)
+		return nil
+	}
+	return acc.V2()
+}
+
+// BoundClusters returns the APISIX clusters that the Ingress is synced to,
+// the cluster of the IngressClass parameters replaces the default one.
+func (t *translator) BoundClusters(ing kube.Ingress) []string {
+	return utils.BoundClusters(t.ingressAnnotations(ing.GetAnnotations()), t.defaultCluster())
+}
+
+func (t *translator) defaultCluster() string {
+	if acc := t.IngressClassParameters(); acc != nil {
+		return acc.Name
+	}
+	return t.ClusterName
+}
+
+// ingressAnnotations merges the default annotations of the IngressClass
+// parameters into the annotations of the Ingress.
+func (t *translator) ingressAnnotations(anno map[string]string) map[string]string {
+	acc := t.IngressClassParameters()
+	if acc == nil || acc.Spec.IngressDefaults == nil || len(acc.Spec.IngressDefaults.Annotations) == 0 {
+		return anno
+	}
+	merged := make(map[string]string, len(anno)+len(acc.Spec.IngressDefaults.Annotations))
+	for k, v := range acc.Spec.IngressDefaults.Annotations {
+		merged[k] = v
+	}
+	for k, v := range anno {
+		merged[k] = v
+	}
+	return merged
+}
+
+// translateIngressAnnotations parses the annotations of the Ingress along
+// with the defaults of the IngressClass parameters.
+func (t *translator) translateIngressAnnotations(anno map[string]string) (*Ingress, error) {
+	anno = t.ingressAnnotations(anno)
+	ingress, err := t.TranslateAnnotations(anno)
+	t.setDefaultPlugins(ingress)
+	if pluginErr := t.mergeGenericPlugins(ingress, anno); pluginErr != nil {
+		err = multierror.Append(err, pluginErr)
+	}
+	return ingress, err
+}
+
+// setDefaultPlugins adds the default plugins of the IngressClass parameters
+// which are not configured by the annotations.
+func (t *translator) setDefaultPlugins(ingress *Ingress) {
+	acc := t.IngressClassParameters()
+	if acc == nil || acc.Spec.IngressDefaults == nil {
+		return
+	}
+	for _, plugin := range acc.Spec.IngressDefaults.Plugins {
+		if !plugin.Enable {
+			continue
+		}
+		if _, ok := ingress.Plugins[plugin.Name]; ok {
+			continue
+		}
+		if ingress.Plugins == nil {
+			ingress.Plugins = make(apisixv1.Plugins)
+		}
+		if plugin.Config != nil {
+			ingress.Plugins[plugin.Name] = plugin.Config
+		} else {
+			ingress.Plugins[plugin.Name] = make(map[string]interface{})
+		}
+	}
+}
+
+// setUpstreamDefaults applies the default upstream config of the
+// IngressClass parameters to the upstream of the Service, unless the
+// Service has its own ApisixUpstream.
+func (t *translator) setUpstreamDefaults(ups *apisixv1.Upstream, namespace, svcName string) error {
+	acc := t.IngressClassParameters()
+	if acc == nil || acc.Spec.IngressDefaults == nil || acc.Spec.IngressDefaults.Upstream == nil {
+		return nil
+	}
+	if t.ApisixUpstreamLister != nil {
+		if _, err := t.ApisixUpstreamLister.V2(namespace, svcName); err == nil {
+			return nil
+		} else if !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+	defaults, err := t.TranslateUpstreamConfigV2(acc.Spec.IngressDefaults.Upstream)
+	if err != nil {
+		return err
+	}
+	ups.Type = defaults.Type
+	ups.HashOn = defaults.HashOn
+	ups.Key = defaults.Key
+	ups.Checks = defaults.Checks
+	ups.Scheme = defaults.Scheme
+	ups.Retries = defaults.Retries
+	ups.Timeout = defaults.Timeout
+	ups.TLS = defaults.TLS
+	ups.PassHost = defaults.PassHost
+	ups.UpstreamHost = defaults.UpstreamHost
+	return nil
+}
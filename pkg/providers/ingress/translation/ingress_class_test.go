@@ -0,0 +1,170 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package translation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/apache/apisix-ingress-controller/pkg/kube"
+	configv2 "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/apis/config/v2"
+	apisixfake "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/client/clientset/versioned/fake"
+	apisixinformers "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/client/informers/externalversions"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/apisix/translation"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations"
+	providertranslation "github.com/apache/apisix-ingress-controller/pkg/providers/translation"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/utils"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+func TestTranslateIngressWithIngressClassParameters(t *testing.T) {
+	informersFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+	apisixFactory := apisixinformers.NewSharedInformerFactory(apisixfake.NewSimpleClientset(), 0)
+	ingressClassInformer := informersFactory.Networking().V1().IngressClasses().Informer()
+	accInformer := apisixFactory.Apisix().V2().ApisixClusterConfigs().Informer()
+
+	group := configv2.GroupName
+	assert.Nil(t, ingressClassInformer.GetIndexer().Add(&networkingv1.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "apisix",
+		},
+		Spec: networkingv1.IngressClassSpec{
+			Parameters: &networkingv1.IngressClassParametersReference{
+				APIGroup: &group,
+				Kind:     "ApisixClusterConfig",
+				Name:     "edge",
+			},
+		},
+	}))
+	retries := 3
+	assert.Nil(t, accInformer.GetIndexer().Add(&configv2.ApisixClusterConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "edge",
+		},
+		Spec: configv2.ApisixClusterConfigSpec{
+			IngressDefaults: &configv2.ApisixClusterIngressDefaults{
+				Annotations: map[string]string{
+					annotations.AnnotationsEnableWebSocket: "true",
+				},
+				Plugins: []configv2.ApisixRoutePlugin{
+					{
+						Name:   "echo",
+						Enable: true,
+						Config: configv2.ApisixRoutePluginConfig{
+							"before_body": "hello",
+						},
+					},
+					{
+						Name:   "cors",
+						Enable: false,
+					},
+				},
+				Upstream: &configv2.ApisixUpstreamConfig{
+					LoadBalancer: &configv2.LoadBalancer{
+						Type: apisixv1.LbEwma,
+					},
+					Retries: &retries,
+				},
+			},
+		},
+	}))
+
+	tr := &translator{
+		TranslatorOptions: &TranslatorOptions{
+			ClusterName:               "default",
+			IngressClassName:          "apisix",
+			IngressClassLister:        informersFactory.Networking().V1().IngressClasses().Lister(),
+			ApisixClusterConfigLister: kube.NewApisixClusterConfigLister(apisixFactory.Apisix().V2().ApisixClusterConfigs().Lister()),
+		},
+		Translator:       providertranslation.NewTranslator(&providertranslation.TranslatorOptions{}),
+		ApisixTranslator: translation.NewApisixTranslator(&translation.TranslatorOptions{}, translator{}),
+	}
+
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "default",
+		},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: "foo.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path: "/foo",
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: "svc",
+											Port: networkingv1.ServiceBackendPort{Number: 80},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	kubeIng, err := kube.NewIngress(ing)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"edge"}, tr.BoundClusters(kubeIng))
+
+	ctx, err := tr.TranslateIngress(kubeIng, true)
+	assert.Nil(t, err)
+	assert.Len(t, ctx.Routes, 1)
+	assert.True(t, ctx.Routes[0].EnableWebsocket)
+	assert.Equal(t, map[string]interface{}{"before_body": "hello"}, ctx.Routes[0].Plugins["echo"])
+	assert.NotContains(t, ctx.Routes[0].Plugins, "cors")
+
+	// Annotations of the Ingress take precedence.
+	ing.Annotations = map[string]string{
+		annotations.AnnotationsEnableWebSocket: "false",
+		utils.ClusterBindingAnnotation:         "internal",
+	}
+	kubeIng, err = kube.NewIngress(ing)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"internal"}, tr.BoundClusters(kubeIng))
+	ctx, err = tr.TranslateIngress(kubeIng, true)
+	assert.Nil(t, err)
+	assert.False(t, ctx.Routes[0].EnableWebsocket)
+
+	ups := apisixv1.NewDefaultUpstream()
+	assert.Nil(t, tr.setUpstreamDefaults(ups, "default", "svc"))
+	assert.Equal(t, apisixv1.LbEwma, ups.Type)
+	assert.Equal(t, &retries, ups.Retries)
+
+	// Without parameters, nothing is inherited.
+	assert.Nil(t, ingressClassInformer.GetIndexer().Update(&networkingv1.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "apisix",
+		},
+	}))
+	ing.Annotations = nil
+	kubeIng, err = kube.NewIngress(ing)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"default"}, tr.BoundClusters(kubeIng))
+	ctx, err = tr.TranslateIngress(kubeIng, true)
+	assert.Nil(t, err)
+	assert.False(t, ctx.Routes[0].EnableWebsocket)
+	assert.Len(t, ctx.Routes[0].Plugins, 0)
+}
@@ -22,16 +22,20 @@ import (
 	"crypto/sha1"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	listerscorev1 "k8s.io/client-go/listers/core/v1"
+	listersnetworkingv1 "k8s.io/client-go/listers/networking/v1"
 
 	"github.com/apache/apisix-ingress-controller/pkg/apisix"
 	"github.com/apache/apisix-ingress-controller/pkg/id"
@@ -40,7 +44,9 @@ import (
 	apisixconst "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/const"
 	"github.com/apache/apisix-ingress-controller/pkg/log"
 	apisixtranslation "github.com/apache/apisix-ingress-controller/pkg/providers/apisix/translation"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/ingress/translation/annotations/upstream"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/translation"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/utils"
 	"github.com/apache/apisix-ingress-controller/pkg/types"
 	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
 )
@@ -56,6 +62,14 @@ type TranslatorOptions struct {
 	ClusterName string
 
 	ServiceLister listerscorev1.ServiceLister
+	IngressLister kube.IngressLister
+
+	// IngressClassName, IngressClassLister and ApisixClusterConfigLister
+	// are used to look up the parameters of the IngressClass.
+	IngressClassName          string
+	IngressClassLister        listersnetworkingv1.IngressClassLister
+	ApisixClusterConfigLister kube.ApisixClusterConfigLister
+	ApisixUpstreamLister      kube.ApisixUpstreamLister
 }
 
 type translator struct {
@@ -77,6 +91,18 @@ type IngressTranslator interface {
 	// TranslateIngressDeleteEvent composes a couple of APISIX Routes and upstreams according
 	// to the given Ingress resource.
 	TranslateIngressDeleteEvent(ing kube.Ingress, args ...bool) (*translation.TranslateContext, error)
+	// ValidateIngress translates the Ingress in dry-run mode, annotations which
+	// are failed to parse and malformed regex paths are reported as errors
+	// instead of being skipped. Backend Services and TLS Secrets are not looked up.
+	ValidateIngress(ing kube.Ingress) error
+	// ValidateAnnotations parses the annotations of the Ingress and reports
+	// the ones which TranslateIngress skips because they are malformed.
+	ValidateAnnotations(ing kube.Ingress) error
+	// BoundClusters returns the APISIX clusters that the Ingress is synced to.
+	BoundClusters(ing kube.Ingress) []string
+	// IngressClassParameters returns the ApisixClusterConfig which the
+	// IngressClass refers to in spec.parameters.
+	IngressClassParameters() *kubev2.ApisixClusterConfig
 }
 
 func NewIngressTranslator(opts *TranslatorOptions,
@@ -144,6 +170,60 @@ func (t *translator) TranslateIngress(ing kube.Ingress, args ...bool) (*translat
 	}
 }
 
+func (t *translator) ValidateIngress(ing kube.Ingress) error {
+	var (
+		anno       map[string]string
+		regexPaths []string
+	)
+	switch ing.GroupVersion() {
+	case kube.IngressV1:
+		anno = ing.V1().Annotations
+		for _, rule := range ing.V1().Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			for _, pathRule := range rule.HTTP.Paths {
+				if pathRule.PathType != nil && *pathRule.PathType == networkingv1.PathTypeImplementationSpecific {
+					regexPaths = append(regexPaths, pathRule.Path)
+				}
+			}
+		}
+	case kube.IngressV1beta1:
+		anno = ing.V1beta1().Annotations
+		for _, rule := range ing.V1beta1().Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			for _, pathRule := range rule.HTTP.Paths {
+				if pathRule.PathType != nil && *pathRule.PathType == networkingv1beta1.PathTypeImplementationSpecific {
+					regexPaths = append(regexPaths, pathRule.Path)
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("translator: source group version not supported: %s", ing.GroupVersion())
+	}
+
+	ingress, err := t.translateIngressAnnotations(anno)
+	if err != nil {
+		return err
+	}
+	if ingress.UseRegex {
+		for _, path := range regexPaths {
+			if _, err := regexp.Compile(path); err != nil {
+				return fmt.Errorf("invalid regex path %q: %s", path, err)
+			}
+		}
+	}
+	_, err = t.TranslateIngress(ing, true)
+	return err
+}
+
+func (t *translator) ValidateAnnotations(ing kube.Ingress) error {
+	_, err := t.translateIngressAnnotations(ing.GetAnnotations())
+	return err
+}
+
 func (t *translator) TranslateIngressDeleteEvent(ing kube.Ingress, args ...bool) (*translation.TranslateContext, error) {
 	switch ing.GroupVersion() {
 	case kube.IngressV1:
@@ -157,14 +237,41 @@ func (t *translator) TranslateIngressDeleteEvent(ing kube.Ingress, args ...bool)
 
 const (
 	_regexPriority = 100
+	// _defaultBackendPriority makes the routes of the default backend match
+	// after all the others.
+	_defaultBackendPriority = -100
+	// _defaultBackendPath replaces the path when composing the names of the
+	// default backend routes, it never conflicts with a real path.
+	_defaultBackendPath = "<default-backend>"
 )
 
 func (t *translator) translateIngressV1(ing *networkingv1.Ingress, skipVerify bool) (*translation.TranslateContext, error) {
 	ctx := translation.DefaultEmptyTranslateContext()
-	ingress := t.TranslateAnnotations(ing.Annotations)
+	ingress, err := t.translateIngressAnnotations(ing.Annotations)
+	if err != nil {
+		var schemaErr *pluginSchemaError
+		if errors.As(err, &schemaErr) {
+			// The plugin may enforce access control, the routes must not
+			// go live without it.
+			return nil, err
+		}
+		log.Warnw("failed to parse annotations",
+			zap.Error(err),
+			zap.String("ingress", ing.Namespace+"/"+ing.Name),
+		)
+	}
+	if ingress.Canary.Enable {
+		// The backends of a canary Ingress are merged into the routes of
+		// the Ingress with the same host and path.
+		return ctx, nil
+	}
 
 	// add https
 	for _, tls := range ing.Spec.TLS {
+		if skipVerify {
+			// The Secret may be created after the Ingress, don't look it up.
+			break
+		}
 		ssl, err := t.TranslateIngressTLS(ing.Namespace, ing.Name, tls.SecretName, tls.Hosts)
 		if err != nil {
 			log.Errorw("failed to translate ingress tls to apisix tls",
@@ -201,29 +308,7 @@ func (t *translator) translateIngressV1(ing *networkingv1.Ingress, skipVerify bo
 						return nil, err
 					}
 				}
-				if ingress.Upstream.Scheme != "" {
-					ups.Scheme = ingress.Upstream.Scheme
-				}
-				if ingress.Upstream.Retry > 0 {
-					retry := ingress.Upstream.Retry
-					ups.Retries = &retry
-				}
-				if ups.Timeout == nil {
-					ups.Timeout = &apisixv1.UpstreamTimeout{
-						Read:    60,
-						Send:    60,
-						Connect: 60,
-					}
-				}
-				if ingress.Upstream.TimeoutConnect > 0 {
-					ups.Timeout.Connect = ingress.Upstream.TimeoutConnect
-				}
-				if ingress.Upstream.TimeoutRead > 0 {
-					ups.Timeout.Read = ingress.Upstream.TimeoutRead
-				}
-				if ingress.Upstream.TimeoutSend > 0 {
-					ups.Timeout.Send = ingress.Upstream.TimeoutSend
-				}
+				setUpstreamAnnotations(ups, ingress.Upstream)
 				ctx.AddUpstream(ups)
 			}
 			uris := []string{pathRule.Path}
@@ -280,19 +365,79 @@ func (t *translator) translateIngressV1(ing *networkingv1.Ingress, skipVerify bo
 			}
 			if ups != nil {
 				route.UpstreamId = ups.ID
+				if err := t.translateCanaryV1(ctx, ing, rule.Host, pathRule.Path, route, skipVerify); err != nil {
+					log.Errorw("failed to translate canary ingress backend to upstream",
+						zap.Error(err),
+						zap.Any("ingress", ing),
+					)
+					return nil, err
+				}
 			}
 			ctx.AddRoute(route)
 		}
 	}
+	if ing.Spec.DefaultBackend != nil {
+		var (
+			ups *apisixv1.Upstream
+			err error
+		)
+		if ing.Spec.DefaultBackend.Service != nil {
+			if skipVerify {
+				ups = t.translateDefaultUpstreamFromIngressV1(ns, ing.Spec.DefaultBackend.Service)
+			} else {
+				ups, err = t.translateUpstreamFromIngressV1(ns, ing.Spec.DefaultBackend.Service)
+			}
+		} else if ing.Spec.DefaultBackend.Resource != nil {
+			ups, err = t.translateResourceBackend(ing.Namespace, ing.Spec.DefaultBackend.Resource, skipVerify)
+		}
+		if err != nil {
+			log.Errorw("failed to translate ingress default backend to upstream",
+				zap.Error(err),
+				zap.Any("ingress", ing),
+			)
+			return nil, err
+		}
+		if ups != nil {
+			setUpstreamAnnotations(ups, ingress.Upstream)
+			var hosts []string
+			for _, rule := range ing.Spec.Rules {
+				if rule.HTTP == nil {
+					hosts = append(hosts, rule.Host)
+				}
+			}
+			t.translateDefaultBackend(ctx, ing.Namespace, ing.Name, hosts, ingress, ups)
+		}
+	}
 	return ctx, nil
 }
 
 func (t *translator) translateIngressV1beta1(ing *networkingv1beta1.Ingress, skipVerify bool) (*translation.TranslateContext, error) {
 	ctx := translation.DefaultEmptyTranslateContext()
-	ingress := t.TranslateAnnotations(ing.Annotations)
+	ingress, err := t.translateIngressAnnotations(ing.Annotations)
+	if err != nil {
+		var schemaErr *pluginSchemaError
+		if errors.As(err, &schemaErr) {
+			// The plugin may enforce access control, the routes must not
+			// go live without it.
+			return nil, err
+		}
+		log.Warnw("failed to parse annotations",
+			zap.Error(err),
+			zap.String("ingress", ing.Namespace+"/"+ing.Name),
+		)
+	}
+	if ingress.Canary.Enable {
+		// The backends of a canary Ingress are merged into the routes of
+		// the Ingress with the same host and path.
+		return ctx, nil
+	}
 
 	// add https
 	for _, tls := range ing.Spec.TLS {
+		if skipVerify {
+			// The Secret may be created after the Ingress, don't look it up.
+			break
+		}
 		ssl, err := t.TranslateIngressTLS(ing.Namespace, ing.Name, tls.SecretName, tls.Hosts)
 		if err != nil {
 			log.Errorw("failed to translate ingress tls to apisix tls",
@@ -308,6 +453,9 @@ func (t *translator) translateIngressV1beta1(ing *networkingv1beta1.Ingress, ski
 		ns = ingress.ServiceNamespace
 	}
 	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
 		for _, pathRule := range rule.HTTP.Paths {
 			var (
 				ups *apisixv1.Upstream
@@ -326,9 +474,7 @@ func (t *translator) translateIngressV1beta1(ing *networkingv1beta1.Ingress, ski
 						return nil, err
 					}
 				}
-				if ingress.Upstream.Scheme != "" {
-					ups.Scheme = ingress.Upstream.Scheme
-				}
+				setUpstreamAnnotations(ups, ingress.Upstream)
 				ctx.AddUpstream(ups)
 			}
 			uris := []string{pathRule.Path}
@@ -379,38 +525,141 @@ func (t *translator) translateIngressV1beta1(ing *networkingv1beta1.Ingress, ski
 			if len(ingress.Plugins) > 0 {
 				route.Plugins = *(ingress.Plugins.DeepCopy())
 			}
-			if ingress.Upstream.Retry > 0 {
-				retry := ingress.Upstream.Retry
-				ups.Retries = &retry
-			}
-			if ups.Timeout == nil {
-				ups.Timeout = &apisixv1.UpstreamTimeout{
-					Read:    60,
-					Send:    60,
-					Connect: 60,
-				}
-			}
-			if ingress.Upstream.TimeoutConnect > 0 {
-				ups.Timeout.Connect = ingress.Upstream.TimeoutConnect
-			}
-			if ingress.Upstream.TimeoutRead > 0 {
-				ups.Timeout.Read = ingress.Upstream.TimeoutRead
-			}
-			if ingress.Upstream.TimeoutSend > 0 {
-				ups.Timeout.Send = ingress.Upstream.TimeoutSend
-			}
 			if ingress.PluginConfigName != "" {
 				route.PluginConfigId = id.GenID(apisixv1.ComposePluginConfigName(ing.Namespace, ingress.PluginConfigName))
 			}
 			if ups != nil {
 				route.UpstreamId = ups.ID
+				if err := t.translateCanaryV1beta1(ctx, ing, rule.Host, pathRule.Path, route, skipVerify); err != nil {
+					log.Errorw("failed to translate canary ingress backend to upstream",
+						zap.Error(err),
+						zap.Any("ingress", ing),
+					)
+					return nil, err
+				}
 			}
 			ctx.AddRoute(route)
 		}
 	}
+	if ing.Spec.Backend != nil {
+		var (
+			ups *apisixv1.Upstream
+			err error
+		)
+		if ing.Spec.Backend.ServiceName != "" {
+			if skipVerify {
+				ups = t.translateDefaultUpstreamFromIngressV1beta1(ns, ing.Spec.Backend.ServiceName, ing.Spec.Backend.ServicePort)
+			} else {
+				ups, err = t.translateUpstreamFromIngressV1beta1(ns, ing.Spec.Backend.ServiceName, ing.Spec.Backend.ServicePort)
+			}
+		} else if ing.Spec.Backend.Resource != nil {
+			ups, err = t.translateResourceBackend(ing.Namespace, ing.Spec.Backend.Resource, skipVerify)
+		}
+		if err != nil {
+			log.Errorw("failed to translate ingress default backend to upstream",
+				zap.Error(err),
+				zap.Any("ingress", ing),
+			)
+			return nil, err
+		}
+		if ups != nil {
+			setUpstreamAnnotations(ups, ingress.Upstream)
+			var hosts []string
+			for _, rule := range ing.Spec.Rules {
+				if rule.HTTP == nil {
+					hosts = append(hosts, rule.Host)
+				}
+			}
+			t.translateDefaultBackend(ctx, ing.Namespace, ing.Name, hosts, ingress, ups)
+		}
+	}
 	return ctx, nil
 }
 
+// translateDefaultBackend composes the routes of the default backend, a
+// catch-all one and one for each host of the rules without HTTP paths.
+func (t *translator) translateDefaultBackend(ctx *translation.TranslateContext, namespace, name string,
+	hosts []string, ingress *Ingress, ups *apisixv1.Upstream) {
+	ctx.AddUpstream(ups)
+	for _, host := range defaultBackendHosts(hosts) {
+		route := apisixv1.NewDefaultRoute()
+		route.Name = composeIngressRouteName(namespace, name, host, _defaultBackendPath)
+		route.ID = id.GenID(route.Name)
+		route.Host = host
+		route.Uris = []string{"/*"}
+		route.Priority = _defaultBackendPriority
+		route.EnableWebsocket = ingress.EnableWebSocket
+		if len(ingress.Plugins) > 0 {
+			route.Plugins = *(ingress.Plugins.DeepCopy())
+		}
+		if ingress.PluginConfigName != "" {
+			route.PluginConfigId = id.GenID(apisixv1.ComposePluginConfigName(namespace, ingress.PluginConfigName))
+		}
+		route.UpstreamId = ups.ID
+		ctx.AddRoute(route)
+	}
+}
+
+// defaultBackendHosts returns the hosts of the default backend routes, the
+// empty one stands for the catch-all route.
+func defaultBackendHosts(hosts []string) []string {
+	out := []string{""}
+	for _, host := range hosts {
+		if host != "" {
+			out = append(out, host)
+		}
+	}
+	return out
+}
+
+// translateResourceBackend translates the resource backend of the Ingress,
+// only ApisixUpstream with external nodes or service discovery is supported,
+// nil is returned for the others.
+func (t *translator) translateResourceBackend(namespace string, res *corev1.TypedLocalObjectReference, skipVerify bool) (*apisixv1.Upstream, error) {
+	if res.APIGroup == nil || *res.APIGroup != kubev2.GroupName || res.Kind != "ApisixUpstream" {
+		log.Warnw("ignore unsupported resource backend",
+			zap.String("namespace", namespace),
+			zap.Any("resource", res),
+		)
+		return nil, nil
+	}
+	if skipVerify {
+		ups := apisixv1.NewDefaultUpstream()
+		ups.Name = apisixv1.ComposeExternalUpstreamName(namespace, res.Name)
+		ups.ID = id.GenID(ups.Name)
+		return ups, nil
+	}
+	return t.ApisixTranslator.TranslateExternalApisixUpstream(namespace, res.Name)
+}
+
+// setUpstreamAnnotations applies the upstream annotations of the Ingress
+// to the upstream.
+func setUpstreamAnnotations(ups *apisixv1.Upstream, u upstream.Upstream) {
+	if u.Scheme != "" {
+		ups.Scheme = u.Scheme
+	}
+	if u.Retry > 0 {
+		retry := u.Retry
+		ups.Retries = &retry
+	}
+	if ups.Timeout == nil {
+		ups.Timeout = &apisixv1.UpstreamTimeout{
+			Read:    60,
+			Send:    60,
+			Connect: 60,
+		}
+	}
+	if u.TimeoutConnect > 0 {
+		ups.Timeout.Connect = u.TimeoutConnect
+	}
+	if u.TimeoutRead > 0 {
+		ups.Timeout.Read = u.TimeoutRead
+	}
+	if u.TimeoutSend > 0 {
+		ups.Timeout.Send = u.TimeoutSend
+	}
+}
+
 func (t *translator) translateDefaultUpstreamFromIngressV1(namespace string, backend *networkingv1.IngressServiceBackend) *apisixv1.Upstream {
 	var portNumber int32
 	if backend.Port.Name != "" {
@@ -460,6 +709,9 @@ func (t *translator) translateUpstreamFromIngressV1(namespace string, backend *n
 	if err != nil {
 		return nil, err
 	}
+	if err := t.setUpstreamDefaults(ups, namespace, backend.Name); err != nil {
+		return nil, err
+	}
 	ups.Name = apisixv1.ComposeUpstreamName(namespace, backend.Name, "", svcPort, types.ResolveGranularity.Endpoint)
 	ups.ID = id.GenID(ups.Name)
 	return ups, nil
@@ -514,6 +766,9 @@ func (t *translator) translateUpstreamFromIngressV1beta1(namespace string, svcNa
 	if err != nil {
 		return nil, err
 	}
+	if err := t.setUpstreamDefaults(ups, namespace, svcName); err != nil {
+		return nil, err
+	}
 	ups.Name = apisixv1.ComposeUpstreamName(namespace, svcName, "", portNumber, types.ResolveGranularity.Endpoint)
 	ups.ID = id.GenID(ups.Name)
 	return ups, nil
@@ -532,13 +787,19 @@ func (t *translator) TranslateOldIngress(ing kube.Ingress) (*translation.Transla
 
 func (t *translator) translateOldIngressTLS(namespace, ingName, secretName string, hosts []string) (*apisixv1.Ssl, error) {
 	ssl, err := t.TranslateIngressTLS(namespace, ingName, secretName, hosts)
-	if err != nil && k8serrors.IsNotFound(err) {
+	var certErr *translation.CertificateError
+	if err != nil && (k8serrors.IsNotFound(err) || errors.As(err, &certErr)) {
 		apisixTLS, err := createApisixTLS(namespace, ingName, secretName, hosts)
 		if err != nil {
 			return nil, err
 		}
 		return &apisixv1.Ssl{
-			ID: id.GenID(namespace + "_" + apisixTLS.Name),
+			ID:   id.GenID(namespace + "_" + apisixTLS.Name),
+			Snis: hosts,
+			Labels: map[string]string{
+				translation.MetaSecretNamespace: namespace,
+				translation.MetaSecretName:      secretName,
+			},
 		}, nil
 	}
 	return ssl, err
@@ -546,6 +807,7 @@ func (t *translator) translateOldIngressTLS(namespace, ingName, secretName strin
 
 func (t *translator) translateOldIngressV1(ing *networkingv1.Ingress) (*translation.TranslateContext, error) {
 	oldCtx := translation.DefaultEmptyTranslateContext()
+	cluster := utils.BoundClusters(t.ingressAnnotations(ing.Annotations), t.defaultCluster())[0]
 
 	for _, tls := range ing.Spec.TLS {
 		ssl, err := t.translateOldIngressTLS(ing.Namespace, ing.Name, tls.SecretName, tls.Hosts)
@@ -558,24 +820,19 @@ func (t *translator) translateOldIngressV1(ing *networkingv1.Ingress) (*translat
 		}
 		oldCtx.AddSSL(ssl)
 	}
+	var hosts []string
 	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			hosts = append(hosts, rule.Host)
+			continue
+		}
 		for _, pathRule := range rule.HTTP.Paths {
-			name := composeIngressRouteName(ing.Namespace, ing.Name, rule.Host, pathRule.Path)
-			r, err := t.Apisix.Cluster(t.ClusterName).Route().Get(context.Background(), name)
-			if err != nil {
-				continue
-			}
-			if r.UpstreamId != "" {
-				ups := apisixv1.NewDefaultUpstream()
-				ups.ID = r.UpstreamId
-				oldCtx.AddUpstream(ups)
-			}
-			if r.PluginConfigId != "" {
-				pc := apisixv1.NewDefaultPluginConfig()
-				pc.ID = r.PluginConfigId
-				oldCtx.AddPluginConfig(pc)
-			}
-			oldCtx.AddRoute(r)
+			t.translateOldRoute(oldCtx, cluster, composeIngressRouteName(ing.Namespace, ing.Name, rule.Host, pathRule.Path))
+		}
+	}
+	if ing.Spec.DefaultBackend != nil {
+		for _, host := range defaultBackendHosts(hosts) {
+			t.translateOldRoute(oldCtx, cluster, composeIngressRouteName(ing.Namespace, ing.Name, host, _defaultBackendPath))
 		}
 	}
 	return oldCtx, nil
@@ -583,6 +840,7 @@ func (t *translator) translateOldIngressV1(ing *networkingv1.Ingress) (*translat
 
 func (t *translator) translateOldIngressV1beta1(ing *networkingv1beta1.Ingress) (*translation.TranslateContext, error) {
 	oldCtx := translation.DefaultEmptyTranslateContext()
+	cluster := utils.BoundClusters(t.ingressAnnotations(ing.Annotations), t.defaultCluster())[0]
 
 	for _, tls := range ing.Spec.TLS {
 		ssl, err := t.translateOldIngressTLS(ing.Namespace, ing.Name, tls.SecretName, tls.Hosts)
@@ -595,29 +853,49 @@ func (t *translator) translateOldIngressV1beta1(ing *networkingv1beta1.Ingress)
 		}
 		oldCtx.AddSSL(ssl)
 	}
+	var hosts []string
 	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			hosts = append(hosts, rule.Host)
+			continue
+		}
 		for _, pathRule := range rule.HTTP.Paths {
-			name := composeIngressRouteName(ing.Namespace, ing.Name, rule.Host, pathRule.Path)
-			r, err := t.Apisix.Cluster(t.ClusterName).Route().Get(context.Background(), name)
-			if err != nil {
-				continue
-			}
-			if r.UpstreamId != "" {
-				ups := apisixv1.NewDefaultUpstream()
-				ups.ID = r.UpstreamId
-				oldCtx.AddUpstream(ups)
-			}
-			if r.PluginConfigId != "" {
-				pc := apisixv1.NewDefaultPluginConfig()
-				pc.ID = r.PluginConfigId
-				oldCtx.AddPluginConfig(pc)
-			}
-			oldCtx.AddRoute(r)
+			t.translateOldRoute(oldCtx, cluster, composeIngressRouteName(ing.Namespace, ing.Name, rule.Host, pathRule.Path))
+		}
+	}
+	if ing.Spec.Backend != nil {
+		for _, host := range defaultBackendHosts(hosts) {
+			t.translateOldRoute(oldCtx, cluster, composeIngressRouteName(ing.Namespace, ing.Name, host, _defaultBackendPath))
 		}
 	}
 	return oldCtx, nil
 }
 
+// translateOldRoute gets the route from cache and adds it to the context,
+// along with the upstreams and plugin config it refers to.
+func (t *translator) translateOldRoute(oldCtx *translation.TranslateContext, cluster, name string) {
+	r, err := t.Apisix.Cluster(cluster).Route().Get(context.Background(), name)
+	if err != nil {
+		return
+	}
+	if r.UpstreamId != "" {
+		ups := apisixv1.NewDefaultUpstream()
+		ups.ID = r.UpstreamId
+		oldCtx.AddUpstream(ups)
+	}
+	for _, upsID := range trafficSplitUpstreams(r) {
+		ups := apisixv1.NewDefaultUpstream()
+		ups.ID = upsID
+		oldCtx.AddUpstream(ups)
+	}
+	if r.PluginConfigId != "" {
+		pc := apisixv1.NewDefaultPluginConfig()
+		pc.ID = r.PluginConfigId
+		oldCtx.AddPluginConfig(pc)
+	}
+	oldCtx.AddRoute(r)
+}
+
 // In the past, we used host + path directly to form its route name for readability,
 // but this method can cause problems in some scenarios.
 // For example, the generated name is too long.
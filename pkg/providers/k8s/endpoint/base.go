@@ -19,15 +19,22 @@ package endpoint
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	listerscorev1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
 
 	"github.com/apache/apisix-ingress-controller/pkg/config"
 	"github.com/apache/apisix-ingress-controller/pkg/kube"
 	configv2 "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/apis/config/v2"
 	"github.com/apache/apisix-ingress-controller/pkg/log"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/k8s/namespace"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/translation"
 	providertypes "github.com/apache/apisix-ingress-controller/pkg/providers/types"
 	"github.com/apache/apisix-ingress-controller/pkg/providers/utils"
@@ -39,6 +46,11 @@ type baseEndpointController struct {
 	*providertypes.Common
 	translator translation.Translator
 
+	// slowStartEvents are the scheduled events to raise the weights of
+	// the warming nodes, keyed by the namespace/name of the services.
+	slowStartMu     sync.Mutex
+	slowStartEvents map[string]*types.Event
+
 	apisixUpstreamLister kube.ApisixUpstreamLister
 	svcLister            listerscorev1.ServiceLister
 }
@@ -123,3 +135,119 @@ func (c *baseEndpointController) syncEmptyEndpoint(ctx context.Context, ep kube.
 	}
 	return nil
 }
+
+// podEventHandler resyncs the endpoints of the services selecting a pod
+// once its labels or annotations change, since the subsets and weights
+// of the upstream nodes depend on them.
+func (c *baseEndpointController) podEventHandler(resource string, queue workqueue.RateLimitingInterface,
+	namespaceProvider namespace.WatchingNamespaceProvider) cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			prev := oldObj.(*corev1.Pod)
+			curr := newObj.(*corev1.Pod)
+			if prev.GetResourceVersion() >= curr.GetResourceVersion() {
+				return
+			}
+			if !namespaceProvider.IsWatchingNamespace(curr.Namespace + "/" + curr.Name) {
+				return
+			}
+			if labels.Equals(prev.Labels, curr.Labels) && labels.Equals(prev.Annotations, curr.Annotations) {
+				return
+			}
+			for _, ep := range c.endpointsOfPod(curr.Namespace, prev.Labels, curr.Labels) {
+				log.Debugw("pod labels or annotations changed, resync endpoints",
+					zap.String("pod", curr.Namespace+"/"+curr.Name),
+					zap.String("service", ep.ServiceName()),
+				)
+				queue.Add(&types.Event{
+					Type:   types.EventUpdate,
+					Object: ep,
+				})
+				c.MetricsCollector.IncrEvents(resource, "update")
+			}
+		},
+	}
+}
+
+// endpointsOfPod returns the endpoints of the services which select the
+// pod by any of the given label sets.
+func (c *baseEndpointController) endpointsOfPod(namespace string, podLabels ...map[string]string) []kube.Endpoint {
+	svcs, err := c.svcLister.Services(namespace).List(labels.Everything())
+	if err != nil {
+		log.Errorw("failed to list services",
+			zap.Error(err),
+			zap.String("namespace", namespace),
+		)
+		return nil
+	}
+	var eps []kube.Endpoint
+	for _, svc := range svcs {
+		if len(svc.Spec.Selector) == 0 {
+			continue
+		}
+		selector := labels.SelectorFromSet(svc.Spec.Selector)
+		for _, set := range podLabels {
+			if !selector.Matches(labels.Set(set)) {
+				continue
+			}
+			ep, err := c.EpLister.GetEndpoint(namespace, svc.Name)
+			if err == nil {
+				// Make sure the endpoints are not empty.
+				_, err = ep.Namespace()
+			}
+			if err != nil {
+				log.Warnw("failed to get endpoints of service",
+					zap.Error(err),
+					zap.String("namespace", namespace),
+					zap.String("service", svc.Name),
+				)
+			} else {
+				eps = append(eps, ep)
+			}
+			break
+		}
+	}
+	return eps
+}
+
+// scheduleSlowStart enqueues another sync of the endpoints after the slow
+// start interval if some of their nodes are warming up, so that the weights
+// of the nodes grow step by step. At most one sync is scheduled for the
+// endpoints of a service at a time.
+func (c *baseEndpointController) scheduleSlowStart(queue workqueue.RateLimitingInterface, ev *types.Event, ep kube.Endpoint) {
+	namespace, err := ep.Namespace()
+	if err != nil {
+		return
+	}
+	svc, err := c.svcLister.Services(namespace).Get(ep.ServiceName())
+	if err != nil {
+		return
+	}
+	var interval time.Duration
+	for _, port := range svc.Spec.Ports {
+		if d := c.translator.SlowStartInterval(ep, port.Port); d > 0 && (interval == 0 || d < interval) {
+			interval = d
+		}
+	}
+
+	key := namespace + "/" + svc.Name
+	c.slowStartMu.Lock()
+	defer c.slowStartMu.Unlock()
+	if pending, ok := c.slowStartEvents[key]; ok && pending != ev {
+		// Another sync has been scheduled.
+		return
+	}
+	if interval == 0 {
+		delete(c.slowStartEvents, key)
+		return
+	}
+	if c.slowStartEvents == nil {
+		c.slowStartEvents = make(map[string]*types.Event)
+	}
+	next := &types.Event{
+		Type:   types.EventUpdate,
+		Object: ep,
+	}
+	c.slowStartEvents[key] = next
+	queue.AddAfter(next, interval)
+}
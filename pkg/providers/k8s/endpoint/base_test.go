@@ -0,0 +1,166 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+package endpoint
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/apache/apisix-ingress-controller/pkg/apisix"
+	"github.com/apache/apisix-ingress-controller/pkg/config"
+	"github.com/apache/apisix-ingress-controller/pkg/kube"
+	apisixfake "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/client/clientset/versioned/fake"
+	apisixinformers "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/client/informers/externalversions"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/translation"
+	providertypes "github.com/apache/apisix-ingress-controller/pkg/providers/types"
+	"github.com/apache/apisix-ingress-controller/pkg/types"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+type fakeUpstream struct {
+	apisix.Upstream
+	upstreams map[string]*apisixv1.Upstream
+}
+
+func (u *fakeUpstream) Get(_ context.Context, name string) (*apisixv1.Upstream, error) {
+	ups, ok := u.upstreams[name]
+	if !ok {
+		return nil, apisix.ErrNotFound
+	}
+	return ups.DeepCopy(), nil
+}
+
+func (u *fakeUpstream) Update(_ context.Context, ups *apisixv1.Upstream, _ bool) (*apisixv1.Upstream, error) {
+	u.upstreams[ups.Name] = ups
+	return ups, nil
+}
+
+type fakeCluster struct {
+	apisix.Cluster
+	upstream *fakeUpstream
+}
+
+func (c *fakeCluster) Upstream() apisix.Upstream {
+	return c.upstream
+}
+
+func (c *fakeCluster) String() string {
+	return "fake"
+}
+
+type fakeAPISIX struct {
+	apisix.APISIX
+	cluster *fakeCluster
+}
+
+func (a *fakeAPISIX) ListClusters() []apisix.Cluster {
+	return []apisix.Cluster{a.cluster}
+}
+
+type fakeTranslator struct {
+	translation.Translator
+}
+
+func (t *fakeTranslator) TranslateEndpoint(ep kube.Endpoint, port int32, _ types.Labels) (apisixv1.UpstreamNodes, error) {
+	var nodes apisixv1.UpstreamNodes
+	for _, hp := range ep.Endpoints(&corev1.ServicePort{Name: "grpc", Port: port}) {
+		nodes = append(nodes, apisixv1.UpstreamNode{Host: hp.Host, Port: hp.Port, Weight: 100})
+	}
+	return nodes, nil
+}
+
+func newLeaderElector(t *testing.T) *leaderelection.LeaderElector {
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock: &resourcelock.LeaseLock{
+			LeaseMeta:  metav1.ObjectMeta{Namespace: "default", Name: "ingress-apisix-leader"},
+			Client:     fake.NewSimpleClientset().CoordinationV1(),
+			LockConfig: resourcelock.ResourceLockConfig{Identity: "test"},
+		},
+		LeaseDuration: 15 * time.Second,
+		RenewDeadline: 10 * time.Second,
+		RetryPeriod:   2 * time.Second,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(context.Context) {},
+			OnStoppedLeading: func() {},
+		},
+	})
+	assert.Nil(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go elector.Run(ctx)
+	assert.Eventually(t, elector.IsLeader, 5*time.Second, 10*time.Millisecond)
+	return elector
+}
+
+func TestSyncEndpointGRPCRouteUpstream(t *testing.T) {
+	informersFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+	apisixFactory := apisixinformers.NewSharedInformerFactory(apisixfake.NewSimpleClientset(), 0)
+	svcInformer := informersFactory.Core().V1().Services().Informer()
+	assert.Nil(t, svcInformer.GetIndexer().Add(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "grpc"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Name: "grpc", Port: 50051}},
+		},
+	}))
+
+	// The upstream which the GRPCRoute referring to the Service is translated to.
+	name := apisixv1.ComposeUpstreamName("default", "grpc", "", 50051, types.ResolveGranularity.Endpoint)
+	upstream := &fakeUpstream{
+		upstreams: map[string]*apisixv1.Upstream{
+			name: {
+				Metadata: apisixv1.Metadata{Name: name},
+				Scheme:   apisixv1.SchemeGRPC,
+				Nodes:    apisixv1.UpstreamNodes{{Host: "10.0.0.1", Port: 50051, Weight: 100}},
+			},
+		},
+	}
+	c := &baseEndpointController{
+		Common: &providertypes.Common{
+			Config:  config.NewDefaultConfig(),
+			APISIX:  &fakeAPISIX{cluster: &fakeCluster{upstream: upstream}},
+			Elector: newLeaderElector(t),
+		},
+		translator:           &fakeTranslator{},
+		svcLister:            informersFactory.Core().V1().Services().Lister(),
+		apisixUpstreamLister: kube.NewApisixUpstreamLister(apisixFactory.Apisix().V2().ApisixUpstreams().Lister()),
+	}
+
+	ep := kube.NewEndpoint(&corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "grpc"},
+		Subsets: []corev1.EndpointSubset{
+			{
+				Addresses: []corev1.EndpointAddress{{IP: "10.0.0.2"}, {IP: "10.0.0.3"}},
+				Ports:     []corev1.EndpointPort{{Name: "grpc", Port: 50051}},
+			},
+		},
+	})
+	assert.Nil(t, c.syncEndpoint(context.Background(), ep))
+
+	ups := upstream.upstreams[name]
+	assert.Equal(t, apisixv1.SchemeGRPC, ups.Scheme)
+	assert.ElementsMatch(t, apisixv1.UpstreamNodes{
+		{Host: "10.0.0.2", Port: 50051, Weight: 100},
+		{Host: "10.0.0.3", Port: 50051, Weight: 100},
+	}, ups.Nodes)
+}
@@ -62,6 +62,7 @@ func newEndpointsController(base *baseEndpointController, namespaceProvider name
 			DeleteFunc: ctl.onDelete,
 		},
 	)
+	ctl.PodInformer.AddEventHandler(ctl.podEventHandler("endpoints", ctl.workqueue, namespaceProvider))
 
 	return ctl
 }
@@ -109,7 +110,9 @@ func (c *endpointsController) sync(ctx context.Context, ev *types.Event) error {
 		}
 		return err
 	}
-	return c.syncEndpoint(ctx, newestEp)
+	err = c.syncEndpoint(ctx, newestEp)
+	c.scheduleSlowStart(c.workqueue, ev, newestEp)
+	return err
 }
 
 func (c *endpointsController) handleSyncErr(obj interface{}, err error) {
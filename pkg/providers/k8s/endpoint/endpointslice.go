@@ -67,6 +67,7 @@ func newEndpointSliceController(base *baseEndpointController, namespaceProvider
 			DeleteFunc: c.onDelete,
 		},
 	)
+	c.PodInformer.AddEventHandler(c.podEventHandler("endpointSlice", c.workqueue, namespaceProvider))
 
 	return c
 }
@@ -118,7 +119,9 @@ func (c *endpointSliceController) sync(ctx context.Context, ev *types.Event) err
 		}
 		return err
 	}
-	return c.syncEndpoint(ctx, newestEp)
+	err = c.syncEndpoint(ctx, newestEp)
+	c.scheduleSlowStart(c.workqueue, ev, newestEp)
+	return err
 }
 
 func (c *endpointSliceController) handleSyncErr(obj interface{}, err error) {
@@ -0,0 +1,59 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package translation
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"time"
+)
+
+// CertificateError means the certificate in a Secret is unusable.
+type CertificateError struct {
+	Secret string
+	Reason string
+}
+
+func (ce *CertificateError) Error() string {
+	return fmt.Sprintf("invalid certificate in Secret %s: %s", ce.Secret, ce.Reason)
+}
+
+// ValidateKeyPair checks that the private key matches the certificate and
+// the certificate is in its validity period, the leaf certificate is returned.
+func ValidateKeyPair(secret string, cert, key []byte) (*x509.Certificate, error) {
+	pair, err := tls.X509KeyPair(cert, key)
+	if err != nil {
+		return nil, &CertificateError{Secret: secret, Reason: err.Error()}
+	}
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, &CertificateError{Secret: secret, Reason: err.Error()}
+	}
+	now := time.Now()
+	if now.After(leaf.NotAfter) {
+		return nil, &CertificateError{
+			Secret: secret,
+			Reason: "certificate expired at " + leaf.NotAfter.Format(time.RFC3339),
+		}
+	}
+	if now.Before(leaf.NotBefore) {
+		return nil, &CertificateError{
+			Secret: secret,
+			Reason: "certificate is not valid before " + leaf.NotBefore.Format(time.RFC3339),
+		}
+	}
+	return leaf, nil
+}
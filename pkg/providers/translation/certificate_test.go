@@ -0,0 +1,68 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package translation
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func generateCertificate(t *testing.T, notBefore, notAfter time.Time, dnsNames ...string) ([]byte, []byte) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		DNSNames:     dnsNames,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &priv.PublicKey, priv)
+	assert.Nil(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(priv)
+	assert.Nil(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func TestValidateKeyPair(t *testing.T) {
+	now := time.Now()
+	cert, key := generateCertificate(t, now.Add(-time.Hour), now.Add(time.Hour), "foo.com")
+	leaf, err := ValidateKeyPair("default/foo", cert, key)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"foo.com"}, leaf.DNSNames)
+
+	_, otherKey := generateCertificate(t, now.Add(-time.Hour), now.Add(time.Hour), "foo.com")
+	_, err = ValidateKeyPair("default/foo", cert, otherKey)
+	assert.ErrorContains(t, err, "invalid certificate in Secret default/foo")
+	assert.ErrorContains(t, err, "private key does not match public key")
+
+	cert, key = generateCertificate(t, now.Add(-2*time.Hour), now.Add(-time.Hour), "foo.com")
+	_, err = ValidateKeyPair("default/foo", cert, key)
+	assert.ErrorContains(t, err, "certificate expired at")
+
+	cert, key = generateCertificate(t, now.Add(time.Hour), now.Add(2*time.Hour), "foo.com")
+	_, err = ValidateKeyPair("default/foo", cert, key)
+	assert.ErrorContains(t, err, "certificate is not valid before")
+}
@@ -24,6 +24,7 @@ type TranslateContext struct {
 	UpstreamMap   map[string]struct{}
 	SSL           []*apisix.Ssl
 	PluginConfigs []*apisix.PluginConfig
+	Services      []*apisix.Service
 	GlobalRules   []*apisix.GlobalRule
 }
 
@@ -46,10 +47,15 @@ func (tc *TranslateContext) AddStreamRoute(sr *apisix.StreamRoute) {
 }
 
 func (tc *TranslateContext) AddUpstream(u *apisix.Upstream) {
-	if _, ok := tc.UpstreamMap[u.Name]; ok {
+	// Upstreams rebuilt from the old routes carry the ID only.
+	key := u.Name
+	if key == "" {
+		key = u.ID
+	}
+	if _, ok := tc.UpstreamMap[key]; ok {
 		return
 	}
-	tc.UpstreamMap[u.Name] = struct{}{}
+	tc.UpstreamMap[key] = struct{}{}
 	tc.Upstreams = append(tc.Upstreams, u)
 }
 
@@ -62,6 +68,10 @@ func (tc *TranslateContext) AddPluginConfig(pc *apisix.PluginConfig) {
 	tc.PluginConfigs = append(tc.PluginConfigs, pc)
 }
 
+func (tc *TranslateContext) AddService(svc *apisix.Service) {
+	tc.Services = append(tc.Services, svc)
+}
+
 func (tc *TranslateContext) AddGlobalRule(gr *apisix.GlobalRule) {
 	tc.GlobalRules = append(tc.GlobalRules, gr)
 }
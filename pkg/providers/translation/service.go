@@ -18,6 +18,8 @@ package translation
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
@@ -32,6 +34,19 @@ import (
 	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
 )
 
+// _remoteZonePriority is the priority of the nodes outside the zone of
+// APISIX when the topology aware routing is enabled, the nodes in the
+// local zone have the default priority 0.
+const _remoteZonePriority = -1
+
+// _slowStartSteps is the number of steps in which the weights of the
+// warming nodes grow to the full value.
+const _slowStartSteps = 10
+
+// _terminatingWeight is the weight of the terminating but still serving
+// nodes, which are only used when no ready nodes remain.
+const _terminatingWeight = 1
+
 func (t *translator) TranslateService(namespace, name, subset string, port int32) (*apisixv1.Upstream, error) {
 	endpoint, err := t.EndpointLister.GetEndpoint(namespace, name)
 	if err != nil {
@@ -115,80 +130,306 @@ func (t *translator) translateUpstreamV2(ep *kube.Endpoint, namespace, name, sub
 }
 
 func (t *translator) TranslateEndpoint(endpoint kube.Endpoint, port int32, labels types.Labels) (apisixv1.UpstreamNodes, error) {
+	namespace, svcPort, err := t.servicePort(endpoint, port)
+	if err != nil {
+		return nil, err
+	}
+	svcName := endpoint.ServiceName()
+	upsCfgs := t.apisixUpstreamConfigs(namespace, svcName, port)
+	hostports := endpoint.Endpoints(svcPort)
+	if labels != nil {
+		hostports = t.filterEndpointsByLabels(hostports, labels, namespace)
+	}
+	hostports = filterTerminatingEndpoints(hostports, upsCfgs)
+
+	// As nodes is not optional, here we create an empty slice,
+	// not a nil slice.
+	nodes := make(apisixv1.UpstreamNodes, 0)
+	topologyAware := t.topologyAware(upsCfgs)
+	nodeWeight := nodeWeightConfig(upsCfgs)
+	slowStart := slowStartConfig(upsCfgs)
+	now := time.Now()
+	for _, hostport := range hostports {
+		node := apisixv1.UpstreamNode{
+			Host:   hostport.Host,
+			Port:   hostport.Port,
+			Weight: DefaultWeight,
+		}
+		if nodeWeight != nil {
+			node.Weight = t.nodeWeight(hostport, namespace, nodeWeight)
+		}
+		if slowStart != nil {
+			node.Weight = t.slowStartWeight(hostport, namespace, slowStart, node.Weight, now)
+		}
+		if hostport.Terminating {
+			node.Weight = _terminatingWeight
+		}
+		if topologyAware && !t.inTopologyZone(hostport) {
+			// APISIX only uses the nodes in other zones when all
+			// the nodes in the local zone are unavailable.
+			node.Priority = _remoteZonePriority
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+func (t *translator) SlowStartInterval(endpoint kube.Endpoint, port int32) time.Duration {
+	namespace, svcPort, err := t.servicePort(endpoint, port)
+	if err != nil {
+		return 0
+	}
+	slowStart := slowStartConfig(t.apisixUpstreamConfigs(namespace, endpoint.ServiceName(), port))
+	if slowStart == nil {
+		return 0
+	}
+	now := time.Now()
+	for _, hostport := range endpoint.Endpoints(svcPort) {
+		if hostport.Terminating {
+			continue
+		}
+		if elapsed, ok := t.readyDuration(hostport, namespace, now); ok && elapsed < slowStart.Window.Duration {
+			interval := slowStart.Window.Duration / _slowStartSteps
+			if interval < time.Second {
+				interval = time.Second
+			}
+			return interval
+		}
+	}
+	return 0
+}
+
+// servicePort returns the namespace of the endpoints and the port of their
+// service.
+func (t *translator) servicePort(endpoint kube.Endpoint, port int32) (string, *corev1.ServicePort, error) {
 	namespace, err := endpoint.Namespace()
 	if err != nil {
 		log.Errorw("failed to get endpoint namespace",
 			zap.Error(err),
 			zap.Any("endpoint", endpoint),
 		)
-		return nil, err
+		return "", nil, err
 	}
 	svcName := endpoint.ServiceName()
 	svc, err := t.ServiceLister.Services(namespace).Get(svcName)
 	if err != nil {
-		return nil, &TranslateError{
+		return "", nil, &TranslateError{
 			Field:  "service",
 			Reason: err.Error(),
 		}
 	}
 
-	var svcPort *corev1.ServicePort
 	for _, exposePort := range svc.Spec.Ports {
 		if exposePort.Port == port {
-			svcPort = &exposePort
-			break
+			return namespace, &exposePort, nil
 		}
 	}
-	if svcPort == nil {
-		return nil, &TranslateError{
-			Field:  "service.spec.ports",
-			Reason: "port not defined",
+	return "", nil, &TranslateError{
+		Field:  "service.spec.ports",
+		Reason: "port not defined",
+	}
+}
+
+// apisixUpstreamConfigs returns the configurations of the ApisixUpstream
+// which apply to the service port, in the ascending order of precedence,
+// i.e. the port level settings come after the ApisixUpstream ones.
+func (t *translator) apisixUpstreamConfigs(namespace, name string, port int32) []*v2.ApisixUpstreamConfig {
+	if t.ApisixUpstreamLister == nil {
+		return nil
+	}
+	au, err := t.ApisixUpstreamLister.V2(namespace, name)
+	if err != nil || au == nil || au.V2().Spec == nil {
+		return nil
+	}
+	spec := au.V2().Spec
+	if !utils.MatchCRDsIngressClass(spec.IngressClassName, t.IngressClassName) {
+		return nil
+	}
+	cfgs := []*v2.ApisixUpstreamConfig{&spec.ApisixUpstreamConfig}
+	for i := range spec.PortLevelSettings {
+		if spec.PortLevelSettings[i].Port == port {
+			cfgs = append(cfgs, &spec.PortLevelSettings[i].ApisixUpstreamConfig)
+			break
 		}
 	}
-	// As nodes is not optional, here we create an empty slice,
-	// not a nil slice.
-	nodes := make(apisixv1.UpstreamNodes, 0)
-	for _, hostport := range endpoint.Endpoints(svcPort) {
-		nodes = append(nodes, apisixv1.UpstreamNode{
-			Host: hostport.Host,
-			Port: hostport.Port,
-			// FIXME Custom node weight
-			Weight: DefaultWeight,
-		})
+	return cfgs
+}
+
+// topologyAware tells whether the nodes of the service port should prefer
+// the local zone, the ApisixUpstream of the service overrides the global
+// option.
+func (t *translator) topologyAware(upsCfgs []*v2.ApisixUpstreamConfig) bool {
+	if t.TopologyZone == "" {
+		return false
 	}
-	if labels != nil {
-		nodes = t.filterNodesByLabels(nodes, labels, namespace)
-		return nodes, nil
+	enabled := t.TopologyAwareRouting
+	for _, cfg := range upsCfgs {
+		if cfg.TopologyAware != nil {
+			enabled = *cfg.TopologyAware
+		}
 	}
-	return nodes, nil
+	return enabled
 }
 
-func (t *translator) filterNodesByLabels(nodes apisixv1.UpstreamNodes, labels types.Labels, namespace string) apisixv1.UpstreamNodes {
-	if labels == nil {
-		return nodes
+// inTopologyZone tells whether the endpoint should serve the zone of APISIX.
+// The topology aware hints take precedence over the zone of the endpoint,
+// endpoints without any topology information are considered to be local.
+func (t *translator) inTopologyZone(hostport kube.HostPort) bool {
+	if len(hostport.ZoneHints) > 0 {
+		for _, zone := range hostport.ZoneHints {
+			if zone == t.TopologyZone {
+				return true
+			}
+		}
+		return false
 	}
+	return hostport.Zone == "" || hostport.Zone == t.TopologyZone
+}
 
-	filteredNodes := make(apisixv1.UpstreamNodes, 0)
-	for _, node := range nodes {
-		podName, err := t.PodProvider.GetPodCache().GetNameByIP(node.Host)
-		if err != nil {
-			log.Errorw("failed to find pod name by ip, ignore it",
-				zap.Error(err),
-				zap.String("pod_ip", node.Host),
+// nodeWeightConfig returns the node weight configuration which takes
+// precedence, nil if none of the configurations has it.
+func nodeWeightConfig(upsCfgs []*v2.ApisixUpstreamConfig) *v2.ApisixUpstreamNodeWeight {
+	var nodeWeight *v2.ApisixUpstreamNodeWeight
+	for _, cfg := range upsCfgs {
+		if cfg.NodeWeight != nil {
+			nodeWeight = cfg.NodeWeight
+		}
+	}
+	return nodeWeight
+}
+
+// nodeWeight decides the weight of the endpoint by the annotation and
+// labels of its pod, DefaultWeight is used if neither of them matches.
+func (t *translator) nodeWeight(hostport kube.HostPort, namespace string, nodeWeight *v2.ApisixUpstreamNodeWeight) int {
+	pod, err := t.podOfEndpoint(hostport, namespace)
+	if err != nil {
+		return DefaultWeight
+	}
+	if nodeWeight.Annotation != "" {
+		if value, ok := pod.Annotations[nodeWeight.Annotation]; ok {
+			weight, err := strconv.Atoi(value)
+			if err == nil && weight >= 0 {
+				return weight
+			}
+			log.Warnw("invalid node weight in pod annotation, ignore it",
+				zap.String("annotation", nodeWeight.Annotation),
+				zap.String("value", value),
+				zap.String("pod_name", pod.Name),
 			)
-			continue
 		}
-		pod, err := t.PodLister.Pods(namespace).Get(podName)
+	}
+	for _, rule := range nodeWeight.Rules {
+		if types.Labels(rule.Labels).IsSubsetOf(pod.Labels) {
+			return rule.Weight
+		}
+	}
+	return DefaultWeight
+}
+
+// slowStartConfig returns the slow start configuration which takes
+// precedence, nil if none of the configurations has it.
+func slowStartConfig(upsCfgs []*v2.ApisixUpstreamConfig) *v2.ApisixUpstreamSlowStart {
+	var slowStart *v2.ApisixUpstreamSlowStart
+	for _, cfg := range upsCfgs {
+		if cfg.SlowStart != nil {
+			slowStart = cfg.SlowStart
+		}
+	}
+	if slowStart == nil || slowStart.Window.Duration <= 0 {
+		return nil
+	}
+	return slowStart
+}
+
+// slowStartWeight scales the weight of the endpoint by the time elapsed
+// since its pod became ready, from the initial percentage at the beginning
+// to the full weight at the end of the slow start window.
+func (t *translator) slowStartWeight(hostport kube.HostPort, namespace string, slowStart *v2.ApisixUpstreamSlowStart, weight int, now time.Time) int {
+	elapsed, ok := t.readyDuration(hostport, namespace, now)
+	if !ok || elapsed >= slowStart.Window.Duration || weight == 0 {
+		return weight
+	}
+	percentage := float64(slowStart.InitialPercentage) +
+		float64(100-slowStart.InitialPercentage)*float64(elapsed)/float64(slowStart.Window.Duration)
+	scaled := int(float64(weight) * percentage / 100)
+	if scaled < 1 {
+		// Zero weight stops the traffic to the node completely.
+		scaled = 1
+	}
+	return scaled
+}
+
+// readyDuration returns how long the pod of the endpoint has been ready.
+func (t *translator) readyDuration(hostport kube.HostPort, namespace string, now time.Time) (time.Duration, bool) {
+	pod, err := t.podOfEndpoint(hostport, namespace)
+	if err != nil {
+		return 0, false
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue && !cond.LastTransitionTime.IsZero() {
+			return now.Sub(cond.LastTransitionTime.Time), true
+		}
+	}
+	return 0, false
+}
+
+// filterTerminatingEndpoints drops the terminating endpoints so that they
+// are drained, unless no ready endpoints remain and the ApisixUpstream
+// doesn't ask to drop them, in which case they keep taking the traffic
+// until they stop serving.
+func filterTerminatingEndpoints(hostports []kube.HostPort, upsCfgs []*v2.ApisixUpstreamConfig) []kube.HostPort {
+	policy := v2.TerminatingEndpointsFallback
+	for _, cfg := range upsCfgs {
+		if cfg.TerminatingEndpoints != "" {
+			policy = cfg.TerminatingEndpoints
+		}
+	}
+	ready := make([]kube.HostPort, 0, len(hostports))
+	for _, hostport := range hostports {
+		if !hostport.Terminating {
+			ready = append(ready, hostport)
+		}
+	}
+	if len(ready) == 0 && policy == v2.TerminatingEndpointsFallback {
+		return hostports
+	}
+	return ready
+}
+
+func (t *translator) filterEndpointsByLabels(hostports []kube.HostPort, labels types.Labels, namespace string) []kube.HostPort {
+	if labels == nil {
+		return hostports
+	}
+
+	filtered := make([]kube.HostPort, 0)
+	for _, hostport := range hostports {
+		pod, err := t.podOfEndpoint(hostport, namespace)
 		if err != nil {
-			log.Errorw("failed to find pod, ignore it",
-				zap.Error(err),
-				zap.String("pod_name", podName),
-			)
 			continue
 		}
 		if labels.IsSubsetOf(pod.Labels) {
-			filteredNodes = append(filteredNodes, node)
+			filtered = append(filtered, hostport)
 		}
 	}
-	return filteredNodes
+	return filtered
+}
+
+func (t *translator) podOfEndpoint(hostport kube.HostPort, namespace string) (*corev1.Pod, error) {
+	podName, err := t.PodProvider.GetPodCache().GetNameByIP(hostport.Host)
+	if err != nil {
+		log.Errorw("failed to find pod name by ip, ignore it",
+			zap.Error(err),
+			zap.String("pod_ip", hostport.Host),
+		)
+		return nil, err
+	}
+	pod, err := t.PodLister.Pods(namespace).Get(podName)
+	if err != nil {
+		log.Errorw("failed to find pod, ignore it",
+			zap.Error(err),
+			zap.String("pod_name", podName),
+		)
+		return nil, err
+	}
+	return pod, nil
 }
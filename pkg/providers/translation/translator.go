@@ -16,6 +16,7 @@ package translation
 
 import (
 	"fmt"
+	"time"
 
 	listerscorev1 "k8s.io/client-go/listers/core/v1"
 
@@ -57,6 +58,11 @@ type Translator interface {
 	// according to the give port. Extra labels can be passed to filter the ultimate
 	// upstream nodes.
 	TranslateEndpoint(kube.Endpoint, int32, types.Labels) (apisixv1.UpstreamNodes, error)
+	// SlowStartInterval returns the interval to translate the Endpoints again
+	// for the given port, so that the weights of the nodes which are warming up
+	// according to the slow start of ApisixUpstream grow. Zero means none of
+	// the nodes is warming up.
+	SlowStartInterval(kube.Endpoint, int32) time.Duration
 }
 
 // TranslatorOptions contains options to help Translator
@@ -65,6 +71,13 @@ type TranslatorOptions struct {
 	APIVersion       string
 	IngressClassName string
 
+	// TopologyAwareRouting makes the upstream nodes prefer the endpoints
+	// in TopologyZone, unless the ApisixUpstream says otherwise.
+	TopologyAwareRouting bool
+	// TopologyZone is the zone where APISIX runs, the topology aware
+	// routing is disabled if it's empty.
+	TopologyZone string
+
 	EndpointLister       kube.EndpointLister
 	ServiceLister        listerscorev1.ServiceLister
 	SecretLister         listerscorev1.SecretLister
@@ -17,6 +17,7 @@ package translation
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -25,10 +26,13 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
+	listerscorev1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/apache/apisix-ingress-controller/pkg/kube"
 	configv2 "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/apis/config/v2"
+	listersv2 "github.com/apache/apisix-ingress-controller/pkg/kube/apisix/client/listers/config/v2"
+	"github.com/apache/apisix-ingress-controller/pkg/types"
 	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
 )
 
@@ -343,3 +347,509 @@ func TestTranslateUpstreamNodesWithEndpointSlices(t *testing.T) {
 		},
 	}, nodes)
 }
+
+func TestTranslateUpstreamNodesTopologyAware(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "svc",
+			Namespace: "test",
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name: "port1",
+					Port: 80,
+				},
+				{
+					Name: "port2",
+					Port: 443,
+				},
+			},
+		},
+	}
+	isTrue := true
+	port1 := int32(9080)
+	port2 := int32(9443)
+	port1Name := "port1"
+	port2Name := "port2"
+	zoneA := "zone-a"
+	zoneB := "zone-b"
+	ep := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "svc",
+			Namespace: "test",
+			Labels: map[string]string{
+				discoveryv1.LabelServiceName: "svc",
+			},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{
+			{
+				Addresses:  []string{"192.168.1.1"},
+				Conditions: discoveryv1.EndpointConditions{Ready: &isTrue},
+				Zone:       &zoneA,
+			},
+			{
+				Addresses:  []string{"192.168.1.2"},
+				Conditions: discoveryv1.EndpointConditions{Ready: &isTrue},
+				Zone:       &zoneB,
+			},
+			{
+				// Hinted to serve zone-a although it resides in zone-b.
+				Addresses:  []string{"192.168.1.3"},
+				Conditions: discoveryv1.EndpointConditions{Ready: &isTrue},
+				Zone:       &zoneB,
+				Hints: &discoveryv1.EndpointHints{
+					ForZones: []discoveryv1.ForZone{{Name: zoneA}},
+				},
+			},
+		},
+		Ports: []discoveryv1.EndpointPort{
+			{
+				Name: &port1Name,
+				Port: &port1,
+			},
+			{
+				Name: &port2Name,
+				Port: &port2,
+			},
+		},
+	}
+
+	svcIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.Nil(t, svcIndexer.Add(svc))
+	auIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+
+	tr := &translator{&TranslatorOptions{
+		IngressClassName:     "apisix",
+		ServiceLister:        listerscorev1.NewServiceLister(svcIndexer),
+		ApisixUpstreamLister: kube.NewApisixUpstreamLister(listersv2.NewApisixUpstreamLister(auIndexer)),
+		TopologyAwareRouting: true,
+		TopologyZone:         zoneA,
+	}}
+
+	nodes, err := tr.TranslateEndpoint(kube.NewEndpointWithSlice(ep), 80, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, apisixv1.UpstreamNodes{
+		{
+			Host:   "192.168.1.1",
+			Port:   9080,
+			Weight: 100,
+		},
+		{
+			Host:     "192.168.1.2",
+			Port:     9080,
+			Weight:   100,
+			Priority: -1,
+		},
+		{
+			Host:   "192.168.1.3",
+			Port:   9080,
+			Weight: 100,
+		},
+	}, nodes)
+
+	// The port level settings of ApisixUpstream override the global option.
+	disabled := false
+	assert.Nil(t, auIndexer.Add(&configv2.ApisixUpstream{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "svc",
+			Namespace: "test",
+		},
+		Spec: &configv2.ApisixUpstreamSpec{
+			PortLevelSettings: []configv2.PortLevelSettings{
+				{
+					Port: 443,
+					ApisixUpstreamConfig: configv2.ApisixUpstreamConfig{
+						TopologyAware: &disabled,
+					},
+				},
+			},
+		},
+	}))
+	nodes, err = tr.TranslateEndpoint(kube.NewEndpointWithSlice(ep), 443, nil)
+	assert.Nil(t, err)
+	for _, node := range nodes {
+		assert.Equal(t, 0, node.Priority)
+	}
+	nodes, err = tr.TranslateEndpoint(kube.NewEndpointWithSlice(ep), 80, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, -1, nodes[1].Priority)
+
+	// Nothing changes without the zone of APISIX.
+	tr.TopologyZone = ""
+	nodes, err = tr.TranslateEndpoint(kube.NewEndpointWithSlice(ep), 80, nil)
+	assert.Nil(t, err)
+	for _, node := range nodes {
+		assert.Equal(t, 0, node.Priority)
+	}
+}
+
+func TestTranslateUpstreamNodesTerminating(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "svc",
+			Namespace: "test",
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name: "port1",
+					Port: 80,
+				},
+			},
+		},
+	}
+	isTrue := true
+	isFalse := false
+	port1 := int32(9080)
+	port1Name := "port1"
+	readyEndpoint := discoveryv1.Endpoint{
+		Addresses: []string{"192.168.1.1"},
+		Conditions: discoveryv1.EndpointConditions{
+			Ready:       &isTrue,
+			Serving:     &isTrue,
+			Terminating: &isFalse,
+		},
+	}
+	terminatingEndpoint := discoveryv1.Endpoint{
+		Addresses: []string{"192.168.1.2"},
+		Conditions: discoveryv1.EndpointConditions{
+			Ready:       &isFalse,
+			Serving:     &isTrue,
+			Terminating: &isTrue,
+		},
+	}
+	stoppedEndpoint := discoveryv1.Endpoint{
+		Addresses: []string{"192.168.1.3"},
+		Conditions: discoveryv1.EndpointConditions{
+			Ready:       &isFalse,
+			Serving:     &isFalse,
+			Terminating: &isTrue,
+		},
+	}
+	newEndpointSlice := func(endpoints ...discoveryv1.Endpoint) kube.Endpoint {
+		return kube.NewEndpointWithSlice(&discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "svc",
+				Namespace: "test",
+				Labels: map[string]string{
+					discoveryv1.LabelServiceName: "svc",
+				},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints:   endpoints,
+			Ports: []discoveryv1.EndpointPort{
+				{
+					Name: &port1Name,
+					Port: &port1,
+				},
+			},
+		})
+	}
+
+	svcIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.Nil(t, svcIndexer.Add(svc))
+	auIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+
+	tr := &translator{&TranslatorOptions{
+		IngressClassName:     "apisix",
+		ServiceLister:        listerscorev1.NewServiceLister(svcIndexer),
+		ApisixUpstreamLister: kube.NewApisixUpstreamLister(listersv2.NewApisixUpstreamLister(auIndexer)),
+	}}
+
+	// The terminating endpoints are drained while ready endpoints remain.
+	nodes, err := tr.TranslateEndpoint(newEndpointSlice(readyEndpoint, terminatingEndpoint, stoppedEndpoint), 80, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, apisixv1.UpstreamNodes{
+		{
+			Host:   "192.168.1.1",
+			Port:   9080,
+			Weight: 100,
+		},
+	}, nodes)
+
+	nodes, err = tr.TranslateEndpoint(newEndpointSlice(terminatingEndpoint, stoppedEndpoint), 80, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, apisixv1.UpstreamNodes{
+		{
+			Host:   "192.168.1.2",
+			Port:   9080,
+			Weight: 1,
+		},
+	}, nodes)
+
+	assert.Nil(t, auIndexer.Add(&configv2.ApisixUpstream{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "svc",
+			Namespace: "test",
+		},
+		Spec: &configv2.ApisixUpstreamSpec{
+			ApisixUpstreamConfig: configv2.ApisixUpstreamConfig{
+				TerminatingEndpoints: configv2.TerminatingEndpointsDrop,
+			},
+		},
+	}))
+	nodes, err = tr.TranslateEndpoint(newEndpointSlice(terminatingEndpoint, stoppedEndpoint), 80, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, apisixv1.UpstreamNodes{}, nodes)
+}
+
+type fakePodProvider struct {
+	podCache types.PodCache
+}
+
+func (p *fakePodProvider) Run(_ context.Context) {}
+
+func (p *fakePodProvider) GetPodCache() types.PodCache {
+	return p.podCache
+}
+
+func TestTranslateUpstreamNodesWeight(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "svc",
+			Namespace: "test",
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name: "port1",
+					Port: 80,
+				},
+			},
+		},
+	}
+	newPod := func(name, ip, version string, annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "test",
+				Labels:      map[string]string{"version": version},
+				Annotations: annotations,
+			},
+			Status: corev1.PodStatus{
+				PodIP: ip,
+			},
+		}
+	}
+	pods := []*corev1.Pod{
+		newPod("pod1", "192.168.1.1", "v1", nil),
+		newPod("pod2", "192.168.1.2", "v2", nil),
+		newPod("pod3", "192.168.1.3", "v2", map[string]string{"weight": "5"}),
+		newPod("pod4", "192.168.1.4", "v3", map[string]string{"weight": "invalid"}),
+	}
+	isTrue := true
+	port1 := int32(9080)
+	port1Name := "port1"
+	ep := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "svc",
+			Namespace: "test",
+			Labels: map[string]string{
+				discoveryv1.LabelServiceName: "svc",
+			},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Ports: []discoveryv1.EndpointPort{
+			{
+				Name: &port1Name,
+				Port: &port1,
+			},
+		},
+	}
+
+	svcIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.Nil(t, svcIndexer.Add(svc))
+	podIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	podCache := types.NewPodCache()
+	for _, pod := range pods {
+		assert.Nil(t, podIndexer.Add(pod))
+		assert.Nil(t, podCache.Add(pod))
+		ep.Endpoints = append(ep.Endpoints, discoveryv1.Endpoint{
+			Addresses:  []string{pod.Status.PodIP},
+			Conditions: discoveryv1.EndpointConditions{Ready: &isTrue},
+		})
+	}
+	auIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.Nil(t, auIndexer.Add(&configv2.ApisixUpstream{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "svc",
+			Namespace: "test",
+		},
+		Spec: &configv2.ApisixUpstreamSpec{
+			ApisixUpstreamConfig: configv2.ApisixUpstreamConfig{
+				NodeWeight: &configv2.ApisixUpstreamNodeWeight{
+					Annotation: "weight",
+					Rules: []configv2.ApisixUpstreamNodeWeightRule{
+						{
+							Labels: map[string]string{"version": "v2"},
+							Weight: 10,
+						},
+						{
+							Labels: map[string]string{"version": "v3"},
+							Weight: 20,
+						},
+					},
+				},
+			},
+		},
+	}))
+
+	tr := &translator{&TranslatorOptions{
+		IngressClassName:     "apisix",
+		ServiceLister:        listerscorev1.NewServiceLister(svcIndexer),
+		PodLister:            listerscorev1.NewPodLister(podIndexer),
+		ApisixUpstreamLister: kube.NewApisixUpstreamLister(listersv2.NewApisixUpstreamLister(auIndexer)),
+		PodProvider:          &fakePodProvider{podCache: podCache},
+	}}
+
+	nodes, err := tr.TranslateEndpoint(kube.NewEndpointWithSlice(ep), 80, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, apisixv1.UpstreamNodes{
+		{
+			Host:   "192.168.1.1",
+			Port:   9080,
+			Weight: 100,
+		},
+		{
+			Host:   "192.168.1.2",
+			Port:   9080,
+			Weight: 10,
+		},
+		{
+			Host:   "192.168.1.3",
+			Port:   9080,
+			Weight: 5,
+		},
+		{
+			Host:   "192.168.1.4",
+			Port:   9080,
+			Weight: 20,
+		},
+	}, nodes)
+
+	// The weights apply to the nodes of subsets as well.
+	nodes, err = tr.TranslateEndpoint(kube.NewEndpointWithSlice(ep), 80, types.Labels{"version": "v2"})
+	assert.Nil(t, err)
+	assert.Equal(t, apisixv1.UpstreamNodes{
+		{
+			Host:   "192.168.1.2",
+			Port:   9080,
+			Weight: 10,
+		},
+		{
+			Host:   "192.168.1.3",
+			Port:   9080,
+			Weight: 5,
+		},
+	}, nodes)
+}
+
+func TestTranslateUpstreamNodesSlowStart(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "svc",
+			Namespace: "test",
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name: "port1",
+					Port: 80,
+				},
+			},
+		},
+	}
+	now := time.Now()
+	newPod := func(name, ip string, readyTime time.Time) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test",
+			},
+			Status: corev1.PodStatus{
+				PodIP: ip,
+				Conditions: []corev1.PodCondition{
+					{
+						Type:               corev1.PodReady,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(readyTime),
+					},
+				},
+			},
+		}
+	}
+	pods := []*corev1.Pod{
+		newPod("pod1", "192.168.1.1", now.Add(-time.Hour)),
+		newPod("pod2", "192.168.1.2", now.Add(-50*time.Second)),
+		newPod("pod3", "192.168.1.3", now),
+	}
+	isTrue := true
+	port1 := int32(9080)
+	port1Name := "port1"
+	ep := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "svc",
+			Namespace: "test",
+			Labels: map[string]string{
+				discoveryv1.LabelServiceName: "svc",
+			},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Ports: []discoveryv1.EndpointPort{
+			{
+				Name: &port1Name,
+				Port: &port1,
+			},
+		},
+	}
+
+	svcIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.Nil(t, svcIndexer.Add(svc))
+	podIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	podCache := types.NewPodCache()
+	for _, pod := range pods {
+		assert.Nil(t, podIndexer.Add(pod))
+		assert.Nil(t, podCache.Add(pod))
+		ep.Endpoints = append(ep.Endpoints, discoveryv1.Endpoint{
+			Addresses:  []string{pod.Status.PodIP},
+			Conditions: discoveryv1.EndpointConditions{Ready: &isTrue},
+		})
+	}
+	auIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+
+	tr := &translator{&TranslatorOptions{
+		IngressClassName:     "apisix",
+		ServiceLister:        listerscorev1.NewServiceLister(svcIndexer),
+		PodLister:            listerscorev1.NewPodLister(podIndexer),
+		ApisixUpstreamLister: kube.NewApisixUpstreamLister(listersv2.NewApisixUpstreamLister(auIndexer)),
+		PodProvider:          &fakePodProvider{podCache: podCache},
+	}}
+
+	// Nodes take the full weight without slow start.
+	assert.Equal(t, time.Duration(0), tr.SlowStartInterval(kube.NewEndpointWithSlice(ep), 80))
+
+	assert.Nil(t, auIndexer.Add(&configv2.ApisixUpstream{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "svc",
+			Namespace: "test",
+		},
+		Spec: &configv2.ApisixUpstreamSpec{
+			ApisixUpstreamConfig: configv2.ApisixUpstreamConfig{
+				SlowStart: &configv2.ApisixUpstreamSlowStart{
+					Window:            metav1.Duration{Duration: 100 * time.Second},
+					InitialPercentage: 10,
+				},
+			},
+		},
+	}))
+
+	nodes, err := tr.TranslateEndpoint(kube.NewEndpointWithSlice(ep), 80, nil)
+	assert.Nil(t, err)
+	assert.Len(t, nodes, 3)
+	assert.Equal(t, 100, nodes[0].Weight)
+	assert.InDelta(t, 55, nodes[1].Weight, 1)
+	assert.InDelta(t, 10, nodes[2].Weight, 1)
+	assert.Equal(t, 10*time.Second, tr.SlowStartInterval(kube.NewEndpointWithSlice(ep), 80))
+}
@@ -19,11 +19,17 @@ package types
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/go-multierror"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/informers"
 	listerscorev1 "k8s.io/client-go/listers/core/v1"
+	listersnetworkingv1 "k8s.io/client-go/listers/networking/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/record"
@@ -69,6 +75,11 @@ type ListerInformer struct {
 	IngressLister   kube.IngressLister
 	IngressInformer cache.SharedIndexInformer
 
+	// IngressClassLister and IngressClassInformer are nil unless Ingress
+	// networking/v1 is used.
+	IngressClassLister   listersnetworkingv1.IngressClassLister
+	IngressClassInformer cache.SharedIndexInformer
+
 	ApisixUpstreamInformer      cache.SharedIndexInformer
 	ApisixRouteInformer         cache.SharedIndexInformer
 	ApisixPluginConfigInformer  cache.SharedIndexInformer
@@ -149,17 +160,77 @@ func (c *Common) RecordEventS(object runtime.Object, eventtype, reason string, m
 	c.Recorder.Event(object, eventtype, reason, msg)
 }
 
-// TODO: Move sync utils to apisix.APISIX interface?
-func (c *Common) SyncManifests(ctx context.Context, added, updated, deleted *utils.Manifest, shouldCompare bool) error {
-	if !c.Elector.IsLeader() && !c.Config.EtcdServer.Enabled {
+// _certExpiryWarningPeriod is how long before the expiry of a certificate
+// the warning events are recorded.
+const _certExpiryWarningPeriod = 30 * 24 * time.Hour
+
+// MonitorCertificate exposes the expiry time of the certificate in the SSL
+// object, which comes from the Secret (namespace/name), and records warning
+// events on the object if the certificate is close to expiry or doesn't
+// cover all the SNIs.
+func (c *Common) MonitorCertificate(object runtime.Object, secret string, ssl *apisixv1.Ssl, evType types.EventType) {
+	if evType == types.EventDelete {
+		for _, sni := range ssl.Snis {
+			c.MetricsCollector.DeleteCertificateExpiry(secret, sni)
+		}
+		return
+	}
+	cert, err := utils.ParseCertificate([]byte(ssl.Cert))
+	if err != nil {
+		log.Warnw("failed to parse certificate",
+			zap.Error(err),
+			zap.String("secret", secret),
+		)
+		return
+	}
+	for _, sni := range ssl.Snis {
+		c.MetricsCollector.SetCertificateExpiry(secret, sni, cert.NotAfter)
+	}
+	if uncovered := utils.HostsNotCoveredByCert(cert, ssl.Snis); len(uncovered) > 0 {
+		msg := fmt.Sprintf("hosts %s are not covered by the certificate in Secret %s", strings.Join(uncovered, ", "), secret)
+		log.Warnw(msg)
+		c.RecordEventS(object, corev1.EventTypeWarning, utils.CertificateHostsNotCovered, msg)
+	}
+	if time.Until(cert.NotAfter) < _certExpiryWarningPeriod {
+		msg := fmt.Sprintf("certificate in Secret %s expires at %s", secret, cert.NotAfter.Format(time.RFC3339))
+		log.Warnw(msg)
+		c.RecordEventS(object, corev1.EventTypeWarning, utils.CertificateExpiring, msg)
+	}
+}
+
+// DeleteStaleCertificateExpiry deletes the expiry time of the certificates
+// served before, unless they are still served with the same Secret for the
+// SNI. Both olds and news map the Secrets (namespace/name) to the SNIs. It's
+// used when the Secret or the hosts of an object change.
+func (c *Common) DeleteStaleCertificateExpiry(olds, news map[string][]string) {
+	for secret, snis := range olds {
+		for _, sni := range snis {
+			if !utils.Contains(news[secret], sni) {
+				c.MetricsCollector.DeleteCertificateExpiry(secret, sni)
+			}
+		}
+	}
+}
+
+// BoundClusters returns the names of APISIX clusters that the object
+// should be synced to, see utils.ClusterBindingAnnotation.
+func (c *Common) BoundClusters(obj metav1.Object) []string {
+	if obj == nil {
 		return nil
 	}
-	return utils.SyncManifests(ctx, c.APISIX, c.Config.APISIX.DefaultClusterName, added, updated, deleted, shouldCompare)
+	return utils.BoundClusters(obj.GetAnnotations(), c.Config.APISIX.DefaultClusterName)
+}
+
+// TODO: Move sync utils to apisix.APISIX interface?
+func (c *Common) SyncManifests(ctx context.Context, added, updated, deleted *utils.Manifest, shouldCompare bool) error {
+	return c.SyncClusterManifests(ctx, c.Config.APISIX.DefaultClusterName, added, updated, deleted, shouldCompare)
 }
 
-// TODO: support multiple cluster
 func (c *Common) SyncClusterManifests(ctx context.Context, clusterName string, added, updated, deleted *utils.Manifest, shouldCompare bool) error {
-	if clusterName != c.Config.APISIX.DefaultClusterName {
+	if !c.Elector.IsLeader() && !c.Config.EtcdServer.Enabled {
+		return nil
+	}
+	if !c.APISIX.HasCluster(clusterName) {
 		log.Errorw("cluster does not exist",
 			zap.String("cluster_name", clusterName),
 		)
@@ -168,17 +239,63 @@ func (c *Common) SyncClusterManifests(ctx context.Context, clusterName string, a
 	return utils.SyncManifests(ctx, c.APISIX, clusterName, added, updated, deleted, shouldCompare)
 }
 
-func (c *Common) SyncSSL(ctx context.Context, ssl *apisixv1.Ssl, event types.EventType) error {
+// SyncBoundManifests syncs the manifest of a resource to all the clusters it's
+// bound to. For update events, clusters in both bindings receive the diff between
+// m and om, newly bound clusters receive m and unbound clusters get om deleted.
+// Sync events with om receive m as a whole, along with the deletion of the
+// objects of om which are no longer in m.
+func (c *Common) SyncBoundManifests(ctx context.Context, event types.EventType, clusters, oldClusters []string, m, om *utils.Manifest) error {
+	var merr *multierror.Error
+	for _, cluster := range clusters {
+		var added, updated, deleted *utils.Manifest
+		if event == types.EventDelete {
+			deleted = m
+		} else if event.IsAddEvent() || !utils.Contains(oldClusters, cluster) {
+			added = m
+			if event.IsSyncEvent() && om != nil && utils.Contains(oldClusters, cluster) {
+				_, _, deleted = m.Diff(om)
+			}
+		} else if om != nil {
+			added, updated, deleted = m.Diff(om)
+		}
+		log.Debugw("sync manifests to cluster",
+			zap.String("event_type", event.String()),
+			zap.String("cluster", cluster),
+			zap.Any("add", added),
+			zap.Any("update", updated),
+			zap.Any("delete", deleted),
+		)
+		if err := c.SyncClusterManifests(ctx, cluster, added, updated, deleted, event.IsSyncEvent()); err != nil {
+			merr = multierror.Append(merr, err)
+		}
+	}
+	if (event == types.EventUpdate || event.IsSyncEvent()) && om != nil {
+		for _, cluster := range utils.Difference(oldClusters, clusters) {
+			log.Debugw("remove manifests from unbound cluster",
+				zap.String("cluster", cluster),
+				zap.Any("delete", om),
+			)
+			if err := c.SyncClusterManifests(ctx, cluster, nil, nil, om, false); err != nil {
+				merr = multierror.Append(merr, err)
+			}
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+func (c *Common) SyncSSL(ctx context.Context, clusterName string, ssl *apisixv1.Ssl, event types.EventType) error {
 	var (
 		err error
 	)
-	clusterName := c.Config.APISIX.DefaultClusterName
 	if event == types.EventDelete {
 		err = c.APISIX.Cluster(clusterName).SSL().Delete(ctx, ssl)
 	} else if event == types.EventUpdate {
 		_, err = c.APISIX.Cluster(clusterName).SSL().Update(ctx, ssl, false)
 	} else {
 		_, err = c.APISIX.Cluster(clusterName).SSL().Create(ctx, ssl, event.IsSyncEvent())
+		if err == nil {
+			utils.MigrateLegacyObjects(ctx, c.APISIX, clusterName, &utils.Manifest{SSLs: []*apisixv1.Ssl{ssl}})
+		}
 	}
 	return err
 }
@@ -195,8 +312,7 @@ func (c *Common) SyncPluginMetadata(ctx context.Context, pm *apisixv1.PluginMeta
 	return err
 }
 
-func (c *Common) SyncConsumer(ctx context.Context, consumer *apisixv1.Consumer, event types.EventType) (err error) {
-	clusterName := c.Config.APISIX.DefaultClusterName
+func (c *Common) SyncConsumer(ctx context.Context, clusterName string, consumer *apisixv1.Consumer, event types.EventType) (err error) {
 	if event == types.EventDelete {
 		err = c.APISIX.Cluster(clusterName).Consumer().Delete(ctx, consumer)
 	} else if event == types.EventUpdate {
@@ -250,10 +366,11 @@ func (c *Common) SyncUpstreamNodesChangeToCluster(ctx context.Context, cluster a
 		zap.String("cluster", cluster.String()),
 	)
 
-	updated := &utils.Manifest{
-		Upstreams: []*apisixv1.Upstream{upstream},
+	if !c.Elector.IsLeader() && !c.Config.EtcdServer.Enabled {
+		return nil
 	}
-	return c.SyncManifests(ctx, nil, updated, nil, false)
+	_, err = cluster.Upstream().Update(ctx, upstream, false)
+	return err
 }
 
 func compareUpstreamNodes(old, new apisixv1.UpstreamNodes) bool {
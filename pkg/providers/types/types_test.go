@@ -0,0 +1,228 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package types
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/apache/apisix-ingress-controller/pkg/apisix"
+	"github.com/apache/apisix-ingress-controller/pkg/config"
+	"github.com/apache/apisix-ingress-controller/pkg/metrics"
+	"github.com/apache/apisix-ingress-controller/pkg/providers/utils"
+	"github.com/apache/apisix-ingress-controller/pkg/types"
+	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
+)
+
+type fakeCollector struct {
+	metrics.Collector
+	deleted []string
+}
+
+func (c *fakeCollector) DeleteCertificateExpiry(secret, sni string) {
+	c.deleted = append(c.deleted, secret+":"+sni)
+}
+
+func TestDeleteStaleCertificateExpiry(t *testing.T) {
+	collector := &fakeCollector{}
+	c := &Common{MetricsCollector: collector}
+
+	// The hosts of the certificate changed.
+	c.DeleteStaleCertificateExpiry(
+		map[string][]string{"default/cert": {"foo.com", "bar.com"}},
+		map[string][]string{"default/cert": {"foo.com", "baz.com"}},
+	)
+	assert.Equal(t, []string{"default/cert:bar.com"}, collector.deleted)
+
+	// The Secret changed.
+	collector.deleted = nil
+	c.DeleteStaleCertificateExpiry(
+		map[string][]string{"default/cert": {"foo.com"}},
+		map[string][]string{"default/cert2": {"foo.com"}},
+	)
+	assert.Equal(t, []string{"default/cert:foo.com"}, collector.deleted)
+
+	// Nothing changed.
+	collector.deleted = nil
+	c.DeleteStaleCertificateExpiry(
+		map[string][]string{"default/cert": {"foo.com"}},
+		map[string][]string{"default/cert": {"foo.com"}},
+	)
+	assert.Len(t, collector.deleted, 0)
+}
+
+type fakeRoute struct {
+	apisix.Route
+	created []string
+	updated []string
+	deleted []string
+}
+
+func (r *fakeRoute) Create(_ context.Context, route *apisixv1.Route, _ bool) (*apisixv1.Route, error) {
+	r.created = append(r.created, route.ID)
+	return route, nil
+}
+
+func (r *fakeRoute) Update(_ context.Context, route *apisixv1.Route, _ bool) (*apisixv1.Route, error) {
+	r.updated = append(r.updated, route.ID)
+	return route, nil
+}
+
+func (r *fakeRoute) Delete(_ context.Context, route *apisixv1.Route) error {
+	r.deleted = append(r.deleted, route.ID)
+	return nil
+}
+
+type fakeCluster struct {
+	apisix.Cluster
+	route *fakeRoute
+}
+
+func (c *fakeCluster) Route() apisix.Route {
+	return c.route
+}
+
+type fakeAPISIX struct {
+	apisix.APISIX
+	clusters map[string]*fakeCluster
+}
+
+func (a *fakeAPISIX) HasCluster(name string) bool {
+	_, ok := a.clusters[name]
+	return ok
+}
+
+func (a *fakeAPISIX) Cluster(name string) apisix.Cluster {
+	return a.clusters[name]
+}
+
+func newFakeCommon(t *testing.T, clusters ...string) (*Common, *fakeAPISIX) {
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock: &resourcelock.LeaseLock{
+			LeaseMeta:  metav1.ObjectMeta{Namespace: "default", Name: "ingress-apisix-leader"},
+			Client:     fake.NewSimpleClientset().CoordinationV1(),
+			LockConfig: resourcelock.ResourceLockConfig{Identity: "test"},
+		},
+		LeaseDuration: 15 * time.Second,
+		RenewDeadline: 10 * time.Second,
+		RetryPeriod:   2 * time.Second,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(context.Context) {},
+			OnStoppedLeading: func() {},
+		},
+	})
+	assert.Nil(t, err)
+	cfg := config.NewDefaultConfig()
+	// Bypass the leader election.
+	cfg.EtcdServer.Enabled = true
+	apisix := &fakeAPISIX{clusters: make(map[string]*fakeCluster)}
+	for _, name := range clusters {
+		apisix.clusters[name] = &fakeCluster{route: &fakeRoute{}}
+	}
+	return &Common{Config: cfg, Elector: elector, APISIX: apisix}, apisix
+}
+
+func TestSyncBoundManifestsClusterChange(t *testing.T) {
+	c, apisix := newFakeCommon(t, "old", "new")
+	om := &utils.Manifest{
+		Routes: []*apisixv1.Route{
+			{Metadata: apisixv1.Metadata{ID: "r1"}},
+			{Metadata: apisixv1.Metadata{ID: "r2"}},
+		},
+	}
+	m := &utils.Manifest{
+		Routes: []*apisixv1.Route{
+			{Metadata: apisixv1.Metadata{ID: "r1"}},
+		},
+	}
+
+	// The Ingress moved to another cluster.
+	err := c.SyncBoundManifests(context.Background(), types.EventSync, []string{"new"}, []string{"old"}, m, om)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"r1"}, apisix.clusters["new"].route.created)
+	assert.Len(t, apisix.clusters["new"].route.deleted, 0)
+	assert.Len(t, apisix.clusters["old"].route.created, 0)
+	assert.Equal(t, []string{"r1", "r2"}, apisix.clusters["old"].route.deleted)
+
+	// The cluster stays, the routes which are gone are deleted.
+	apisix.clusters["new"].route = &fakeRoute{}
+	err = c.SyncBoundManifests(context.Background(), types.EventSync, []string{"new"}, []string{"new"}, m, om)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"r1"}, apisix.clusters["new"].route.created)
+	assert.Equal(t, []string{"r2"}, apisix.clusters["new"].route.deleted)
+}
+
+func TestSyncBoundManifestsBindingAnnotationChange(t *testing.T) {
+	c, apisix := newFakeCommon(t, "internal", "edge", "canary")
+
+	old := &metav1.ObjectMeta{
+		Annotations: map[string]string{utils.ClusterBindingAnnotation: "internal,edge"},
+	}
+	obj := &metav1.ObjectMeta{
+		Annotations: map[string]string{utils.ClusterBindingAnnotation: "edge, canary"},
+	}
+	om := &utils.Manifest{
+		Routes: []*apisixv1.Route{
+			{Metadata: apisixv1.Metadata{ID: "r1"}},
+			{Metadata: apisixv1.Metadata{ID: "r2"}},
+		},
+	}
+	m := &utils.Manifest{
+		Routes: []*apisixv1.Route{
+			{Metadata: apisixv1.Metadata{ID: "r1"}, Uri: "/foo"},
+			{Metadata: apisixv1.Metadata{ID: "r3"}},
+		},
+	}
+
+	err := c.SyncBoundManifests(context.Background(), types.EventUpdate, c.BoundClusters(obj), c.BoundClusters(old), m, om)
+	assert.Nil(t, err)
+
+	// The objects are removed from the unbound cluster.
+	internal := apisix.clusters["internal"].route
+	assert.Len(t, internal.created, 0)
+	assert.Len(t, internal.updated, 0)
+	assert.Equal(t, []string{"r1", "r2"}, internal.deleted)
+
+	// The cluster bound before and after receives the diff.
+	edge := apisix.clusters["edge"].route
+	assert.Equal(t, []string{"r3"}, edge.created)
+	assert.Equal(t, []string{"r1"}, edge.updated)
+	assert.Equal(t, []string{"r2"}, edge.deleted)
+
+	// The newly bound cluster receives all the objects.
+	canary := apisix.clusters["canary"].route
+	assert.Equal(t, []string{"r1", "r3"}, canary.created)
+	assert.Len(t, canary.updated, 0)
+	assert.Len(t, canary.deleted, 0)
+
+	// The annotation is removed, the objects are moved to the default cluster.
+	apisix.clusters[c.Config.APISIX.DefaultClusterName] = &fakeCluster{route: &fakeRoute{}}
+	edge.created, edge.updated, edge.deleted = nil, nil, nil
+	canary.created = nil
+	err = c.SyncBoundManifests(context.Background(), types.EventUpdate, c.BoundClusters(&metav1.ObjectMeta{}), c.BoundClusters(obj), m, m)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"r1", "r3"}, apisix.clusters[c.Config.APISIX.DefaultClusterName].route.created)
+	assert.Equal(t, []string{"r1", "r3"}, edge.deleted)
+	assert.Equal(t, []string{"r1", "r3"}, canary.deleted)
+}
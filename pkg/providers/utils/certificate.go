@@ -0,0 +1,59 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"strings"
+)
+
+// ParseCertificate parses the leaf certificate in the PEM encoded certificate chain.
+func ParseCertificate(cert []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(cert)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("failed to decode PEM certificate")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// HostsNotCoveredByCert returns the hosts which are not covered by the
+// subject alternative names of the certificate.
+func HostsNotCoveredByCert(cert *x509.Certificate, hosts []string) []string {
+	var uncovered []string
+	for _, host := range hosts {
+		if strings.HasPrefix(host, "*.") {
+			// A wildcard host is covered only by the same wildcard SAN,
+			// x509.Certificate.VerifyHostname doesn't accept it.
+			covered := false
+			for _, name := range cert.DNSNames {
+				if strings.EqualFold(name, host) {
+					covered = true
+					break
+				}
+			}
+			if !covered {
+				uncovered = append(uncovered, host)
+			}
+			continue
+		}
+		if cert.VerifyHostname(host) != nil {
+			uncovered = append(uncovered, host)
+		}
+	}
+	return uncovered
+}
@@ -0,0 +1,63 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func generateCertificate(t *testing.T, notBefore, notAfter time.Time, dnsNames ...string) ([]byte, []byte) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		DNSNames:     dnsNames,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &priv.PublicKey, priv)
+	assert.Nil(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(priv)
+	assert.Nil(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func TestHostsNotCoveredByCert(t *testing.T) {
+	now := time.Now()
+	certPEM, _ := generateCertificate(t, now.Add(-time.Hour), now.Add(time.Hour), "foo.com", "*.bar.com")
+	cert, err := ParseCertificate(certPEM)
+	assert.Nil(t, err)
+
+	assert.Nil(t, HostsNotCoveredByCert(cert, []string{"foo.com", "api.bar.com", "*.bar.com"}))
+	assert.Equal(t, []string{"baz.com", "a.b.bar.com", "*.foo.com"},
+		HostsNotCoveredByCert(cert, []string{"baz.com", "a.b.bar.com", "*.foo.com"}))
+
+	_, err = ParseCertificate([]byte("not a certificate"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,52 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package utils
+
+import "strings"
+
+// ClusterBindingAnnotation binds a resource to one or more APISIX clusters,
+// the value is a comma separated list of ApisixClusterConfig names, e.g.
+// "internal,edge". Resources without this annotation are only synced to
+// the default cluster.
+const ClusterBindingAnnotation = "k8s.apisix.apache.org/apisix-clusters"
+
+// BoundClusters returns the APISIX cluster names that a resource with the
+// given annotations is bound to. Duplicated and empty names are dropped, and
+// the default cluster is returned if no cluster is specified.
+func BoundClusters(annotations map[string]string, defaultCluster string) []string {
+	value, ok := annotations[ClusterBindingAnnotation]
+	if !ok {
+		return []string{defaultCluster}
+	}
+	var (
+		clusters []string
+		seen     = make(map[string]struct{})
+	)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		clusters = append(clusters, name)
+	}
+	if len(clusters) == 0 {
+		return []string{defaultCluster}
+	}
+	return clusters
+}
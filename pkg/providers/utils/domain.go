@@ -68,3 +68,26 @@ func IsHostnameMatch(listener, route string) bool {
 
 	return true
 }
+
+// IntersectHostname returns the intersection of the listener and route
+// hostname, which is the more specific one of them, the empty string means
+// any hostname. It reports false if the hostnames don't intersect.
+func IntersectHostname(listener, route string) (string, bool) {
+	if !IsHostnameMatch(listener, route) {
+		return "", false
+	}
+	switch {
+	case listener == "":
+		return route, true
+	case route == "":
+		return listener, true
+	case !strings.HasPrefix(route, "*"):
+		return route, true
+	case !strings.HasPrefix(listener, "*"):
+		return listener, true
+	case len(route) >= len(listener):
+		return route, true
+	default:
+		return listener, true
+	}
+}
@@ -44,3 +44,27 @@ func TestHostnameMatch(t *testing.T) {
 		assert.False(t, IsHostnameMatch(wildcardHostname, i))
 	}
 }
+
+func TestIntersectHostname(t *testing.T) {
+	cases := []struct {
+		listener string
+		route    string
+		expected string
+		ok       bool
+	}{
+		{listener: "", route: "", expected: "", ok: true},
+		{listener: "", route: "foo.sample.com", expected: "foo.sample.com", ok: true},
+		{listener: "*.sample.com", route: "", expected: "*.sample.com", ok: true},
+		{listener: "*.sample.com", route: "foo.sample.com", expected: "foo.sample.com", ok: true},
+		{listener: "foo.sample.com", route: "*.sample.com", expected: "foo.sample.com", ok: true},
+		{listener: "*.sample.com", route: "*.foo.sample.com", expected: "*.foo.sample.com", ok: true},
+		{listener: "*.foo.sample.com", route: "*.sample.com", expected: "*.foo.sample.com", ok: true},
+		{listener: "*.sample.com", route: "sample.com", ok: false},
+		{listener: "foo.sample.com", route: "bar.sample.com", ok: false},
+	}
+	for _, c := range cases {
+		hostname, ok := IntersectHostname(c.listener, c.route)
+		assert.Equal(t, c.ok, ok, "listener %q route %q", c.listener, c.route)
+		assert.Equal(t, c.expected, hostname, "listener %q route %q", c.listener, c.route)
+	}
+}
@@ -19,3 +19,13 @@ package utils
 func PtrOf[T any](v T) *T {
 	return &v
 }
+
+// Contains reports whether v is present in s.
+func Contains[T comparable](s []T, v T) bool {
+	for _, elem := range s {
+		if elem == v {
+			return true
+		}
+	}
+	return false
+}
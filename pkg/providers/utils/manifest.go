@@ -23,6 +23,7 @@ import (
 
 	"github.com/apache/apisix-ingress-controller/pkg/apisix"
 	"github.com/apache/apisix-ingress-controller/pkg/apisix/cache"
+	"github.com/apache/apisix-ingress-controller/pkg/id"
 	"github.com/apache/apisix-ingress-controller/pkg/log"
 	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
 )
@@ -166,6 +167,31 @@ func DiffPluginConfigs(olds, news []*apisixv1.PluginConfig) (added, updated, del
 	return
 }
 
+func DiffServices(olds, news []*apisixv1.Service) (added, updated, deleted []*apisixv1.Service) {
+	oldMap := make(map[string]*apisixv1.Service, len(olds))
+	newMap := make(map[string]*apisixv1.Service, len(news))
+	for _, s := range olds {
+		oldMap[s.ID] = s
+	}
+	for _, s := range news {
+		newMap[s.ID] = s
+	}
+
+	for _, s := range news {
+		if ou, ok := oldMap[s.ID]; !ok {
+			added = append(added, s)
+		} else if !reflect.DeepEqual(ou, s) {
+			updated = append(updated, s)
+		}
+	}
+	for _, s := range olds {
+		if _, ok := newMap[s.ID]; !ok {
+			deleted = append(deleted, s)
+		}
+	}
+	return
+}
+
 func DiffPluginMetadatas(olds, news []*apisixv1.PluginMetadata) (added, updated, deleted []*apisixv1.PluginMetadata) {
 	oldMap := make(map[string]*apisixv1.PluginMetadata, len(olds))
 	newMap := make(map[string]*apisixv1.PluginMetadata, len(news))
@@ -222,6 +248,7 @@ type Manifest struct {
 	StreamRoutes    []*apisixv1.StreamRoute
 	SSLs            []*apisixv1.Ssl
 	PluginConfigs   []*apisixv1.PluginConfig
+	Services        []*apisixv1.Service
 	PluginMetadatas []*apisixv1.PluginMetadata
 	GlobalRules     []*apisixv1.GlobalRule
 }
@@ -232,6 +259,7 @@ func (m *Manifest) Diff(om *Manifest) (added, updated, deleted *Manifest) {
 	au, uu, du := DiffUpstreams(om.Upstreams, m.Upstreams)
 	asr, usr, dsr := DiffStreamRoutes(om.StreamRoutes, m.StreamRoutes)
 	apc, upc, dpc := DiffPluginConfigs(om.PluginConfigs, m.PluginConfigs)
+	as, us, ds := DiffServices(om.Services, m.Services)
 	apm, upm, dpm := DiffPluginMetadatas(om.PluginMetadatas, m.PluginMetadatas)
 	agr, ugr, dgr := DiffGlobalRules(om.GlobalRules, m.GlobalRules)
 
@@ -241,6 +269,7 @@ func (m *Manifest) Diff(om *Manifest) (added, updated, deleted *Manifest) {
 		StreamRoutes:    asr,
 		SSLs:            sa,
 		PluginConfigs:   apc,
+		Services:        as,
 		PluginMetadatas: apm,
 		GlobalRules:     agr,
 	}
@@ -250,6 +279,7 @@ func (m *Manifest) Diff(om *Manifest) (added, updated, deleted *Manifest) {
 		StreamRoutes:    usr,
 		SSLs:            su,
 		PluginConfigs:   upc,
+		Services:        us,
 		PluginMetadatas: upm,
 		GlobalRules:     ugr,
 	}
@@ -259,6 +289,7 @@ func (m *Manifest) Diff(om *Manifest) (added, updated, deleted *Manifest) {
 		StreamRoutes:    dsr,
 		SSLs:            sd,
 		PluginConfigs:   dpc,
+		Services:        ds,
 		PluginMetadatas: dpm,
 		GlobalRules:     dgr,
 	}
@@ -287,6 +318,11 @@ func SyncManifests(ctx context.Context, apisix apisix.APISIX, clusterName string
 				merr = multierror.Append(merr, err)
 			}
 		}
+		for _, s := range added.Services {
+			if _, err := apisix.Cluster(clusterName).Service().Create(ctx, s, shouldCompare); err != nil {
+				merr = multierror.Append(merr, err)
+			}
+		}
 		for _, r := range added.Routes {
 			if _, err := apisix.Cluster(clusterName).Route().Create(ctx, r, shouldCompare); err != nil {
 				merr = multierror.Append(merr, err)
@@ -307,6 +343,11 @@ func SyncManifests(ctx context.Context, apisix apisix.APISIX, clusterName string
 				merr = multierror.Append(merr, err)
 			}
 		}
+		// Only remove the objects with legacy IDs after their replacements
+		// were created, so there is no traffic gap.
+		if merr == nil {
+			MigrateLegacyObjects(ctx, apisix, clusterName, added)
+		}
 	}
 	if updated != nil {
 		for _, ssl := range updated.SSLs {
@@ -324,6 +365,11 @@ func SyncManifests(ctx context.Context, apisix apisix.APISIX, clusterName string
 				merr = multierror.Append(merr, err)
 			}
 		}
+		for _, s := range updated.Services {
+			if _, err := apisix.Cluster(clusterName).Service().Update(ctx, s, false); err != nil {
+				merr = multierror.Append(merr, err)
+			}
+		}
 		for _, r := range updated.Routes {
 			if _, err := apisix.Cluster(clusterName).Route().Update(ctx, r, false); err != nil {
 				merr = multierror.Append(merr, err)
@@ -365,6 +411,19 @@ func SyncManifests(ctx context.Context, apisix apisix.APISIX, clusterName string
 				merr = multierror.Append(merr, err)
 			}
 		}
+		for _, s := range deleted.Services {
+			if err := apisix.Cluster(clusterName).Service().Delete(ctx, s); err != nil {
+				// Service might be referenced by other routes.
+				if err != cache.ErrStillInUse {
+					merr = multierror.Append(merr, err)
+				} else {
+					log.Infow("service was referenced by other routes",
+						zap.String("service_id", s.ID),
+						zap.String("service_name", s.Name),
+					)
+				}
+			}
+		}
 		for _, u := range deleted.Upstreams {
 			if err := apisix.Cluster(clusterName).Upstream().Delete(ctx, u); err != nil {
 				// Upstream might be referenced by other routes.
@@ -456,3 +515,78 @@ func SyncManifests(ctx context.Context, apisix apisix.APISIX, clusterName string
 	}
 	return nil
 }
+
+// MigrateLegacyObjects removes the objects which were created with the legacy
+// (CRC32) IDs of the objects in the manifest, it's a no-op unless the ID
+// migration mode is enabled. Routes are removed before services, upstreams
+// and plugin configs as the latter can't be removed while being referenced.
+// The clients refuse to remove the objects still referenced by the routes in
+// the cache, so a legacy upstream shared by several routes is only removed
+// once the legacy routes of all its owners are gone. Failures are only logged
+// since the removal will be retried in the next sync.
+func MigrateLegacyObjects(ctx context.Context, apisix apisix.APISIX, clusterName string, m *Manifest) {
+	migrate := func(kind, newID string, remove func(legacyID string) error) {
+		legacyID, ok := id.LegacyID(clusterName, newID)
+		if !ok {
+			return
+		}
+		if legacyID == newID {
+			id.MarkMigrated(clusterName, newID)
+			return
+		}
+		if err := remove(legacyID); err == cache.ErrStillInUse {
+			log.Debugw("object with legacy id is still in use, remove it later",
+				zap.String("kind", kind),
+				zap.String("id", newID),
+				zap.String("legacy_id", legacyID),
+			)
+			return
+		} else if err != nil {
+			log.Warnw("failed to remove object with legacy id",
+				zap.String("kind", kind),
+				zap.String("id", newID),
+				zap.String("legacy_id", legacyID),
+				zap.Error(err),
+			)
+			return
+		}
+		log.Infow("removed object with legacy id",
+			zap.String("kind", kind),
+			zap.String("id", newID),
+			zap.String("legacy_id", legacyID),
+		)
+		id.MarkMigrated(clusterName, newID)
+	}
+
+	cluster := apisix.Cluster(clusterName)
+	for _, r := range m.Routes {
+		migrate("route", r.ID, func(legacyID string) error {
+			return cluster.Route().Delete(ctx, &apisixv1.Route{Metadata: apisixv1.Metadata{ID: legacyID, Name: r.Name}})
+		})
+	}
+	for _, sr := range m.StreamRoutes {
+		migrate("stream_route", sr.ID, func(legacyID string) error {
+			return cluster.StreamRoute().Delete(ctx, &apisixv1.StreamRoute{ID: legacyID})
+		})
+	}
+	for _, s := range m.Services {
+		migrate("service", s.ID, func(legacyID string) error {
+			return cluster.Service().Delete(ctx, &apisixv1.Service{Metadata: apisixv1.Metadata{ID: legacyID, Name: s.Name}})
+		})
+	}
+	for _, pc := range m.PluginConfigs {
+		migrate("plugin_config", pc.ID, func(legacyID string) error {
+			return cluster.PluginConfig().Delete(ctx, &apisixv1.PluginConfig{Metadata: apisixv1.Metadata{ID: legacyID, Name: pc.Name}})
+		})
+	}
+	for _, u := range m.Upstreams {
+		migrate("upstream", u.ID, func(legacyID string) error {
+			return cluster.Upstream().Delete(ctx, &apisixv1.Upstream{Metadata: apisixv1.Metadata{ID: legacyID, Name: u.Name}})
+		})
+	}
+	for _, ssl := range m.SSLs {
+		migrate("ssl", ssl.ID, func(legacyID string) error {
+			return cluster.SSL().Delete(ctx, &apisixv1.Ssl{ID: legacyID})
+		})
+	}
+}
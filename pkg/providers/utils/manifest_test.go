@@ -15,10 +15,15 @@
 package utils
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/apache/apisix-ingress-controller/pkg/apisix"
+	"github.com/apache/apisix-ingress-controller/pkg/apisix/cache"
+	"github.com/apache/apisix-ingress-controller/pkg/id"
 	apisixv1 "github.com/apache/apisix-ingress-controller/pkg/types/apisix/v1"
 )
 
@@ -324,3 +329,124 @@ func TestManifestDiff(t *testing.T) {
 	assert.Nil(t, updated.Upstreams)
 	assert.Nil(t, updated.PluginConfigs)
 }
+
+type fakeRoute struct {
+	apisix.Route
+	deleted []string
+}
+
+func (r *fakeRoute) Delete(_ context.Context, obj *apisixv1.Route) error {
+	r.deleted = append(r.deleted, obj.ID)
+	return nil
+}
+
+type fakeUpstream struct {
+	apisix.Upstream
+	deleted []string
+	err     error
+}
+
+func (u *fakeUpstream) Delete(_ context.Context, obj *apisixv1.Upstream) error {
+	if u.err != nil {
+		return u.err
+	}
+	u.deleted = append(u.deleted, obj.ID)
+	return nil
+}
+
+type fakeCluster struct {
+	apisix.Cluster
+	route    *fakeRoute
+	upstream *fakeUpstream
+}
+
+func (c *fakeCluster) Route() apisix.Route {
+	return c.route
+}
+
+func (c *fakeCluster) Upstream() apisix.Upstream {
+	return c.upstream
+}
+
+type fakeAPISIX struct {
+	apisix.APISIX
+	cluster *fakeCluster
+}
+
+func (a *fakeAPISIX) Cluster(string) apisix.Cluster {
+	return a.cluster
+}
+
+func TestMigrateLegacyObjects(t *testing.T) {
+	assert.Nil(t, id.SetScheme(id.SchemeSHA256, true))
+	defer func() {
+		assert.Nil(t, id.SetScheme(id.SchemeCRC32, false))
+	}()
+
+	cluster := &fakeCluster{
+		route:    &fakeRoute{},
+		upstream: &fakeUpstream{err: errors.New("unavailable")},
+	}
+	m := &Manifest{
+		Routes: []*apisixv1.Route{
+			{Metadata: apisixv1.Metadata{ID: id.GenID("default_route_rule1")}},
+		},
+		Upstreams: []*apisixv1.Upstream{
+			{Metadata: apisixv1.Metadata{ID: id.GenID("default_svc_80")}},
+		},
+	}
+
+	MigrateLegacyObjects(context.Background(), &fakeAPISIX{cluster: cluster}, "default", m)
+	assert.Equal(t, []string{id.GenLegacyID("default_route_rule1")}, cluster.route.deleted)
+	_, ok := id.LegacyID("default", m.Routes[0].ID)
+	assert.False(t, ok, "the migrated route shouldn't be removed again")
+	_, ok = id.LegacyID("default", m.Upstreams[0].ID)
+	assert.True(t, ok, "the upstream failed to be removed should be retried")
+
+	cluster.upstream.err = nil
+	MigrateLegacyObjects(context.Background(), &fakeAPISIX{cluster: cluster}, "default", m)
+	assert.Len(t, cluster.route.deleted, 1)
+	assert.Equal(t, []string{id.GenLegacyID("default_svc_80")}, cluster.upstream.deleted)
+	_, ok = id.LegacyID("default", m.Upstreams[0].ID)
+	assert.False(t, ok)
+}
+
+func TestMigrateSharedLegacyUpstream(t *testing.T) {
+	assert.Nil(t, id.SetScheme(id.SchemeSHA256, true))
+	defer func() {
+		assert.Nil(t, id.SetScheme(id.SchemeCRC32, false))
+	}()
+
+	ups := &apisixv1.Upstream{Metadata: apisixv1.Metadata{ID: id.GenID("default_svc_80")}}
+	// Two routes share the upstream.
+	foo := &Manifest{
+		Routes:    []*apisixv1.Route{{Metadata: apisixv1.Metadata{ID: id.GenID("default_foo_rule1")}}},
+		Upstreams: []*apisixv1.Upstream{ups},
+	}
+	bar := &Manifest{
+		Routes:    []*apisixv1.Route{{Metadata: apisixv1.Metadata{ID: id.GenID("default_bar_rule1")}}},
+		Upstreams: []*apisixv1.Upstream{ups},
+	}
+	a := &fakeCluster{route: &fakeRoute{}, upstream: &fakeUpstream{err: cache.ErrStillInUse}}
+	b := &fakeCluster{route: &fakeRoute{}, upstream: &fakeUpstream{}}
+
+	// The legacy route of bar still refers to the legacy upstream.
+	MigrateLegacyObjects(context.Background(), &fakeAPISIX{cluster: a}, "a", foo)
+	assert.Equal(t, []string{id.GenLegacyID("default_foo_rule1")}, a.route.deleted)
+	_, ok := id.LegacyID("a", ups.ID)
+	assert.True(t, ok, "the upstream still in use should be removed later")
+
+	a.upstream.err = nil
+	MigrateLegacyObjects(context.Background(), &fakeAPISIX{cluster: a}, "a", bar)
+	assert.Equal(t, []string{id.GenLegacyID("default_foo_rule1"), id.GenLegacyID("default_bar_rule1")}, a.route.deleted)
+	assert.Equal(t, []string{id.GenLegacyID("default_svc_80")}, a.upstream.deleted)
+	_, ok = id.LegacyID("a", ups.ID)
+	assert.False(t, ok)
+
+	// The migration of a cluster doesn't affect the others.
+	_, ok = id.LegacyID("b", ups.ID)
+	assert.True(t, ok)
+	MigrateLegacyObjects(context.Background(), &fakeAPISIX{cluster: b}, "b", foo)
+	assert.Equal(t, []string{id.GenLegacyID("default_foo_rule1")}, b.route.deleted)
+	assert.Equal(t, []string{id.GenLegacyID("default_svc_80")}, b.upstream.deleted)
+}
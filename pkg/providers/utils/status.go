@@ -27,6 +27,9 @@ import (
 const (
 	ConditionType        = "ResourcesAvailable"
 	CommonSuccessMessage = "Sync Successfully"
+	// NodesHealthyConditionType is the condition reflecting the health
+	// status of the upstream nodes reported by APISIX
+	NodesHealthyConditionType = "NodesHealthy"
 
 	// Component is used for event component
 	Component = "ApisixIngress"
@@ -38,6 +41,18 @@ const (
 	ResourceSyncAborted = "ResourceSyncAborted"
 	// MessageResourceFailed is used to report error
 	MessageResourceFailed = "%s synced failed, with error: %s"
+	// AnnotationsInvalid is used when some annotations of a resource failed to parse
+	AnnotationsInvalid = "InvalidAnnotations"
+	// ClusterUnhealthy is used when an APISIX cluster failed the health check
+	ClusterUnhealthy = "ClusterUnhealthy"
+	// CertificateExpiring is used when a certificate is close to expiry
+	CertificateExpiring = "CertificateExpiring"
+	// CertificateHostsNotCovered is used when some hosts are not covered by the certificate
+	CertificateHostsNotCovered = "CertificateHostsNotCovered"
+	// UpstreamNodesHealthy is used when all the upstream nodes passed the health check
+	UpstreamNodesHealthy = "UpstreamNodesHealthy"
+	// UpstreamNodesUnhealthy is used when some upstream nodes failed the health check
+	UpstreamNodesUnhealthy = "UpstreamNodesUnhealthy"
 )
 
 // RecorderEvent recorder events for resources
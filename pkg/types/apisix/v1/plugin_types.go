@@ -31,9 +31,17 @@ type TrafficSplitConfig struct {
 // TrafficSplitConfigRule is the rule config in traffic-split plugin config.
 // +k8s:deepcopy-gen=true
 type TrafficSplitConfigRule struct {
+	Match             []TrafficSplitConfigRuleMatch            `json:"match,omitempty"`
 	WeightedUpstreams []TrafficSplitConfigRuleWeightedUpstream `json:"weighted_upstreams"`
 }
 
+// TrafficSplitConfigRuleMatch is the match condition of the traffic split
+// plugin rule, the rule applies only when the vars are satisfied.
+// +k8s:deepcopy-gen=true
+type TrafficSplitConfigRuleMatch struct {
+	Vars Vars `json:"vars,omitempty"`
+}
+
 // TrafficSplitConfigRuleWeightedUpstream is the weighted upstream config in
 // the traffic split plugin rule.
 // +k8s:deepcopy-gen=true
@@ -139,6 +147,7 @@ type WolfRBACConsumerConfig struct {
 type RewriteConfig struct {
 	RewriteTarget      string   `json:"uri,omitempty"`
 	RewriteTargetRegex []string `json:"regex_uri,omitempty"`
+	Host               string   `json:"host,omitempty"`
 	Headers            Headers  `json:"headers,omitempty"`
 }
 
@@ -171,6 +180,50 @@ type ForwardAuthConfig struct {
 	ClientHeaders   []string `json:"client_headers,omitempty"`
 }
 
+// LimitReqConfig is the rule config for limit-req plugin.
+// +k8s:deepcopy-gen=true
+type LimitReqConfig struct {
+	Rate         int    `json:"rate"`
+	Burst        int    `json:"burst"`
+	Key          string `json:"key"`
+	KeyType      string `json:"key_type,omitempty"`
+	RejectedCode int    `json:"rejected_code,omitempty"`
+	NoDelay      bool   `json:"nodelay,omitempty"`
+}
+
+// LimitCountConfig is the rule config for limit-count plugin.
+// +k8s:deepcopy-gen=true
+type LimitCountConfig struct {
+	Count        int    `json:"count"`
+	TimeWindow   int    `json:"time_window"`
+	Key          string `json:"key"`
+	KeyType      string `json:"key_type,omitempty"`
+	RejectedCode int    `json:"rejected_code,omitempty"`
+}
+
+// LimitConnConfig is the rule config for limit-conn plugin.
+// +k8s:deepcopy-gen=true
+type LimitConnConfig struct {
+	Conn             int     `json:"conn"`
+	Burst            int     `json:"burst"`
+	DefaultConnDelay float64 `json:"default_conn_delay"`
+	Key              string  `json:"key"`
+	KeyType          string  `json:"key_type,omitempty"`
+	RejectedCode     int     `json:"rejected_code,omitempty"`
+}
+
+// ClientControlConfig is the rule config for client-control plugin.
+// +k8s:deepcopy-gen=true
+type ClientControlConfig struct {
+	MaxBodySize int64 `json:"max_body_size"`
+}
+
+// ProxyControlConfig is the rule config for proxy-control plugin.
+// +k8s:deepcopy-gen=true
+type ProxyControlConfig struct {
+	RequestBuffering bool `json:"request_buffering"`
+}
+
 // BasicAuthConfig is the rule config for basic-auth plugin.
 // +k8s:deepcopy-gen=true
 type BasicAuthConfig struct {
@@ -122,6 +122,7 @@ type Route struct {
 	UpstreamId      string           `json:"upstream_id,omitempty" yaml:"upstream_id,omitempty"`
 	Plugins         Plugins          `json:"plugins,omitempty" yaml:"plugins,omitempty"`
 	PluginConfigId  string           `json:"plugin_config_id,omitempty" yaml:"plugin_config_id,omitempty"`
+	ServiceId       string           `json:"service_id,omitempty" yaml:"service_id,omitempty"`
 	FilterFunc      string           `json:"filter_func,omitempty" yaml:"filter_func,omitempty"`
 }
 
@@ -385,6 +386,9 @@ type UpstreamNode struct {
 	Host   string `json:"host,omitempty" yaml:"host,omitempty"`
 	Port   int    `json:"port,omitempty" yaml:"port,omitempty"`
 	Weight int    `json:"weight,omitempty" yaml:"weight,omitempty"`
+	// Priority groups the nodes, nodes with lower priority are only
+	// used when all the nodes with higher priority are unavailable.
+	Priority int `json:"priority,omitempty" yaml:"priority,omitempty"`
 }
 
 // UpstreamHealthCheck defines the active and/or passive health check for an Upstream,
@@ -512,6 +516,16 @@ type PluginConfig struct {
 	Plugins  Plugins `json:"plugins" yaml:"plugins"`
 }
 
+// Service apisix service object, which holds the plugins and the upstream
+// shared by routes.
+// +k8s:deepcopy-gen=true
+type Service struct {
+	Metadata `json:",inline" yaml:",inline"`
+
+	UpstreamId string  `json:"upstream_id,omitempty" yaml:"upstream_id,omitempty"`
+	Plugins    Plugins `json:"plugins,omitempty" yaml:"plugins,omitempty"`
+}
+
 type PluginMetadata struct {
 	Name     string
 	Metadata map[string]any
@@ -585,6 +599,19 @@ func NewDefaultPluginConfig() *PluginConfig {
 	}
 }
 
+// NewDefaultService returns an empty Service with default values.
+func NewDefaultService() *Service {
+	return &Service{
+		Metadata: Metadata{
+			Desc: "Created by apisix-ingress-controller, DO NOT modify it manually",
+			Labels: map[string]string{
+				"managed-by": "apisix-ingress-controller",
+			},
+		},
+		Plugins: make(Plugins),
+	}
+}
+
 // NewDefaultGlobalRule returns an empty PluginConfig with default values.
 func NewDefaultGlobalRule() *GlobalRule {
 	return &GlobalRule{
@@ -696,6 +723,24 @@ func ComposePluginConfigName(namespace, name string) string {
 	return buf.String()
 }
 
+// ComposeServiceName uses namespace, name and rule name to compose
+// the service name.
+func ComposeServiceName(namespace, name string, rule string) string {
+	// FIXME Use sync.Pool to reuse this buffer if the upstream
+	// name composing code path is hot.
+	p := make([]byte, 0, len(namespace)+len(name)+len(rule)+10)
+	buf := bytes.NewBuffer(p)
+
+	buf.WriteString(namespace)
+	buf.WriteByte('_')
+	buf.WriteString(name)
+	buf.WriteByte('_')
+	buf.WriteString(rule)
+	buf.WriteString("_service")
+
+	return buf.String()
+}
+
 // ComposeGlobalRuleName uses namespace, name to compose
 // the global_rule name.
 func ComposeGlobalRuleName(namespace, name string) string {
@@ -730,3 +775,47 @@ func (s *Schema) DeepCopy() *Schema {
 	s.DeepCopyInto(out)
 	return out
 }
+
+const (
+	// NodeStatusHealthy means the node passed the health check.
+	NodeStatusHealthy = "healthy"
+	// NodeStatusMostlyHealthy means the node passed the health check
+	// but failed recently.
+	NodeStatusMostlyHealthy = "mostly_healthy"
+	// NodeStatusMostlyUnhealthy means the node failed the health check
+	// but passed recently.
+	NodeStatusMostlyUnhealthy = "mostly_unhealthy"
+	// NodeStatusUnhealthy means the node failed the health check.
+	NodeStatusUnhealthy = "unhealthy"
+)
+
+// UpstreamHealthStatus is the health status of the upstream nodes reported
+// by the health checker of APISIX.
+type UpstreamHealthStatus struct {
+	// ID is the id of the upstream.
+	ID    string                     `json:"id" yaml:"id"`
+	Nodes []UpstreamNodeHealthStatus `json:"nodes" yaml:"nodes"`
+}
+
+// UpstreamNodeHealthStatus is the health status of an upstream node.
+type UpstreamNodeHealthStatus struct {
+	Host   string `json:"ip" yaml:"ip"`
+	Port   int    `json:"port" yaml:"port"`
+	Status string `json:"status" yaml:"status"`
+}
+
+// Unhealthy tells whether the node is considered unhealthy by APISIX.
+func (s *UpstreamNodeHealthStatus) Unhealthy() bool {
+	return s.Status == NodeStatusUnhealthy || s.Status == NodeStatusMostlyUnhealthy
+}
+
+// UnhealthyNodes returns the unhealthy nodes of the upstream.
+func (s *UpstreamHealthStatus) UnhealthyNodes() []UpstreamNodeHealthStatus {
+	var nodes []UpstreamNodeHealthStatus
+	for _, node := range s.Nodes {
+		if node.Unhealthy() {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
@@ -88,6 +88,22 @@ func (in *CSRFConfig) DeepCopy() *CSRFConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientControlConfig) DeepCopyInto(out *ClientControlConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientControlConfig.
+func (in *ClientControlConfig) DeepCopy() *ClientControlConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientControlConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Consumer) DeepCopyInto(out *Consumer) {
 	*out = *in
@@ -287,6 +303,54 @@ func (in *LDAPAuthConsumerConfig) DeepCopy() *LDAPAuthConsumerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LimitConnConfig) DeepCopyInto(out *LimitConnConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LimitConnConfig.
+func (in *LimitConnConfig) DeepCopy() *LimitConnConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LimitConnConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LimitCountConfig) DeepCopyInto(out *LimitCountConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LimitCountConfig.
+func (in *LimitCountConfig) DeepCopy() *LimitCountConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LimitCountConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LimitReqConfig) DeepCopyInto(out *LimitReqConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LimitReqConfig.
+func (in *LimitReqConfig) DeepCopy() *LimitReqConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LimitReqConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Metadata) DeepCopyInto(out *Metadata) {
 	*out = *in
@@ -349,6 +413,22 @@ func (in *PluginConfig) DeepCopy() *PluginConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyControlConfig) DeepCopyInto(out *ProxyControlConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyControlConfig.
+func (in *ProxyControlConfig) DeepCopy() *ProxyControlConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyControlConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RedirectConfig) DeepCopyInto(out *RedirectConfig) {
 	*out = *in
@@ -496,6 +576,24 @@ func (in *Route) DeepCopy() *Route {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Service) DeepCopyInto(out *Service) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.Plugins.DeepCopyInto(&out.Plugins)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Service.
+func (in *Service) DeepCopy() *Service {
+	if in == nil {
+		return nil
+	}
+	out := new(Service)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Ssl) DeepCopyInto(out *Ssl) {
 	*out = *in
@@ -605,6 +703,13 @@ func (in *TrafficSplitConfig) DeepCopy() *TrafficSplitConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TrafficSplitConfigRule) DeepCopyInto(out *TrafficSplitConfigRule) {
 	*out = *in
+	if in.Match != nil {
+		in, out := &in.Match, &out.Match
+		*out = make([]TrafficSplitConfigRuleMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.WeightedUpstreams != nil {
 		in, out := &in.WeightedUpstreams, &out.WeightedUpstreams
 		*out = make([]TrafficSplitConfigRuleWeightedUpstream, len(*in))
@@ -623,6 +728,35 @@ func (in *TrafficSplitConfigRule) DeepCopy() *TrafficSplitConfigRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficSplitConfigRuleMatch) DeepCopyInto(out *TrafficSplitConfigRuleMatch) {
+	*out = *in
+	if in.Vars != nil {
+		in, out := &in.Vars, &out.Vars
+		*out = make(Vars, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make([]StringOrSlice, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficSplitConfigRuleMatch.
+func (in *TrafficSplitConfigRuleMatch) DeepCopy() *TrafficSplitConfigRuleMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficSplitConfigRuleMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TrafficSplitConfigRuleWeightedUpstream) DeepCopyInto(out *TrafficSplitConfigRuleWeightedUpstream) {
 	*out = *in
@@ -198,6 +198,7 @@ rules:
     - gateway.networking.k8s.io
     resources:
     - httproutes
+    - grpcroutes
     - tlsroutes
     - tcproutes
     - gateways